@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetActivationRamp returns the activation ramp of the ScaledObject, or nil if it isn't set
+func (so *ScaledObject) GetActivationRamp() *ActivationRamp {
+	if so.Spec.Advanced == nil {
+		return nil
+	}
+	return so.Spec.Advanced.ActivationRamp
+}
+
+// GetInitialReplicas returns the replica count the ScaleTarget is activated with,
+// never lower than minReplicaCount nor 1
+func (r *ActivationRamp) GetInitialReplicas(minReplicaCount *int32) int32 {
+	initialReplicas := max(r.InitialReplicas, 1)
+	if minReplicaCount != nil {
+		initialReplicas = max(initialReplicas, *minReplicaCount)
+	}
+	return initialReplicas
+}
+
+// NextStep returns the replica count of the step following currentReplicas, capped at targetReplicas
+func (r *ActivationRamp) NextStep(currentReplicas, targetReplicas int32) int32 {
+	return min(currentReplicas+max(r.StepReplicas, 1), targetReplicas)
+}
+
+// IsStepDue returns whether the step interval elapsed since the last step of the ramp
+func (r *ActivationRamp) IsStepDue(status *ActivationRampStatus, now time.Time) bool {
+	return !status.LastStepTime.Add(r.StepInterval.Duration).After(now)
+}
+
+// Validate checks the replica counts and the step interval of the activation ramp
+func (r *ActivationRamp) Validate() error {
+	if r.InitialReplicas < 1 {
+		return fmt.Errorf("initialReplicas=%d must be greater than 0", r.InitialReplicas)
+	}
+	if r.StepReplicas < 1 {
+		return fmt.Errorf("stepReplicas=%d must be greater than 0", r.StepReplicas)
+	}
+	if r.StepInterval.Duration <= 0 {
+		return fmt.Errorf("stepInterval=%s must be greater than 0", r.StepInterval.Duration)
+	}
+	return nil
+}
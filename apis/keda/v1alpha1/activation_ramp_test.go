@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestActivationRampSteps(t *testing.T) {
+	ramp := &ActivationRamp{InitialReplicas: 2, StepReplicas: 3, StepInterval: metav1.Duration{Duration: time.Minute}}
+
+	assert.Equal(t, int32(2), ramp.GetInitialReplicas(nil))
+	assert.Equal(t, int32(2), ramp.GetInitialReplicas(ptr.To[int32](0)))
+	assert.Equal(t, int32(4), ramp.GetInitialReplicas(ptr.To[int32](4)))
+
+	assert.Equal(t, int32(5), ramp.NextStep(2, 10))
+	assert.Equal(t, int32(10), ramp.NextStep(8, 10))
+
+	now := time.Now()
+	assert.False(t, ramp.IsStepDue(&ActivationRampStatus{LastStepTime: metav1.NewTime(now.Add(-30 * time.Second))}, now))
+	assert.True(t, ramp.IsStepDue(&ActivationRampStatus{LastStepTime: metav1.NewTime(now.Add(-time.Minute))}, now))
+}
+
+func TestValidateActivationRamp(t *testing.T) {
+	tests := []struct {
+		name           string
+		ramp           *ActivationRamp
+		maxReplicas    *int32
+		expectedErrMsg string
+	}{
+		{
+			name: "no ramp",
+		},
+		{
+			name:        "valid ramp",
+			ramp:        &ActivationRamp{InitialReplicas: 1, StepReplicas: 2, StepInterval: metav1.Duration{Duration: 30 * time.Second}},
+			maxReplicas: ptr.To[int32](10),
+		},
+		{
+			name:           "missing initial replicas",
+			ramp:           &ActivationRamp{StepReplicas: 2, StepInterval: metav1.Duration{Duration: 30 * time.Second}},
+			expectedErrMsg: "activationRamp: initialReplicas=0 must be greater than 0",
+		},
+		{
+			name:           "missing step replicas",
+			ramp:           &ActivationRamp{InitialReplicas: 1, StepInterval: metav1.Duration{Duration: 30 * time.Second}},
+			expectedErrMsg: "activationRamp: stepReplicas=0 must be greater than 0",
+		},
+		{
+			name:           "missing step interval",
+			ramp:           &ActivationRamp{InitialReplicas: 1, StepReplicas: 2},
+			expectedErrMsg: "activationRamp: stepInterval=0s must be greater than 0",
+		},
+		{
+			name:           "initial replicas above max replica count",
+			ramp:           &ActivationRamp{InitialReplicas: 5, StepReplicas: 2, StepInterval: metav1.Duration{Duration: 30 * time.Second}},
+			maxReplicas:    ptr.To[int32](4),
+			expectedErrMsg: "activationRamp: initialReplicas=5 must not be greater than MaxReplicaCount=4",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{Spec: ScaledObjectSpec{MaxReplicaCount: test.maxReplicas, Advanced: &AdvancedConfig{ActivationRamp: test.ramp}}}
+			err := validateActivationRamp(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -34,6 +34,8 @@ const (
 	ConditionFallback ConditionType = "Fallback"
 	// ConditionPaused specifies that the resource is paused.
 	ConditionPaused ConditionType = "Paused"
+	// ConditionWaiting specifies that the activation of the resource is held back by its readiness gate.
+	ConditionWaiting ConditionType = "Waiting"
 )
 
 const (
@@ -45,6 +47,12 @@ const (
 	ScaledObjectConditionPausedReason = "ScaledObjectPaused"
 	// ScaledObjectConditionPausedMessage defines the default Message for paused ScaledObject
 	ScaledObjectConditionPausedMessage = "ScaledObject is paused"
+	// ScaledObjectConditionWaitingReason defines the Reason for ScaledObject waiting for its readiness gate
+	ScaledObjectConditionWaitingReason = "ReadinessGateNotReady"
+	// ScaledObjectConditionNotWaitingReason defines the Reason for ScaledObject whose readiness gate is open
+	ScaledObjectConditionNotWaitingReason = "ReadinessGateReady"
+	// ScaledObjectConditionNotWaitingMessage defines the default Message for ScaledObject whose readiness gate is open
+	ScaledObjectConditionNotWaitingMessage = "Dependencies of the ScaledObject are ready"
 )
 
 const (
@@ -88,6 +96,7 @@ func (c *Conditions) AreInitialized() bool {
 	foundActive := false
 	foundFallback := false
 	foundPaused := false
+	foundWaiting := false
 	if *c != nil {
 		for _, condition := range *c {
 			if condition.Type == ConditionReady {
@@ -113,14 +122,20 @@ func (c *Conditions) AreInitialized() bool {
 				break
 			}
 		}
+		for _, condition := range *c {
+			if condition.Type == ConditionWaiting {
+				foundWaiting = true
+				break
+			}
+		}
 	}
 
-	return foundReady && foundActive && foundFallback && foundPaused
+	return foundReady && foundActive && foundFallback && foundPaused && foundWaiting
 }
 
 // GetInitializedConditions returns Conditions initialized to the default -> Status: Unknown
 func GetInitializedConditions() *Conditions {
-	return &Conditions{{Type: ConditionReady, Status: metav1.ConditionUnknown}, {Type: ConditionActive, Status: metav1.ConditionUnknown}, {Type: ConditionFallback, Status: metav1.ConditionUnknown}, {Type: ConditionPaused, Status: metav1.ConditionUnknown}}
+	return &Conditions{{Type: ConditionReady, Status: metav1.ConditionUnknown}, {Type: ConditionActive, Status: metav1.ConditionUnknown}, {Type: ConditionFallback, Status: metav1.ConditionUnknown}, {Type: ConditionPaused, Status: metav1.ConditionUnknown}, {Type: ConditionWaiting, Status: metav1.ConditionUnknown}}
 }
 
 // IsTrue is true if the condition is True
@@ -179,6 +194,14 @@ func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason st
 	c.setCondition(ConditionPaused, status, reason, message)
 }
 
+// SetWaitingCondition modifies Waiting Condition according to input parameters
+func (c *Conditions) SetWaitingCondition(status metav1.ConditionStatus, reason string, message string) {
+	if *c == nil {
+		c = GetInitializedConditions()
+	}
+	c.setCondition(ConditionWaiting, status, reason, message)
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -211,6 +234,14 @@ func (c *Conditions) GetPausedCondition() Condition {
 	return c.getCondition(ConditionPaused)
 }
 
+// GetWaitingCondition returns Condition of type Waiting
+func (c *Conditions) GetWaitingCondition() Condition {
+	if *c == nil {
+		c = GetInitializedConditions()
+	}
+	return c.getCondition(ConditionWaiting)
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ActiveUntil returns whether the pause is in force at the given time and when it ends,
+// ie. the cron expression fired less than duration ago
+func (p *PauseSchedule) ActiveUntil(now time.Time) (time.Time, bool, error) {
+	schedule, location, err := p.parse()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	start := schedule.Next(now.In(location).Add(-p.Duration.Duration))
+	if start.After(now) {
+		return time.Time{}, false, nil
+	}
+	return start.Add(p.Duration.Duration), true, nil
+}
+
+// NextTransition returns the next time the pause starts or ends
+func (p *PauseSchedule) NextTransition(now time.Time) (time.Time, error) {
+	until, active, err := p.ActiveUntil(now)
+	if err != nil || active {
+		return until, err
+	}
+	schedule, location, err := p.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(now.In(location)), nil
+}
+
+// Validate checks the cron expression, the duration and the timezone of the pause schedule
+func (p *PauseSchedule) Validate() error {
+	if p.Duration.Duration <= 0 {
+		return fmt.Errorf("duration=%s must be greater than 0", p.Duration.Duration)
+	}
+	_, _, err := p.parse()
+	return err
+}
+
+func (p *PauseSchedule) parse() (cron.Schedule, *time.Location, error) {
+	location := time.UTC
+	if p.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(p.Timezone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to load timezone %q: %w", p.Timezone, err)
+		}
+	}
+	schedule, err := scheduleCronParser.Parse(p.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing schedule %q: %w", p.Schedule, err)
+	}
+	return schedule, location, nil
+}
+
+// getActivePauseSchedule returns the first pause schedule in force at the given time and when it ends,
+// invalid schedules are ignored as they are rejected by the admission webhook
+func getActivePauseSchedule(schedules []PauseSchedule, now time.Time) (*PauseSchedule, time.Time) {
+	for i := range schedules {
+		until, active, err := schedules[i].ActiveUntil(now)
+		if err == nil && active {
+			return &schedules[i], until
+		}
+	}
+	return nil, time.Time{}
+}
+
+// getNextPauseScheduleTransition returns the next time any of the pause schedules starts or ends,
+// the second value is false if there are no valid schedules
+func getNextPauseScheduleTransition(schedules []PauseSchedule, now time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for i := range schedules {
+		transition, err := schedules[i].NextTransition(now)
+		if err != nil {
+			continue
+		}
+		if !found || transition.Before(next) {
+			next = transition
+			found = true
+		}
+	}
+	return next, found
+}
+
+func validatePauseSchedules(schedules []PauseSchedule, pausedReplicaCountSupported bool) error {
+	for i, schedule := range schedules {
+		if schedule.PausedReplicaCount != nil {
+			if !pausedReplicaCountSupported {
+				return fmt.Errorf("pauseSchedules[%d]: pausedReplicaCount is not supported", i)
+			}
+			if *schedule.PausedReplicaCount < 0 {
+				return fmt.Errorf("pauseSchedules[%d]: pausedReplicaCount=%d must not be negative", i, *schedule.PausedReplicaCount)
+			}
+		}
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf("pauseSchedules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetActivePauseSchedule returns the pause schedule of the ScaledObject in force at the given time and when it ends
+func (so *ScaledObject) GetActivePauseSchedule(now time.Time) (*PauseSchedule, time.Time) {
+	return getActivePauseSchedule(so.Spec.PauseSchedules, now)
+}
+
+// GetNextPauseScheduleTransition returns the next time a pause schedule of the ScaledObject starts or ends
+func (so *ScaledObject) GetNextPauseScheduleTransition(now time.Time) (time.Time, bool) {
+	return getNextPauseScheduleTransition(so.Spec.PauseSchedules, now)
+}
+
+// NeedToBePaused will check whether ScaledObject needs to be paused at the given time,
+// the paused annotations take precedence over the pause schedules
+func (so *ScaledObject) NeedToBePaused(now time.Time) bool {
+	if so.HasPausedAnnotation() {
+		return so.NeedToBePausedByAnnotation()
+	}
+	schedule, _ := so.GetActivePauseSchedule(now)
+	if schedule == nil {
+		return false
+	}
+	if schedule.PausedReplicaCount != nil {
+		// as with PausedReplicasAnnotation, the ScaledObject is paused once scaled to the paused replica count
+		return so.Status.PausedReplicaCount != nil
+	}
+	return true
+}
+
+// GetActivePauseSchedule returns the pause schedule of the ScaledJob in force at the given time and when it ends
+func (sj *ScaledJob) GetActivePauseSchedule(now time.Time) (*PauseSchedule, time.Time) {
+	return getActivePauseSchedule(sj.Spec.PauseSchedules, now)
+}
+
+// GetNextPauseScheduleTransition returns the next time a pause schedule of the ScaledJob starts or ends
+func (sj *ScaledJob) GetNextPauseScheduleTransition(now time.Time) (time.Time, bool) {
+	return getNextPauseScheduleTransition(sj.Spec.PauseSchedules, now)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var nightlyRelease = PauseSchedule{
+	Schedule: "0 22 * * *",
+	Duration: metav1.Duration{Duration: 2 * time.Hour},
+}
+
+func TestPauseScheduleActiveUntil(t *testing.T) {
+	tests := []struct {
+		name           string
+		now            time.Time
+		expectedActive bool
+		expectedUntil  time.Time
+		expectedNext   time.Time
+	}{
+		{
+			name:         "before the pause",
+			now:          time.Date(2024, 3, 4, 21, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2024, 3, 4, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "at the start of the pause",
+			now:            time.Date(2024, 3, 4, 22, 0, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedUntil:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+			expectedNext:   time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "during the pause",
+			now:            time.Date(2024, 3, 4, 23, 30, 0, 0, time.UTC),
+			expectedActive: true,
+			expectedUntil:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+			expectedNext:   time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "at the end of the pause",
+			now:          time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2024, 3, 5, 22, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			until, active, err := nightlyRelease.ActiveUntil(test.now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedActive, active)
+			if test.expectedActive {
+				assert.True(t, test.expectedUntil.Equal(until), "expected %s, got %s", test.expectedUntil, until)
+			}
+
+			next, err := nightlyRelease.NextTransition(test.now)
+			assert.NoError(t, err)
+			assert.True(t, test.expectedNext.Equal(next), "expected %s, got %s", test.expectedNext, next)
+		})
+	}
+}
+
+func TestScaledObjectNeedToBePaused(t *testing.T) {
+	during := time.Date(2024, 3, 4, 23, 0, 0, 0, time.UTC)
+	outside := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	so := &ScaledObject{Spec: ScaledObjectSpec{PauseSchedules: []PauseSchedule{nightlyRelease}}}
+	assert.True(t, so.NeedToBePaused(during))
+	assert.False(t, so.NeedToBePaused(outside))
+
+	// the annotation overrides the schedule in both directions
+	so.Annotations = map[string]string{PausedAnnotation: "false"}
+	assert.False(t, so.NeedToBePaused(during))
+	so.Annotations = map[string]string{PausedAnnotation: "true"}
+	assert.True(t, so.NeedToBePaused(outside))
+
+	// with a paused replica count, the ScaledObject is paused once scaled to it
+	so.Annotations = nil
+	so.Spec.PauseSchedules[0].PausedReplicaCount = ptr.To[int32](1)
+	assert.False(t, so.NeedToBePaused(during))
+	so.Status.PausedReplicaCount = ptr.To[int32](1)
+	assert.True(t, so.NeedToBePaused(during))
+
+	next, found := so.GetNextPauseScheduleTransition(during)
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestValidatePauseSchedules(t *testing.T) {
+	tests := []struct {
+		name                        string
+		schedules                   []PauseSchedule
+		pausedReplicaCountSupported bool
+		expectedErrMsg              string
+	}{
+		{
+			name:      "valid schedule",
+			schedules: []PauseSchedule{nightlyRelease},
+		},
+		{
+			name:                        "paused replica count",
+			schedules:                   []PauseSchedule{{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}, PausedReplicaCount: ptr.To[int32](2)}},
+			pausedReplicaCountSupported: true,
+		},
+		{
+			name:           "paused replica count not supported",
+			schedules:      []PauseSchedule{{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}, PausedReplicaCount: ptr.To[int32](2)}},
+			expectedErrMsg: "pauseSchedules[0]: pausedReplicaCount is not supported",
+		},
+		{
+			name:           "missing duration",
+			schedules:      []PauseSchedule{{Schedule: "0 22 * * *"}},
+			expectedErrMsg: "pauseSchedules[0]: duration=0s must be greater than 0",
+		},
+		{
+			name:           "invalid schedule",
+			schedules:      []PauseSchedule{nightlyRelease, {Schedule: "tonight", Duration: metav1.Duration{Duration: time.Hour}}},
+			expectedErrMsg: `pauseSchedules[1]: error parsing schedule "tonight"`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := validatePauseSchedules(test.schedules, test.pausedReplicaCountSupported)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var scheduleCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// IsActive returns whether the window of the schedule is open at the given time,
+// ie. the window was started and the next end comes before the next start
+func (s *ReplicaSchedule) IsActive(now time.Time) (bool, error) {
+	nextStart, nextEnd, err := s.nextStartAndEnd(now)
+	if err != nil {
+		return false, err
+	}
+	return nextEnd.Before(nextStart), nil
+}
+
+// NextTransition returns the next time the window of the schedule opens or closes
+func (s *ReplicaSchedule) NextTransition(now time.Time) (time.Time, error) {
+	nextStart, nextEnd, err := s.nextStartAndEnd(now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if nextEnd.Before(nextStart) {
+		return nextEnd, nil
+	}
+	return nextStart, nil
+}
+
+// Validate checks the cron expressions and the timezone of the schedule
+func (s *ReplicaSchedule) Validate() error {
+	_, _, err := s.nextStartAndEnd(time.Now())
+	return err
+}
+
+func (s *ReplicaSchedule) nextStartAndEnd(now time.Time) (time.Time, time.Time, error) {
+	location := time.UTC
+	if s.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(s.Timezone)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("unable to load timezone %q: %w", s.Timezone, err)
+		}
+	}
+	start, err := scheduleCronParser.Parse(s.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("error parsing start %q: %w", s.Start, err)
+	}
+	end, err := scheduleCronParser.Parse(s.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("error parsing end %q: %w", s.End, err)
+	}
+	now = now.In(location)
+	return start.Next(now), end.Next(now), nil
+}
+
+// overrideReplicaCounts returns the given replica counts with the overrides of the schedule applied
+func (s *ReplicaSchedule) overrideReplicaCounts(minReplicaCount, maxReplicaCount, idleReplicaCount *int32) (*int32, *int32, *int32) {
+	if s.MinReplicaCount != nil {
+		minReplicaCount = s.MinReplicaCount
+	}
+	if s.MaxReplicaCount != nil {
+		maxReplicaCount = s.MaxReplicaCount
+	}
+	if s.IdleReplicaCount != nil {
+		idleReplicaCount = s.IdleReplicaCount
+	}
+	return minReplicaCount, maxReplicaCount, idleReplicaCount
+}
+
+func (so *ScaledObject) getSchedules() []ReplicaSchedule {
+	if so.Spec.Advanced == nil {
+		return nil
+	}
+	return so.Spec.Advanced.Schedules
+}
+
+// FindActiveSchedule returns the first schedule of the ScaledObject whose window is open at the given time,
+// or nil if there is none
+func (so *ScaledObject) FindActiveSchedule(now time.Time) (*ReplicaSchedule, error) {
+	schedules := so.getSchedules()
+	for i := range schedules {
+		active, err := schedules[i].IsActive(now)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", schedules[i].Name, err)
+		}
+		if active {
+			return &schedules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// NextScheduleTransition returns the next time the window of any schedule of the ScaledObject opens or closes,
+// the second value is false if the ScaledObject has no schedules
+func (so *ScaledObject) NextScheduleTransition(now time.Time) (time.Time, bool, error) {
+	var next time.Time
+	found := false
+	for _, schedule := range so.getSchedules() {
+		transition, err := schedule.NextTransition(now)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("schedule %q: %w", schedule.Name, err)
+		}
+		if !found || transition.Before(next) {
+			next = transition
+			found = true
+		}
+	}
+	return next, found, nil
+}
+
+// GetActiveSchedule returns the schedule reported as active in status.activeSchedule, or nil if there is none
+func (so *ScaledObject) GetActiveSchedule() *ReplicaSchedule {
+	if so.Status.ActiveSchedule == "" {
+		return nil
+	}
+	schedules := so.getSchedules()
+	for i := range schedules {
+		if schedules[i].Name == so.Status.ActiveSchedule {
+			return &schedules[i]
+		}
+	}
+	return nil
+}
+
+// GetMinReplicaCount returns the MinReplicaCount in force, overridden by the active schedule if any
+func (so *ScaledObject) GetMinReplicaCount() *int32 {
+	minReplicaCount, _, _ := so.getReplicaCounts()
+	return minReplicaCount
+}
+
+// GetMaxReplicaCount returns the MaxReplicaCount in force, overridden by the active schedule if any
+func (so *ScaledObject) GetMaxReplicaCount() *int32 {
+	_, maxReplicaCount, _ := so.getReplicaCounts()
+	return maxReplicaCount
+}
+
+// GetIdleReplicaCount returns the IdleReplicaCount in force, overridden by the active schedule if any
+func (so *ScaledObject) GetIdleReplicaCount() *int32 {
+	_, _, idleReplicaCount := so.getReplicaCounts()
+	return idleReplicaCount
+}
+
+func (so *ScaledObject) getReplicaCounts() (*int32, *int32, *int32) {
+	if schedule := so.GetActiveSchedule(); schedule != nil {
+		return schedule.overrideReplicaCounts(so.Spec.MinReplicaCount, so.Spec.MaxReplicaCount, so.Spec.IdleReplicaCount)
+	}
+	return so.Spec.MinReplicaCount, so.Spec.MaxReplicaCount, so.Spec.IdleReplicaCount
+}
+
+// IsScaledToZeroBySchedule returns whether the active schedule keeps the scale target scaled to zero
+func (so *ScaledObject) IsScaledToZeroBySchedule() bool {
+	schedule := so.GetActiveSchedule()
+	return schedule != nil && schedule.MaxReplicaCount != nil && *schedule.MaxReplicaCount == 0
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+var businessHours = ReplicaSchedule{
+	Name:            "business-hours",
+	Start:           "0 8 * * 1-5",
+	End:             "0 18 * * 1-5",
+	Timezone:        "Europe/Prague",
+	MinReplicaCount: ptr.To[int32](5),
+}
+
+var maintenance = ReplicaSchedule{
+	Name:            "maintenance",
+	Start:           "0 2 * * 0",
+	End:             "0 4 * * 0",
+	MaxReplicaCount: ptr.To[int32](0),
+}
+
+func TestReplicaScheduleIsActive(t *testing.T) {
+	prague, err := time.LoadLocation("Europe/Prague")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		expectedActive bool
+		expectedNext   time.Time
+	}{
+		{
+			name:           "before the window",
+			now:            time.Date(2024, 3, 4, 7, 30, 0, 0, prague), // Monday
+			expectedActive: false,
+			expectedNext:   time.Date(2024, 3, 4, 8, 0, 0, 0, prague),
+		},
+		{
+			name:           "within the window",
+			now:            time.Date(2024, 3, 4, 12, 0, 0, 0, prague),
+			expectedActive: true,
+			expectedNext:   time.Date(2024, 3, 4, 18, 0, 0, 0, prague),
+		},
+		{
+			name:           "after the window",
+			now:            time.Date(2024, 3, 4, 19, 0, 0, 0, prague),
+			expectedActive: false,
+			expectedNext:   time.Date(2024, 3, 5, 8, 0, 0, 0, prague),
+		},
+		{
+			name:           "weekend",
+			now:            time.Date(2024, 3, 9, 12, 0, 0, 0, prague), // Saturday
+			expectedActive: false,
+			expectedNext:   time.Date(2024, 3, 11, 8, 0, 0, 0, prague),
+		},
+		{
+			name:           "timezone of the schedule is used",
+			now:            time.Date(2024, 3, 4, 7, 30, 0, 0, time.UTC), // 8:30 in Prague
+			expectedActive: true,
+			expectedNext:   time.Date(2024, 3, 4, 18, 0, 0, 0, prague),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			active, err := businessHours.IsActive(test.now)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedActive, active)
+
+			next, err := businessHours.NextTransition(test.now)
+			assert.NoError(t, err)
+			assert.True(t, test.expectedNext.Equal(next), "expected %s, got %s", test.expectedNext, next)
+		})
+	}
+}
+
+func TestFindActiveSchedule(t *testing.T) {
+	so := &ScaledObject{Spec: ScaledObjectSpec{Advanced: &AdvancedConfig{Schedules: []ReplicaSchedule{maintenance, businessHours}}}}
+
+	schedule, err := so.FindActiveSchedule(time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC)) // Sunday
+	assert.NoError(t, err)
+	assert.Equal(t, "maintenance", schedule.Name)
+
+	schedule, err = so.FindActiveSchedule(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	next, found, err := so.NextScheduleTransition(time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2024, 3, 10, 4, 0, 0, 0, time.UTC), next.UTC())
+
+	_, found, err = (&ScaledObject{}).NextScheduleTransition(time.Now())
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	invalid := &ScaledObject{Spec: ScaledObjectSpec{Advanced: &AdvancedConfig{Schedules: []ReplicaSchedule{{Name: "invalid", Start: "never", End: "0 4 * * 0"}}}}}
+	_, err = invalid.FindActiveSchedule(time.Now())
+	assert.ErrorContains(t, err, `schedule "invalid": error parsing start "never"`)
+}
+
+func TestScheduleReplicaCountOverrides(t *testing.T) {
+	so := &ScaledObject{Spec: ScaledObjectSpec{
+		MinReplicaCount: ptr.To[int32](1),
+		MaxReplicaCount: ptr.To[int32](10),
+		Advanced:        &AdvancedConfig{Schedules: []ReplicaSchedule{maintenance, businessHours}},
+	}}
+
+	assert.Equal(t, int32(1), *so.GetHPAMinReplicas())
+	assert.Equal(t, int32(10), so.GetHPAMaxReplicas())
+	assert.False(t, so.IsScaledToZeroBySchedule())
+
+	so.Status.ActiveSchedule = "business-hours"
+	assert.Equal(t, int32(5), *so.GetMinReplicaCount())
+	assert.Equal(t, int32(5), *so.GetHPAMinReplicas())
+	assert.Equal(t, int32(10), so.GetHPAMaxReplicas())
+
+	so.Status.ActiveSchedule = "maintenance"
+	assert.Equal(t, int32(0), *so.GetMaxReplicaCount())
+	assert.Equal(t, int32(1), so.GetHPAMaxReplicas())
+	assert.True(t, so.IsScaledToZeroBySchedule())
+
+	// a schedule removed from the spec doesn't apply anymore
+	so.Status.ActiveSchedule = "removed"
+	assert.Nil(t, so.GetActiveSchedule())
+	assert.Equal(t, int32(1), *so.GetMinReplicaCount())
+}
+
+func TestCheckReplicaCountBoundsWithSchedules(t *testing.T) {
+	so := &ScaledObject{Spec: ScaledObjectSpec{
+		MinReplicaCount: ptr.To[int32](1),
+		MaxReplicaCount: ptr.To[int32](4),
+		Advanced:        &AdvancedConfig{Schedules: []ReplicaSchedule{maintenance}},
+	}}
+	assert.NoError(t, CheckReplicaCountBoundsAreValid(so))
+
+	so.Spec.Advanced.Schedules = append(so.Spec.Advanced.Schedules, businessHours)
+	assert.EqualError(t, CheckReplicaCountBoundsAreValid(so), `schedule "business-hours": MinReplicaCount=5 must be less than MaxReplicaCount=4`)
+}
+
+func TestValidateSchedules(t *testing.T) {
+	tests := []struct {
+		name           string
+		schedules      []ReplicaSchedule
+		expectedErrMsg string
+	}{
+		{
+			name:      "valid schedules",
+			schedules: []ReplicaSchedule{businessHours, maintenance},
+		},
+		{
+			name:           "missing name",
+			schedules:      []ReplicaSchedule{{Start: "0 8 * * *", End: "0 9 * * *"}},
+			expectedErrMsg: "schedule name is required",
+		},
+		{
+			name:           "duplicate name",
+			schedules:      []ReplicaSchedule{businessHours, businessHours},
+			expectedErrMsg: `schedule "business-hours" is defined multiple times`,
+		},
+		{
+			name:           "negative replica count",
+			schedules:      []ReplicaSchedule{{Name: "negative", Start: "0 8 * * *", End: "0 9 * * *", IdleReplicaCount: ptr.To[int32](-1)}},
+			expectedErrMsg: `schedule "negative": replica counts must not be negative`,
+		},
+		{
+			name:           "invalid end",
+			schedules:      []ReplicaSchedule{{Name: "invalid", Start: "0 8 * * *", End: "0 25 * * *"}},
+			expectedErrMsg: `schedule "invalid": error parsing end "0 25 * * *"`,
+		},
+		{
+			name:           "unknown timezone",
+			schedules:      []ReplicaSchedule{{Name: "invalid", Start: "0 8 * * *", End: "0 9 * * *", Timezone: "Mars/Olympus"}},
+			expectedErrMsg: `schedule "invalid": unable to load timezone "Mars/Olympus"`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSchedules(test.schedules)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
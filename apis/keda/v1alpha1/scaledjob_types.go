@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"reflect"
+
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -37,6 +39,7 @@ const (
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.conditions[?(@.type==\"Active\")].status"
 // +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=".status.conditions[?(@.type==\"Paused\")].status"
+// +kubebuilder:printcolumn:name="Fallback",type="string",JSONPath=".status.conditions[?(@.type==\"Fallback\")].status"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ScaledJob is the Schema for the scaledjobs API
@@ -70,6 +73,21 @@ type ScaledJobSpec struct {
 	// +optional
 	ScalingStrategy ScalingStrategy `json:"scalingStrategy,omitempty"`
 	Triggers        []ScaleTriggers `json:"triggers"`
+	// Fallback defines the number of jobs used when a trigger fails more than failureThreshold times,
+	// supported behaviors are static (replicas jobs) and lastKnownMetric
+	// +optional
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// PauseSchedules pause the autoscaling on a schedule, the paused annotation overrides them
+	// +optional
+	PauseSchedules []PauseSchedule `json:"pauseSchedules,omitempty"`
+	// ScalingModifiers combine the metrics of the triggers into a composite metric the number of jobs is computed from,
+	// with target as the average value per job. They replace scalingStrategy.multipleScalersCalculation
+	// +optional
+	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// JobTemplateParameters inject parameters computed for each job created into the jobs, so they can pick up
+	// distinct work such as a partition or a shard
+	// +optional
+	JobTemplateParameters *JobTemplateParameters `json:"jobTemplateParameters,omitempty"`
 }
 
 // ScaledJobStatus defines the observed state of ScaledJob
@@ -81,6 +99,16 @@ type ScaledJobStatus struct {
 	Conditions Conditions `json:"conditions,omitempty"`
 	// +optional
 	Paused string `json:"Paused,omitempty"`
+	// +optional
+	Health map[string]HealthStatus `json:"health,omitempty"`
+	// ScalingDecisions are the latest scaling decisions, oldest first, reported when
+	// the autoscaling.keda.sh/scaling-decision-history annotation is set
+	// +optional
+	ScalingDecisions []ScalingDecision `json:"scalingDecisions,omitempty"`
+	// CreatedJobCount is the number of jobs created for the ScaledJob, it is the counter parameter of the next job.
+	// It is only kept when jobTemplateParameters are defined
+	// +optional
+	CreatedJobCount int64 `json:"createdJobCount,omitempty"`
 }
 
 // ScaledJobList contains a list of ScaledJob
@@ -100,12 +128,50 @@ type ScalingStrategy struct {
 	CustomScalingQueueLengthDeduction *int32 `json:"customScalingQueueLengthDeduction,omitempty"`
 	// +optional
 	CustomScalingRunningJobPercentage string `json:"customScalingRunningJobPercentage,omitempty"`
+	// Expression computes the number of jobs to create with the expression strategy from queueLength (the number of jobs asked
+	// for by the triggers), runningJobCount, pendingJobCount, maxReplicaCount and minReplicaCount. The minReplicaCount jobs
+	// aren't counted in runningJobCount and maxReplicaCount
+	// +optional
+	Expression string `json:"expression,omitempty"`
 	// +optional
 	PendingPodConditions []string `json:"pendingPodConditions,omitempty"`
 	// +optional
 	MultipleScalersCalculation string `json:"multipleScalersCalculation,omitempty"`
 }
 
+// JobTemplateParameters defines the parameters injected into the jobs created by a ScaledJob
+type JobTemplateParameters struct {
+	// Env are environment variables added to the containers of the jobs
+	// +optional
+	Env []JobTemplateParameter `json:"env,omitempty"`
+	// Annotations are added to the jobs and their pods
+	// +optional
+	Annotations []JobTemplateParameter `json:"annotations,omitempty"`
+}
+
+// JobTemplateParameter is a parameter whose value is computed for each job from its source
+type JobTemplateParameter struct {
+	Name string `json:"name"`
+	// Source of the value: counter is the sequence number of the job among all the jobs created for the ScaledJob,
+	// index is the index of the job among the jobs created in the same polling interval, trigger is the name of the
+	// trigger the work hint of the job comes from and hint is the work hint given by the scaler, eg. a partition
+	// +kubebuilder:validation:Enum=counter;index;trigger;hint
+	Source JobTemplateParameterSource `json:"source"`
+	// Modulo maps the counter and index values onto [0, modulo), eg. the partitions of a topic
+	// +optional
+	Modulo *int64 `json:"modulo,omitempty"`
+}
+
+// JobTemplateParameterSource is the source of the value of a JobTemplateParameter
+type JobTemplateParameterSource string
+
+const (
+	JobTemplateParameterSourceCounter JobTemplateParameterSource = "counter"
+	JobTemplateParameterSourceIndex   JobTemplateParameterSource = "index"
+	JobTemplateParameterSourceTrigger JobTemplateParameterSource = "trigger"
+	JobTemplateParameterSourceHint    JobTemplateParameterSource = "hint"
+)
+
 // Rollout defines the strategy for job rollouts
 // +optional
 type Rollout struct {
@@ -143,6 +209,11 @@ func (s ScaledJob) MinReplicaCount() int64 {
 	return defaultScaledJobMinReplicaCount
 }
 
+// IsUsingModifiers determines whether scalingModifiers are defined or not
+func (s *ScaledJob) IsUsingModifiers() bool {
+	return !reflect.DeepEqual(s.Spec.ScalingModifiers, ScalingModifiers{})
+}
+
 func (s *ScaledJob) GenerateIdentifier() string {
 	return GenerateIdentifier("ScaledJob", s.Namespace, s.Name)
 }
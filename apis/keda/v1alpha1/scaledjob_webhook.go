@@ -0,0 +1,352 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metricscollector "github.com/kedacore/keda/v2/pkg/metricscollector/webhook"
+)
+
+var scaledjoblog = logf.Log.WithName("scaledjob-validation-webhook")
+
+const (
+	customScalingStrategy = "custom"
+	// ExpressionScalingStrategy computes the number of jobs to create with scalingStrategy.expression
+	ExpressionScalingStrategy = "expression"
+)
+
+// values accepted by the ScaledJob spec, an empty value selects the default behaviour
+var (
+	scaledJobScalingStrategies           = []string{"", "default", customScalingStrategy, "accurate", ExpressionScalingStrategy}
+	scaledJobMultipleScalersCalculations = []string{"", "max", "min", "avg", "sum"}
+	scaledJobRolloutStrategies           = []string{"", "default", "gradual"}
+	scaledJobRolloutPropagationPolicies  = []string{"", "background", "foreground"}
+	jobTemplateParameterSources          = []JobTemplateParameterSource{
+		JobTemplateParameterSourceCounter,
+		JobTemplateParameterSourceIndex,
+		JobTemplateParameterSourceTrigger,
+		JobTemplateParameterSourceHint,
+	}
+)
+
+func (sj *ScaledJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		WithValidator(&ScaledJobCustomValidator{}).
+		For(sj).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-scaledjob,mutating=false,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledjobs,verbs=create;update,versions=v1alpha1,name=vscaledjob.kb.io,admissionReviewVersions=v1
+
+// ScaledJobCustomValidator is a custom validator for ScaledJob objects
+type ScaledJobCustomValidator struct{}
+
+func (sjcv ScaledJobCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	request, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sj := obj.(*ScaledJob)
+	return sj.ValidateCreate(request.DryRun)
+}
+
+func (sjcv ScaledJobCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	request, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sj := newObj.(*ScaledJob)
+	old := oldObj.(*ScaledJob)
+	return sj.ValidateUpdate(old, request.DryRun)
+}
+
+func (sjcv ScaledJobCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	request, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sj := obj.(*ScaledJob)
+	return sj.ValidateDelete(request.DryRun)
+}
+
+var _ webhook.CustomValidator = &ScaledJobCustomValidator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (sj *ScaledJob) ValidateCreate(_ *bool) (admission.Warnings, error) {
+	val, _ := json.MarshalIndent(sj, "", "  ")
+	scaledjoblog.V(1).Info(fmt.Sprintf("validating scaledjob creation for %s", string(val)))
+	return validateScaledJob(sj, "create")
+}
+
+func (sj *ScaledJob) ValidateUpdate(old runtime.Object, _ *bool) (admission.Warnings, error) {
+	val, _ := json.MarshalIndent(sj, "", "  ")
+	scaledjoblog.V(1).Info(fmt.Sprintf("validating scaledjob update for %s", string(val)))
+
+	if isScaledJobRemovingFinalizer(sj, old) {
+		scaledjoblog.V(1).Info("finalizer removal, skipping validation")
+		return nil, nil
+	}
+
+	return validateScaledJob(sj, "update")
+}
+
+func (sj *ScaledJob) ValidateDelete(_ *bool) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func isScaledJobRemovingFinalizer(sj *ScaledJob, old runtime.Object) bool {
+	oldSj := old.(*ScaledJob)
+
+	sjSpec, _ := json.MarshalIndent(sj.Spec, "", "  ")
+	oldSjSpec, _ := json.MarshalIndent(oldSj.Spec, "", "  ")
+
+	return len(sj.ObjectMeta.Finalizers) < len(oldSj.ObjectMeta.Finalizers) && string(sjSpec) == string(oldSjSpec)
+}
+
+func validateScaledJob(sj *ScaledJob, action string) (admission.Warnings, error) {
+	metricscollector.RecordScaledJobValidatingTotal(sj.Namespace, action)
+
+	verifyFunctions := []struct {
+		reason string
+		verify func(*ScaledJob) error
+	}{
+		{"replicas", verifyScaledJobReplicaCount},
+		{"scaling-strategy", verifyScaledJobScalingStrategy},
+		{"rollout", verifyScaledJobRollout},
+		{"fallback", verifyScaledJobFallback},
+		{"pause-schedules", func(sj *ScaledJob) error { return validatePauseSchedules(sj.Spec.PauseSchedules, false) }},
+		{"triggers", verifyScaledJobTriggers},
+		{"scaling-modifiers", verifyScaledJobScalingModifiers},
+		{"job-template-parameters", verifyScaledJobTemplateParameters},
+		{"scaling-decision-history", func(sj *ScaledJob) error {
+			_, err := GetScalingDecisionHistoryLimit(sj.Annotations)
+			return err
+		}},
+	}
+
+	for _, f := range verifyFunctions {
+		if err := f.verify(sj); err != nil {
+			scaledjoblog.WithValues("name", sj.Name).Error(err, "validation error")
+			metricscollector.RecordScaledJobValidatingErrors(sj.Namespace, action, "incorrect-"+f.reason)
+			return nil, err
+		}
+	}
+
+	warnings := scaledJobScalingStrategyWarnings(sj)
+	triggersWarnings, err := ValidateTriggersMetadata(sj.Spec.Triggers)
+	warnings = append(warnings, triggersWarnings...)
+	if err != nil {
+		scaledjoblog.WithValues("name", sj.Name).Error(err, "validation error")
+		metricscollector.RecordScaledJobValidatingErrors(sj.Namespace, action, "incorrect-trigger-metadata")
+		return warnings, err
+	}
+
+	scaledjoblog.V(1).Info(fmt.Sprintf("scaledjob %s is valid", sj.Name))
+	return warnings, nil
+}
+
+// scaledJobScalingStrategyWarnings reports the incomplete custom scaling strategy, which falls back to the
+// default strategy instead of being rejected so the existing ScaledJobs can still be updated
+func scaledJobScalingStrategyWarnings(sj *ScaledJob) admission.Warnings {
+	strategy := sj.Spec.ScalingStrategy
+	if strategy.Strategy != customScalingStrategy {
+		return nil
+	}
+	var warnings admission.Warnings
+	if strategy.CustomScalingQueueLengthDeduction == nil {
+		warnings = append(warnings, fmt.Sprintf("scalingStrategy.customScalingQueueLengthDeduction is not set, the default strategy is used instead of the %q strategy", customScalingStrategy))
+	}
+	if strategy.CustomScalingRunningJobPercentage == "" {
+		warnings = append(warnings, fmt.Sprintf("scalingStrategy.customScalingRunningJobPercentage is not set, the default strategy is used instead of the %q strategy", customScalingStrategy))
+	}
+	return warnings
+}
+
+func verifyScaledJobReplicaCount(sj *ScaledJob) error {
+	if sj.Spec.MinReplicaCount != nil && *sj.Spec.MinReplicaCount < 0 {
+		return fmt.Errorf("MinReplicaCount=%d must not be negative", *sj.Spec.MinReplicaCount)
+	}
+	if sj.Spec.MaxReplicaCount != nil && *sj.Spec.MaxReplicaCount < 0 {
+		return fmt.Errorf("MaxReplicaCount=%d must not be negative", *sj.Spec.MaxReplicaCount)
+	}
+	if sj.Spec.MinReplicaCount != nil && sj.Spec.MaxReplicaCount != nil && *sj.Spec.MinReplicaCount > *sj.Spec.MaxReplicaCount {
+		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", *sj.Spec.MinReplicaCount, *sj.Spec.MaxReplicaCount)
+	}
+	return nil
+}
+
+func verifyScaledJobScalingStrategy(sj *ScaledJob) error {
+	strategy := sj.Spec.ScalingStrategy
+	if !slices.Contains(scaledJobScalingStrategies, strategy.Strategy) {
+		return fmt.Errorf("scalingStrategy.strategy %q is not supported, allowed values are %q", strategy.Strategy, scaledJobScalingStrategies[1:])
+	}
+	if strategy.Strategy == customScalingStrategy && strategy.CustomScalingRunningJobPercentage != "" {
+		if _, err := strconv.ParseFloat(strategy.CustomScalingRunningJobPercentage, 64); err != nil {
+			return fmt.Errorf("scalingStrategy.customScalingRunningJobPercentage %q must be a number: %w", strategy.CustomScalingRunningJobPercentage, err)
+		}
+	}
+	if strategy.Strategy == ExpressionScalingStrategy {
+		if _, err := CompileScalingStrategyExpression(sj); err != nil {
+			return err
+		}
+	} else if strategy.Expression != "" {
+		return fmt.Errorf("scalingStrategy.expression is only supported by the %q strategy", ExpressionScalingStrategy)
+	}
+	if !slices.Contains(scaledJobMultipleScalersCalculations, strategy.MultipleScalersCalculation) {
+		return fmt.Errorf("scalingStrategy.multipleScalersCalculation %q is not supported, allowed values are %q", strategy.MultipleScalersCalculation, scaledJobMultipleScalersCalculations[1:])
+	}
+	return nil
+}
+
+func verifyScaledJobScalingModifiers(sj *ScaledJob) error {
+	if !sj.IsUsingModifiers() {
+		return nil
+	}
+	_, err := ValidateAndCompileScaledJobScalingModifiers(sj)
+	return err
+}
+
+// ValidateAndCompileScaledJobScalingModifiers validates the scalingModifiers of the ScaledJob and compiles
+// the formula as ValidateAndCompileScalingModifiers does for a ScaledObject, the composite metric is
+// an average value per job
+func ValidateAndCompileScaledJobScalingModifiers(sj *ScaledJob) (*vm.Program, error) {
+	sm := sj.Spec.ScalingModifiers
+	if sm.MetricType != "" && sm.MetricType != autoscalingv2.AverageValueMetricType {
+		return nil, fmt.Errorf("scalingModifiers.metricType %q is not supported by ScaledJob, target is the average value per job", sm.MetricType)
+	}
+	if sj.Spec.ScalingStrategy.MultipleScalersCalculation != "" {
+		return nil, fmt.Errorf("scalingModifiers can't be used together with scalingStrategy.multipleScalersCalculation")
+	}
+	return validateAndCompileScalingModifiers(&sj.Spec.ScalingModifiers, sj.Spec.Triggers)
+}
+
+// ScalingStrategyExpressionEnv returns the environment the expression of the expression scaling strategy is compiled and run with
+func ScalingStrategyExpressionEnv(queueLength, runningJobCount, pendingJobCount, maxReplicaCount, minReplicaCount int64) map[string]any {
+	return map[string]any{
+		"queueLength":     float64(queueLength),
+		"runningJobCount": float64(runningJobCount),
+		"pendingJobCount": float64(pendingJobCount),
+		"maxReplicaCount": float64(maxReplicaCount),
+		"minReplicaCount": float64(minReplicaCount),
+	}
+}
+
+// CompileScalingStrategyExpression validates and compiles the expression of the expression scaling strategy,
+// it must return a number
+func CompileScalingStrategyExpression(sj *ScaledJob) (*vm.Program, error) {
+	expression := sj.Spec.ScalingStrategy.Expression
+	if expression == "" {
+		return nil, fmt.Errorf("scalingStrategy.expression is required by the %q strategy", ExpressionScalingStrategy)
+	}
+	// run with dummy values to catch the errors only raised at runtime
+	env := ScalingStrategyExpressionEnv(1, 1, 1, 1, 1)
+	program, err := expr.Compile(expression, expr.Env(env), expr.AsFloat64())
+	if err != nil {
+		return nil, fmt.Errorf("scalingStrategy.expression is invalid: %w", err)
+	}
+	if _, err := expr.Run(program, env); err != nil {
+		return nil, fmt.Errorf("scalingStrategy.expression is invalid: %w", err)
+	}
+	return program, nil
+}
+
+func verifyScaledJobRollout(sj *ScaledJob) error {
+	if !slices.Contains(scaledJobRolloutStrategies, sj.Spec.RolloutStrategy) {
+		return fmt.Errorf("rolloutStrategy %q is not supported, allowed values are %q", sj.Spec.RolloutStrategy, scaledJobRolloutStrategies[1:])
+	}
+	if !slices.Contains(scaledJobRolloutStrategies, sj.Spec.Rollout.Strategy) {
+		return fmt.Errorf("rollout.strategy %q is not supported, allowed values are %q", sj.Spec.Rollout.Strategy, scaledJobRolloutStrategies[1:])
+	}
+	if !slices.Contains(scaledJobRolloutPropagationPolicies, sj.Spec.Rollout.PropagationPolicy) {
+		return fmt.Errorf("rollout.propagationPolicy %q is not supported, allowed values are %q", sj.Spec.Rollout.PropagationPolicy, scaledJobRolloutPropagationPolicies[1:])
+	}
+	return nil
+}
+
+func verifyScaledJobTemplateParameters(sj *ScaledJob) error {
+	parameters := sj.Spec.JobTemplateParameters
+	if parameters == nil {
+		return nil
+	}
+	if err := verifyJobTemplateParameterList("jobTemplateParameters.env", parameters.Env, validation.IsEnvVarName); err != nil {
+		return err
+	}
+	return verifyJobTemplateParameterList("jobTemplateParameters.annotations", parameters.Annotations, validation.IsQualifiedName)
+}
+
+func verifyJobTemplateParameterList(field string, parameters []JobTemplateParameter, validateName func(string) []string) error {
+	names := map[string]bool{}
+	for _, parameter := range parameters {
+		if errs := validateName(parameter.Name); len(errs) > 0 {
+			return fmt.Errorf("%s name %q is invalid: %s", field, parameter.Name, errs[0])
+		}
+		if names[parameter.Name] {
+			return fmt.Errorf("%s name %q is defined multiple times", field, parameter.Name)
+		}
+		names[parameter.Name] = true
+		if !slices.Contains(jobTemplateParameterSources, parameter.Source) {
+			return fmt.Errorf("%s source %q of %q is not supported, allowed values are %q", field, parameter.Source, parameter.Name, jobTemplateParameterSources)
+		}
+		if parameter.Modulo != nil {
+			if parameter.Source != JobTemplateParameterSourceCounter && parameter.Source != JobTemplateParameterSourceIndex {
+				return fmt.Errorf("%s modulo of %q is only supported by the %q and %q sources", field, parameter.Name, JobTemplateParameterSourceCounter, JobTemplateParameterSourceIndex)
+			}
+			if *parameter.Modulo <= 0 {
+				return fmt.Errorf("%s modulo=%d of %q must be positive", field, *parameter.Modulo, parameter.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func verifyScaledJobTriggers(sj *ScaledJob) error {
+	for i, trigger := range sj.Spec.Triggers {
+		if trigger.CooldownPeriod != nil {
+			return fmt.Errorf("property \"cooldownPeriod\" of trigger %q is not supported by ScaledJob", GetTriggerStatusKey(trigger, i))
+		}
+	}
+	return ValidateTriggers(sj.Spec.Triggers)
+}
+
+func verifyScaledJobFallback(sj *ScaledJob) error {
+	fallback := sj.Spec.Fallback
+	if fallback == nil {
+		return nil
+	}
+	if fallback.FailureThreshold < 0 || fallback.Replicas < 0 {
+		return fmt.Errorf("fallback.failureThreshold=%d and fallback.replicas=%d must not be negative", fallback.FailureThreshold, fallback.Replicas)
+	}
+	if behavior := fallback.GetBehavior(); behavior != FallbackBehaviorStatic && behavior != FallbackBehaviorLastKnownMetric {
+		return fmt.Errorf("fallback.behavior %q is not supported by ScaledJob, allowed values are %q", behavior, []FallbackBehavior{FallbackBehaviorStatic, FallbackBehaviorLastKnownMetric})
+	}
+	return verifyFallbackMaxMetricAge(fallback)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateScaledJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           ScaledJobSpec
+		expectedErrMsg string
+	}{
+		{
+			name: "valid scaledjob",
+			spec: ScaledJobSpec{
+				MinReplicaCount: ptr.To[int32](1),
+				MaxReplicaCount: ptr.To[int32](10),
+				ScalingStrategy: ScalingStrategy{
+					Strategy:                          "custom",
+					CustomScalingQueueLengthDeduction: ptr.To[int32](1),
+					CustomScalingRunningJobPercentage: "0.5",
+					MultipleScalersCalculation:        "sum",
+				},
+				Rollout:  Rollout{Strategy: "gradual", PropagationPolicy: "foreground"},
+				Triggers: []ScaleTriggers{{Type: "cron"}},
+			},
+		},
+		{
+			name:           "min greater than max",
+			spec:           ScaledJobSpec{MinReplicaCount: ptr.To[int32](5), MaxReplicaCount: ptr.To[int32](2)},
+			expectedErrMsg: "MinReplicaCount=5 must be less than MaxReplicaCount=2",
+		},
+		{
+			name:           "negative max",
+			spec:           ScaledJobSpec{MaxReplicaCount: ptr.To[int32](-1)},
+			expectedErrMsg: "MaxReplicaCount=-1 must not be negative",
+		},
+		{
+			name:           "unknown scaling strategy",
+			spec:           ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "fastest"}},
+			expectedErrMsg: `scalingStrategy.strategy "fastest" is not supported`,
+		},
+		{
+			name: "unparsable running job percentage",
+			spec: ScaledJobSpec{ScalingStrategy: ScalingStrategy{
+				Strategy:                          "custom",
+				CustomScalingQueueLengthDeduction: ptr.To[int32](1),
+				CustomScalingRunningJobPercentage: "half",
+			}},
+			expectedErrMsg: `scalingStrategy.customScalingRunningJobPercentage "half" must be a number`,
+		},
+		{
+			name: "custom strategy without queue length deduction",
+			spec: ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "custom", CustomScalingRunningJobPercentage: "0.5"}},
+		},
+		{
+			name: "custom strategy without running job percentage",
+			spec: ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "custom", CustomScalingQueueLengthDeduction: ptr.To[int32](1)}},
+		},
+		{
+			name:           "unknown multiple scalers calculation",
+			spec:           ScaledJobSpec{ScalingStrategy: ScalingStrategy{MultipleScalersCalculation: "median"}},
+			expectedErrMsg: `scalingStrategy.multipleScalersCalculation "median" is not supported`,
+		},
+		{
+			name: "expression strategy",
+			spec: ScaledJobSpec{ScalingStrategy: ScalingStrategy{
+				Strategy:   "expression",
+				Expression: "min(queueLength - pendingJobCount, maxReplicaCount - runningJobCount) + minReplicaCount",
+			}},
+		},
+		{
+			name:           "expression strategy without expression",
+			spec:           ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "expression"}},
+			expectedErrMsg: `scalingStrategy.expression is required by the "expression" strategy`,
+		},
+		{
+			name:           "expression with unknown variable",
+			spec:           ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "expression", Expression: "queueLength - idleJobCount"}},
+			expectedErrMsg: "scalingStrategy.expression is invalid",
+		},
+		{
+			name:           "expression not returning a number",
+			spec:           ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "expression", Expression: `"many"`}},
+			expectedErrMsg: "scalingStrategy.expression is invalid",
+		},
+		{
+			name:           "expression with another strategy",
+			spec:           ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "accurate", Expression: "queueLength"}},
+			expectedErrMsg: `scalingStrategy.expression is only supported by the "expression" strategy`,
+		},
+		{
+			name: "scaling modifiers",
+			spec: ScaledJobSpec{
+				ScalingModifiers: ScalingModifiers{Formula: "queue + backlog", Target: "5"},
+				Triggers: []ScaleTriggers{
+					{Name: "queue", Type: "cron"},
+					{Name: "backlog", Type: "cron"},
+				},
+			},
+		},
+		{
+			name:           "scaling modifiers formula without target",
+			spec:           ScaledJobSpec{ScalingModifiers: ScalingModifiers{Formula: "queue"}, Triggers: []ScaleTriggers{{Name: "queue", Type: "cron"}}},
+			expectedErrMsg: "formula is given but target is empty",
+		},
+		{
+			name: "scaling modifiers with value metric type",
+			spec: ScaledJobSpec{
+				ScalingModifiers: ScalingModifiers{Formula: "queue", Target: "5", MetricType: "Value"},
+				Triggers:         []ScaleTriggers{{Name: "queue", Type: "cron"}},
+			},
+			expectedErrMsg: `scalingModifiers.metricType "Value" is not supported by ScaledJob`,
+		},
+		{
+			name: "scaling modifiers with multiple scalers calculation",
+			spec: ScaledJobSpec{
+				ScalingStrategy:  ScalingStrategy{MultipleScalersCalculation: "sum"},
+				ScalingModifiers: ScalingModifiers{Formula: "queue", Target: "5"},
+				Triggers:         []ScaleTriggers{{Name: "queue", Type: "cron"}},
+			},
+			expectedErrMsg: "scalingModifiers can't be used together with scalingStrategy.multipleScalersCalculation",
+		},
+		{
+			name: "job template parameters",
+			spec: ScaledJobSpec{JobTemplateParameters: &JobTemplateParameters{
+				Env: []JobTemplateParameter{
+					{Name: "PARTITION", Source: "counter", Modulo: ptr.To[int64](8)},
+					{Name: "SHARD_ID", Source: "hint"},
+				},
+				Annotations: []JobTemplateParameter{{Name: "example.com/trigger", Source: "trigger"}},
+			}},
+		},
+		{
+			name:           "job template parameter with invalid env name",
+			spec:           ScaledJobSpec{JobTemplateParameters: &JobTemplateParameters{Env: []JobTemplateParameter{{Name: "1PARTITION", Source: "index"}}}},
+			expectedErrMsg: `jobTemplateParameters.env name "1PARTITION" is invalid`,
+		},
+		{
+			name: "duplicate job template parameter",
+			spec: ScaledJobSpec{JobTemplateParameters: &JobTemplateParameters{Annotations: []JobTemplateParameter{
+				{Name: "example.com/partition", Source: "index"},
+				{Name: "example.com/partition", Source: "counter"},
+			}}},
+			expectedErrMsg: `jobTemplateParameters.annotations name "example.com/partition" is defined multiple times`,
+		},
+		{
+			name:           "unknown job template parameter source",
+			spec:           ScaledJobSpec{JobTemplateParameters: &JobTemplateParameters{Env: []JobTemplateParameter{{Name: "PARTITION", Source: "partition"}}}},
+			expectedErrMsg: `jobTemplateParameters.env source "partition" of "PARTITION" is not supported`,
+		},
+		{
+			name:           "job template parameter modulo with hint source",
+			spec:           ScaledJobSpec{JobTemplateParameters: &JobTemplateParameters{Env: []JobTemplateParameter{{Name: "SHARD_ID", Source: "hint", Modulo: ptr.To[int64](2)}}}},
+			expectedErrMsg: `jobTemplateParameters.env modulo of "SHARD_ID" is only supported by the "counter" and "index" sources`,
+		},
+		{
+			name:           "zero job template parameter modulo",
+			spec:           ScaledJobSpec{JobTemplateParameters: &JobTemplateParameters{Env: []JobTemplateParameter{{Name: "PARTITION", Source: "counter", Modulo: ptr.To[int64](0)}}}},
+			expectedErrMsg: `jobTemplateParameters.env modulo=0 of "PARTITION" must be positive`,
+		},
+		{
+			name:           "unknown propagation policy",
+			spec:           ScaledJobSpec{Rollout: Rollout{PropagationPolicy: "orphan"}},
+			expectedErrMsg: `rollout.propagationPolicy "orphan" is not supported`,
+		},
+		{
+			name:           "unknown rollout strategy",
+			spec:           ScaledJobSpec{Rollout: Rollout{Strategy: "slow"}},
+			expectedErrMsg: `rollout.strategy "slow" is not supported`,
+		},
+		{
+			name:           "immediate rollout strategy",
+			spec:           ScaledJobSpec{RolloutStrategy: "immediate"},
+			expectedErrMsg: `rolloutStrategy "immediate" is not supported, allowed values are ["default" "gradual"]`,
+		},
+		{
+			name: "lastKnownMetric fallback",
+			spec: ScaledJobSpec{Fallback: &Fallback{
+				FailureThreshold: 3,
+				Replicas:         2,
+				Behavior:         FallbackBehaviorLastKnownMetric,
+				MaxMetricAge:     &metav1.Duration{Duration: time.Minute},
+			}},
+		},
+		{
+			name:           "negative fallback replicas",
+			spec:           ScaledJobSpec{Fallback: &Fallback{FailureThreshold: 3, Replicas: -1}},
+			expectedErrMsg: "fallback.failureThreshold=3 and fallback.replicas=-1 must not be negative",
+		},
+		{
+			name:           "currentReplicas fallback behavior",
+			spec:           ScaledJobSpec{Fallback: &Fallback{FailureThreshold: 3, Replicas: 1, Behavior: FallbackBehaviorCurrentReplicas}},
+			expectedErrMsg: `fallback.behavior "currentReplicas" is not supported by ScaledJob`,
+		},
+		{
+			name:           "maxMetricAge with static fallback",
+			spec:           ScaledJobSpec{Fallback: &Fallback{FailureThreshold: 3, Replicas: 1, MaxMetricAge: &metav1.Duration{Duration: time.Minute}}},
+			expectedErrMsg: `fallback.maxMetricAge is only supported by the "lastKnownMetric" behavior`,
+		},
+		{
+			name: "duplicate trigger names",
+			spec: ScaledJobSpec{Triggers: []ScaleTriggers{
+				{Name: "trigger", Type: "cron"},
+				{Name: "trigger", Type: "cron"},
+			}},
+			expectedErrMsg: `triggerName "trigger" is defined multiple times`,
+		},
+		{
+			name:           "trigger cooldown period",
+			spec:           ScaledJobSpec{Triggers: []ScaleTriggers{{Type: "cron", CooldownPeriod: ptr.To[int32](0)}}},
+			expectedErrMsg: `property "cooldownPeriod" of trigger "cron-0" is not supported by ScaledJob`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			sj := &ScaledJob{Spec: test.spec}
+			_, err := validateScaledJob(sj, "create")
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestValidateScaledJobTriggersMetadata(t *testing.T) {
+	defer SetTriggerMetadataValidator(nil)
+	SetTriggerMetadataValidator(func(triggerType string, _ autoscalingv2.MetricTargetType, _ map[string]string) ([]string, error) {
+		if triggerType == "invalid" {
+			return nil, errors.New("invalid metadata")
+		}
+		return []string{"deprecated parameter"}, nil
+	})
+
+	sj := &ScaledJob{Spec: ScaledJobSpec{Triggers: []ScaleTriggers{{Type: "valid"}}}}
+	warnings, err := validateScaledJob(sj, "create")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"trigger 0 (valid): deprecated parameter"}, []string(warnings))
+
+	sj.Spec.Triggers = append(sj.Spec.Triggers, ScaleTriggers{Type: "invalid"})
+	_, err = validateScaledJob(sj, "update")
+	assert.EqualError(t, err, "trigger 1 (invalid): invalid metadata")
+}
+
+func TestValidateScaledJobCustomScalingStrategyWarnings(t *testing.T) {
+	sj := &ScaledJob{Spec: ScaledJobSpec{ScalingStrategy: ScalingStrategy{Strategy: "custom"}}}
+	warnings, err := validateScaledJob(sj, "update")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`scalingStrategy.customScalingQueueLengthDeduction is not set, the default strategy is used instead of the "custom" strategy`,
+		`scalingStrategy.customScalingRunningJobPercentage is not set, the default strategy is used instead of the "custom" strategy`,
+	}, []string(warnings))
+
+	sj.Spec.ScalingStrategy.CustomScalingQueueLengthDeduction = ptr.To[int32](1)
+	sj.Spec.ScalingStrategy.CustomScalingRunningJobPercentage = "0.5"
+	warnings, err = validateScaledJob(sj, "update")
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateActivationPolicy(t *testing.T) {
+	triggers := []ScaleTriggers{
+		{Name: "queue", Type: "rabbitmq"},
+		{Name: "database", Type: "postgresql"},
+		{Name: "maintenance", Type: "cron"},
+		{Name: "usage", Type: cpuString},
+	}
+
+	tests := []struct {
+		name           string
+		policy         *ActivationPolicy
+		advanced       *AdvancedConfig
+		expectedErrMsg string
+	}{
+		{
+			name:   "all with a veto trigger",
+			policy: &ActivationPolicy{Mode: ActivationPolicyAll, VetoTriggers: []string{"maintenance"}},
+		},
+		{
+			name:   "quorum",
+			policy: &ActivationPolicy{Mode: ActivationPolicyQuorum, Quorum: ptr.To[int32](3)},
+		},
+		{
+			name:           "quorum larger than the counted triggers",
+			policy:         &ActivationPolicy{Mode: ActivationPolicyQuorum, Quorum: ptr.To[int32](3), VetoTriggers: []string{"maintenance"}},
+			expectedErrMsg: "activationPolicy.quorum=3 must be between 1 and the number of triggers counted by the policy (2)",
+		},
+		{
+			name:           "missing quorum",
+			policy:         &ActivationPolicy{Mode: ActivationPolicyQuorum},
+			expectedErrMsg: `activationPolicy.quorum is required by the "quorum" mode`,
+		},
+		{
+			name:           "quorum without quorum mode",
+			policy:         &ActivationPolicy{Quorum: ptr.To[int32](1)},
+			expectedErrMsg: `activationPolicy.quorum is only supported by the "quorum" mode`,
+		},
+		{
+			name:           "unknown veto trigger",
+			policy:         &ActivationPolicy{VetoTriggers: []string{"unknown"}},
+			expectedErrMsg: `activationPolicy.vetoTriggers: trigger "unknown" is not defined`,
+		},
+		{
+			name:           "cpu veto trigger",
+			policy:         &ActivationPolicy{VetoTriggers: []string{"usage"}},
+			expectedErrMsg: `activationPolicy.vetoTriggers: cpu trigger "usage" can't be a veto trigger`,
+		},
+		{
+			name:           "scaling modifiers",
+			policy:         &ActivationPolicy{Mode: ActivationPolicyAll},
+			advanced:       &AdvancedConfig{ScalingModifiers: ScalingModifiers{Formula: "queue + database", Target: "2"}},
+			expectedErrMsg: "activationPolicy can't be used together with scalingModifiers",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{Spec: ScaledObjectSpec{Triggers: triggers, ActivationPolicy: test.policy, Advanced: test.advanced}}
+			err := validateActivationPolicy(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
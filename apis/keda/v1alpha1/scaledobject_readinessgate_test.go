@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReadinessGate(t *testing.T) {
+	tests := []struct {
+		name           string
+		gate           *ReadinessGate
+		expectedErrMsg string
+	}{
+		{
+			name: "valid gate",
+			gate: &ReadinessGate{
+				Workloads:     []ReadinessGateWorkload{{Name: "database"}, {Kind: "StatefulSet", Name: "broker"}},
+				ScaledObjects: []string{"ingest"},
+				PodSelector:   "app=cache,tier in (backend)",
+			},
+		},
+		{
+			name:           "missing workload name",
+			gate:           &ReadinessGate{Workloads: []ReadinessGateWorkload{{Kind: "Deployment"}}},
+			expectedErrMsg: "readinessGate.workloads[0]: name is required",
+		},
+		{
+			name:           "unsupported kind",
+			gate:           &ReadinessGate{Workloads: []ReadinessGateWorkload{{Kind: "DaemonSet", Name: "agent"}}},
+			expectedErrMsg: "readinessGate.workloads[0]: kind DaemonSet is not supported, only Deployment and StatefulSet are",
+		},
+		{
+			name:           "self dependency",
+			gate:           &ReadinessGate{ScaledObjects: []string{"consumer"}},
+			expectedErrMsg: "readinessGate.scaledObjects: ScaledObject consumer can't depend on itself",
+		},
+		{
+			name:           "invalid pod selector",
+			gate:           &ReadinessGate{PodSelector: "app in cache"},
+			expectedErrMsg: "readinessGate.podSelector:",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{Spec: ScaledObjectSpec{ReadinessGate: test.gate}}
+			so.Name = "consumer"
+			err := validateReadinessGate(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScalingModifiersAggregation(t *testing.T) {
+	triggers := []ScaleTriggers{
+		{Name: "queue", Type: "rabbitmq"},
+		{Name: "lag", Type: "kafka"},
+		{Type: cpuString},
+	}
+	unnamedTriggers := []ScaleTriggers{
+		{Type: "rabbitmq"},
+		{Type: "kafka"},
+	}
+
+	tests := []struct {
+		name           string
+		sm             ScalingModifiers
+		triggers       []ScaleTriggers
+		expectedErrMsg string
+	}{
+		{
+			name:     "sum aggregation",
+			sm:       ScalingModifiers{Aggregation: AggregationSum, Target: "2"},
+			triggers: unnamedTriggers,
+		},
+		{
+			name:     "weighted aggregation",
+			sm:       ScalingModifiers{Aggregation: AggregationWeighted, Target: "2", Weights: map[string]string{"queue": "0.5", "lag": "2"}},
+			triggers: triggers,
+		},
+		{
+			name:     "weighted aggregation with default weights",
+			sm:       ScalingModifiers{Aggregation: AggregationWeighted, Target: "2", Weights: map[string]string{"queue": "3"}},
+			triggers: triggers,
+		},
+		{
+			name:           "unknown aggregation",
+			sm:             ScalingModifiers{Aggregation: "median", Target: "2"},
+			triggers:       triggers,
+			expectedErrMsg: `aggregation "median" is not supported`,
+		},
+		{
+			name:           "aggregation together with formula",
+			sm:             ScalingModifiers{Aggregation: AggregationMax, Formula: "queue + lag", Target: "2"},
+			triggers:       triggers,
+			expectedErrMsg: "can't be used together",
+		},
+		{
+			name:           "aggregation without target",
+			sm:             ScalingModifiers{Aggregation: AggregationAvg},
+			triggers:       triggers,
+			expectedErrMsg: "aggregation is given but target is empty",
+		},
+		{
+			name:           "aggregation with invalid target",
+			sm:             ScalingModifiers{Aggregation: AggregationMin, Target: "abc"},
+			triggers:       triggers,
+			expectedErrMsg: "error converting target for scalingModifiers",
+		},
+		{
+			name:           "weights without weighted aggregation",
+			sm:             ScalingModifiers{Aggregation: AggregationSum, Target: "2", Weights: map[string]string{"queue": "2"}},
+			triggers:       triggers,
+			expectedErrMsg: `weights are only supported by the "weighted" aggregation`,
+		},
+		{
+			name:           "weighted aggregation with unnamed triggers",
+			sm:             ScalingModifiers{Aggregation: AggregationWeighted, Target: "2"},
+			triggers:       unnamedTriggers,
+			expectedErrMsg: `trigger of type "rabbitmq" needs a name`,
+		},
+		{
+			name:           "weight for unknown trigger",
+			sm:             ScalingModifiers{Aggregation: AggregationWeighted, Target: "2", Weights: map[string]string{"missing": "2"}},
+			triggers:       triggers,
+			expectedErrMsg: `weight is given for unknown trigger "missing"`,
+		},
+		{
+			name:           "invalid weight",
+			sm:             ScalingModifiers{Aggregation: AggregationWeighted, Target: "2", Weights: map[string]string{"queue": "heavy"}},
+			triggers:       triggers,
+			expectedErrMsg: `error converting weight "heavy" of trigger "queue"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{ScalingModifiers: test.sm},
+					Triggers: test.triggers,
+				},
+			}
+			program, err := ValidateAndCompileScalingModifiers(so)
+			assert.Nil(t, program)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestValidateScalingModifiersWindowFunctions(t *testing.T) {
+	triggers := []ScaleTriggers{
+		{Name: "lag", Type: "kafka"},
+		{Name: "requests", Type: "prometheus"},
+	}
+
+	tests := []struct {
+		name            string
+		formula         string
+		triggers        []ScaleTriggers
+		expectedFormula string
+		expectedErrMsg  string
+	}{
+		{
+			name:            "bare arguments",
+			formula:         "avg_over(lag, 5m) + max_over( requests ,30s)",
+			triggers:        triggers,
+			expectedFormula: `float(avg_over("lag", "5m") + max_over("requests", "30s"))`,
+		},
+		{
+			name:            "quoted arguments",
+			formula:         `ewma("lag", "10m") + rate("requests", "1m") - min_over(lag, 1h)`,
+			triggers:        triggers,
+			expectedFormula: `float(ewma("lag", "10m") + rate("requests", "1m") - min_over("lag", "1h"))`,
+		},
+		{
+			name:           "unknown trigger",
+			formula:        "avg_over(queue, 5m)",
+			triggers:       triggers,
+			expectedErrMsg: `avg_over: unknown trigger "queue"`,
+		},
+		{
+			name:           "invalid window",
+			formula:        "avg_over(lag, 5x)",
+			triggers:       triggers,
+			expectedErrMsg: `invalid window "5x"`,
+		},
+		{
+			name:           "window too long",
+			formula:        "max_over(lag, 2h)",
+			triggers:       triggers,
+			expectedErrMsg: `window "2h" must be greater than 0 and at most 1h0m0s`,
+		},
+		{
+			name:           "trigger named as function",
+			formula:        "rate",
+			triggers:       []ScaleTriggers{{Name: "rate", Type: "prometheus"}},
+			expectedErrMsg: `trigger name "rate" is reserved`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{
+				Spec: ScaledObjectSpec{
+					Advanced: &AdvancedConfig{ScalingModifiers: ScalingModifiers{Formula: test.formula, Target: "2"}},
+					Triggers: test.triggers,
+				},
+			}
+			program, err := ValidateAndCompileScalingModifiers(so)
+			if test.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, program)
+			assert.Equal(t, test.expectedFormula, so.Spec.Advanced.ScalingModifiers.Formula)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,6 +56,7 @@ const ScaledObjectOwnerAnnotation = "scaledobject.keda.sh/name"
 const ScaledObjectTransferHpaOwnershipAnnotation = "scaledobject.keda.sh/transfer-hpa-ownership"
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 const PausedAnnotation = "autoscaling.keda.sh/paused"
+const DryRunAnnotation = "autoscaling.keda.sh/dry-run"
 
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
@@ -88,6 +90,9 @@ type ScaledObjectSpec struct {
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
 	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+	// IdleCooldownPeriod replaces cooldownPeriod when the ScaleTarget is scaled in to idleReplicaCount, in seconds
+	// +optional
+	IdleCooldownPeriod *int32 `json:"idleCooldownPeriod,omitempty"`
 	// +optional
 	IdleReplicaCount *int32 `json:"idleReplicaCount,omitempty"`
 	// +optional
@@ -100,12 +105,160 @@ type ScaledObjectSpec struct {
 	Triggers []ScaleTriggers `json:"triggers"`
 	// +optional
 	Fallback *Fallback `json:"fallback,omitempty"`
+	// ActivationPolicy combines the activity of the triggers into the activity of the ScaledObject,
+	// by default the ScaledObject is active if any trigger is active
+	// +optional
+	ActivationPolicy *ActivationPolicy `json:"activationPolicy,omitempty"`
+	// ReadinessGate holds back scaling from zero or idle until the dependencies of the scale target are ready
+	// +optional
+	ReadinessGate *ReadinessGate `json:"readinessGate,omitempty"`
+	// PauseSchedules pause the autoscaling on a schedule, the paused annotations override them
+	// +optional
+	PauseSchedules []PauseSchedule `json:"pauseSchedules,omitempty"`
 }
 
 // Fallback is the spec for fallback options
 type Fallback struct {
 	FailureThreshold int32 `json:"failureThreshold"`
 	Replicas         int32 `json:"replicas"`
+	// Behavior defines the replica count used while the fallback is active, defaults to static
+	// +optional
+	// +kubebuilder:validation:Enum=static;currentReplicas;currentReplicasIfHigher;lastKnownMetric
+	Behavior FallbackBehavior `json:"behavior,omitempty"`
+	// MaxMetricAge is the maximum age of the last known metric used by the lastKnownMetric behavior,
+	// older metrics fall back to the static replica count, defaults to 5m
+	// +optional
+	MaxMetricAge *metav1.Duration `json:"maxMetricAge,omitempty"`
+}
+
+// FallbackBehavior is the way the replica count is computed while the fallback is active
+type FallbackBehavior string
+
+const (
+	// FallbackBehaviorStatic scales to fallback.replicas
+	FallbackBehaviorStatic FallbackBehavior = "static"
+
+	// FallbackBehaviorCurrentReplicas keeps the current replica count
+	FallbackBehaviorCurrentReplicas FallbackBehavior = "currentReplicas"
+
+	// FallbackBehaviorCurrentReplicasIfHigher scales to the higher of the current replica count and fallback.replicas
+	FallbackBehaviorCurrentReplicasIfHigher FallbackBehavior = "currentReplicasIfHigher"
+
+	// FallbackBehaviorLastKnownMetric uses the last metric value retrieved from the scaler, as long as it is
+	// not older than fallback.maxMetricAge, and falls back to fallback.replicas otherwise
+	FallbackBehaviorLastKnownMetric FallbackBehavior = "lastKnownMetric"
+)
+
+// DefaultFallbackMaxMetricAge is the maximum age of the last known metric if fallback.maxMetricAge is not set
+const DefaultFallbackMaxMetricAge = 5 * time.Minute
+
+// GetBehavior returns the fallback behavior, static if it isn't set
+func (f *Fallback) GetBehavior() FallbackBehavior {
+	if f.Behavior == "" {
+		return FallbackBehaviorStatic
+	}
+	return f.Behavior
+}
+
+// GetMaxMetricAge returns the maximum age of the last known metric
+func (f *Fallback) GetMaxMetricAge() time.Duration {
+	if f.MaxMetricAge == nil {
+		return DefaultFallbackMaxMetricAge
+	}
+	return f.MaxMetricAge.Duration
+}
+
+// GetReplicas returns the replica count the fallback scales to for the current replica count,
+// the lastKnownMetric behavior scales to fallback.replicas when no metric is available
+func (f *Fallback) GetReplicas(currentReplicas int32) int32 {
+	switch f.GetBehavior() {
+	case FallbackBehaviorCurrentReplicas:
+		return currentReplicas
+	case FallbackBehaviorCurrentReplicasIfHigher:
+		return max(currentReplicas, f.Replicas)
+	default:
+		return f.Replicas
+	}
+}
+
+// ActivationPolicy decides whether the ScaledObject is active, ie. scaled from zero or idle, from the activity of its triggers
+type ActivationPolicy struct {
+	// Mode is any, all or quorum, defaults to any
+	// +optional
+	// +kubebuilder:validation:Enum=any;all;quorum
+	Mode ActivationPolicyMode `json:"mode,omitempty"`
+	// Quorum is the minimum number of active triggers required by the quorum mode
+	// +optional
+	Quorum *int32 `json:"quorum,omitempty"`
+	// VetoTriggers are the names of the triggers forcing the ScaledObject inactive while they are active,
+	// they are not counted by the mode
+	// +optional
+	VetoTriggers []string `json:"vetoTriggers,omitempty"`
+}
+
+// ActivationPolicyMode is the way the activity of the triggers is combined
+type ActivationPolicyMode string
+
+const (
+	// ActivationPolicyAny activates the ScaledObject if any trigger is active
+	ActivationPolicyAny ActivationPolicyMode = "any"
+
+	// ActivationPolicyAll activates the ScaledObject if all triggers are active
+	ActivationPolicyAll ActivationPolicyMode = "all"
+
+	// ActivationPolicyQuorum activates the ScaledObject if at least quorum triggers are active
+	ActivationPolicyQuorum ActivationPolicyMode = "quorum"
+)
+
+// GetMode returns the activation policy mode, any if it isn't set
+func (p *ActivationPolicy) GetMode() ActivationPolicyMode {
+	if p == nil || p.Mode == "" {
+		return ActivationPolicyAny
+	}
+	return p.Mode
+}
+
+// IsVetoTrigger returns whether the trigger with the given name is a veto trigger
+func (p *ActivationPolicy) IsVetoTrigger(triggerName string) bool {
+	if p == nil || triggerName == "" {
+		return false
+	}
+	for _, name := range p.VetoTriggers {
+		if name == triggerName {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadinessGate lists the dependencies which must have ready replicas before the scale target is activated
+type ReadinessGate struct {
+	// Workloads are Deployments or StatefulSets in the namespace of the ScaledObject
+	// +optional
+	Workloads []ReadinessGateWorkload `json:"workloads,omitempty"`
+	// ScaledObjects are names of ScaledObjects in the namespace of the ScaledObject, their scale target must have ready replicas
+	// +optional
+	ScaledObjects []string `json:"scaledObjects,omitempty"`
+	// PodSelector is a label selector of pods in the namespace of the ScaledObject, at least one of them must be ready
+	// +optional
+	PodSelector string `json:"podSelector,omitempty"`
+}
+
+// ReadinessGateWorkload references a workload of the readiness gate
+type ReadinessGateWorkload struct {
+	// Kind is Deployment or StatefulSet, defaults to Deployment
+	// +optional
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name"`
+}
+
+// GetKind returns the kind of the workload, Deployment if it isn't set
+func (w *ReadinessGateWorkload) GetKind() string {
+	if w.Kind == "" {
+		return "Deployment"
+	}
+	return w.Kind
 }
 
 // AdvancedConfig specifies advance scaling options
@@ -116,6 +269,66 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// Schedules override the replica counts of the ScaledObject while their window is active,
+	// the first active schedule of the list is used
+	// +optional
+	Schedules []ReplicaSchedule `json:"schedules,omitempty"`
+	// ActivationRamp scales the ScaleTarget from zero or idle step by step instead of jumping to minReplicaCount
+	// +optional
+	ActivationRamp *ActivationRamp `json:"activationRamp,omitempty"`
+}
+
+// ActivationRamp scales the ScaleTarget from zero or idle to initialReplicas, then adds stepReplicas every stepInterval
+// until the replica count derived from the current metrics is reached
+type ActivationRamp struct {
+	// InitialReplicas is the replica count the ScaleTarget is activated with, minReplicaCount is used if it is higher
+	InitialReplicas int32 `json:"initialReplicas"`
+	// StepReplicas is the number of replicas added by each step
+	StepReplicas int32 `json:"stepReplicas"`
+	// StepInterval is the time between two steps, eg. "30s"
+	StepInterval metav1.Duration `json:"stepInterval"`
+}
+
+// ActivationRampStatus is the state of the ramp of the ScaleTarget from zero or idle
+type ActivationRampStatus struct {
+	// TargetReplicas is the replica count the ramp scales up to, derived from the current metrics
+	TargetReplicas int32 `json:"targetReplicas"`
+	// LastStepTime is the last time the ramp scaled the ScaleTarget up
+	LastStepTime metav1.Time `json:"lastStepTime"`
+}
+
+// PauseSchedule pauses the autoscaling for the given duration every time the cron expression fires
+type PauseSchedule struct {
+	// Schedule is the cron expression starting the pause, eg. "0 22 * * 5"
+	Schedule string `json:"schedule"`
+	// Duration of the pause, eg. "2h"
+	Duration metav1.Duration `json:"duration"`
+	// Timezone of the cron expression as IANA Time Zone Database name, defaults to UTC
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// PausedReplicaCount is the replica count the scale target is scaled to while paused, only supported by ScaledObject
+	// +optional
+	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
+}
+
+// ReplicaSchedule overrides the replica counts of the ScaledObject between the start and the end cron expressions
+type ReplicaSchedule struct {
+	// Name identifies the schedule, it is reported in status.activeSchedule while the schedule is active
+	Name string `json:"name"`
+	// Start is the cron expression opening the window, eg. "0 8 * * 1-5"
+	Start string `json:"start"`
+	// End is the cron expression closing the window, eg. "0 18 * * 1-5"
+	End string `json:"end"`
+	// Timezone of the cron expressions as IANA Time Zone Database name, defaults to UTC
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// MaxReplicaCount overrides the maximum replica count, 0 keeps the scale target scaled to zero
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+	// +optional
+	IdleReplicaCount *int32 `json:"idleReplicaCount,omitempty"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
@@ -126,6 +339,38 @@ type ScalingModifiers struct {
 	ActivationTarget string `json:"activationTarget,omitempty"`
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// Aggregation combines the metrics of all triggers into the composite metric, it can't be used together with Formula
+	// +optional
+	// +kubebuilder:validation:Enum=sum;avg;min;max;weighted
+	Aggregation ScalingModifiersAggregation `json:"aggregation,omitempty"`
+	// Weights are the per trigger name multipliers used by the weighted aggregation, triggers without weight count once
+	// +optional
+	Weights map[string]string `json:"weights,omitempty"`
+}
+
+// ScalingModifiersAggregation is the operator used to combine the triggers metrics
+type ScalingModifiersAggregation string
+
+const (
+	// AggregationSum adds up the metrics of all triggers
+	AggregationSum ScalingModifiersAggregation = "sum"
+
+	// AggregationAvg averages the metrics of all triggers
+	AggregationAvg ScalingModifiersAggregation = "avg"
+
+	// AggregationMin takes the lowest metric of all triggers
+	AggregationMin ScalingModifiersAggregation = "min"
+
+	// AggregationMax takes the highest metric of all triggers
+	AggregationMax ScalingModifiersAggregation = "max"
+
+	// AggregationWeighted adds up the metrics of all triggers multiplied by their weight
+	AggregationWeighted ScalingModifiersAggregation = "weighted"
+)
+
+// RequiresTriggerNames returns true when the metrics have to be paired with their trigger names
+func (sm ScalingModifiers) RequiresTriggerNames() bool {
+	return sm.Formula != "" || sm.Aggregation == AggregationWeighted
 }
 
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
@@ -174,6 +419,29 @@ type ScaledObjectStatus struct {
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
+	// ActiveSchedule is the name of the schedule in spec.advanced.schedules currently in force
+	// +optional
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+	// ActivationTriggers are the triggers which decided the activity according to spec.activationPolicy,
+	// ie. the active triggers or the active veto triggers forcing the ScaledObject inactive
+	// +optional
+	ActivationTriggers []string `json:"activationTriggers,omitempty"`
+	// TriggersLastActiveTime is the last time each trigger was active while the ScaledObject was active,
+	// keyed by trigger name, or by type and index for triggers without name. It is refreshed once it is
+	// older than a tenth of the trigger cooldown period
+	// +optional
+	TriggersLastActiveTime map[string]metav1.Time `json:"triggersLastActiveTime,omitempty"`
+	// ActivationRamp is the state of the ramp from zero or idle in progress, see spec.advanced.activationRamp
+	// +optional
+	ActivationRamp *ActivationRampStatus `json:"activationRamp,omitempty"`
+	// DryRunReplicas is the replica count the ScaleTarget would be scaled to if the ScaledObject
+	// wasn't in dry-run mode, see the autoscaling.keda.sh/dry-run annotation
+	// +optional
+	DryRunReplicas *int32 `json:"dryRunReplicas,omitempty"`
+	// ScalingDecisions are the latest scaling decisions, oldest first, reported when
+	// the autoscaling.keda.sh/scaling-decision-history annotation is set
+	// +optional
+	ScalingDecisions []ScalingDecision `json:"scalingDecisions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -225,6 +493,13 @@ func (so *ScaledObject) NeedToBePausedByAnnotation() bool {
 	return shouldPause
 }
 
+// IsDryRun returns whether the ScaledObject is in dry-run mode, ie. DryRunAnnotation is set to true:
+// the triggers are evaluated but no HPA is created and the ScaleTarget is never scaled
+func (so *ScaledObject) IsDryRun() bool {
+	dryRun, err := strconv.ParseBool(so.GetAnnotations()[DryRunAnnotation])
+	return err == nil && dryRun
+}
+
 // IsUsingModifiers determines whether scalingModifiers are defined or not
 func (so *ScaledObject) IsUsingModifiers() bool {
 	return so.Spec.Advanced != nil && !reflect.DeepEqual(so.Spec.Advanced.ScalingModifiers, ScalingModifiers{})
@@ -232,8 +507,9 @@ func (so *ScaledObject) IsUsingModifiers() bool {
 
 // getHPAMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
 func (so *ScaledObject) GetHPAMinReplicas() *int32 {
-	if so.Spec.MinReplicaCount != nil && *so.Spec.MinReplicaCount > 0 {
-		return so.Spec.MinReplicaCount
+	minReplicaCount := so.GetMinReplicaCount()
+	if minReplicaCount != nil && *minReplicaCount > 0 {
+		return minReplicaCount
 	}
 	tmp := defaultHPAMinReplicas
 	return &tmp
@@ -241,27 +517,57 @@ func (so *ScaledObject) GetHPAMinReplicas() *int32 {
 
 // getHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
 func (so *ScaledObject) GetHPAMaxReplicas() int32 {
-	if so.Spec.MaxReplicaCount != nil {
-		return *so.Spec.MaxReplicaCount
+	maxReplicaCount := so.GetMaxReplicaCount()
+	if maxReplicaCount == nil {
+		return defaultHPAMaxReplicas
 	}
-	return defaultHPAMaxReplicas
+	// MaxReplicas on HPA can't be 0, the scale target is kept scaled to zero by KEDA instead
+	if so.IsScaledToZeroBySchedule() {
+		return *so.GetHPAMinReplicas()
+	}
+	return *maxReplicaCount
 }
 
 // checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
-// i.e. that Min is not greater than Max or Idle greater or equal to Min
+// i.e. that Min is not greater than Max or Idle greater or equal to Min, also for the overrides of each schedule
 func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
+	if err := checkReplicaCountBounds(scaledObject.Spec.MinReplicaCount, scaledObject.Spec.MaxReplicaCount, scaledObject.Spec.IdleReplicaCount); err != nil {
+		return err
+	}
+
+	for _, schedule := range scaledObject.getSchedules() {
+		minReplicaCount, maxReplicaCount, idleReplicaCount := schedule.overrideReplicaCounts(scaledObject.Spec.MinReplicaCount, scaledObject.Spec.MaxReplicaCount, scaledObject.Spec.IdleReplicaCount)
+		if maxReplicaCount != nil && *maxReplicaCount == 0 {
+			// the scale target is kept scaled to zero, the other replica counts don't apply
+			continue
+		}
+		if err := checkReplicaCountBounds(minReplicaCount, maxReplicaCount, idleReplicaCount); err != nil {
+			return fmt.Errorf("schedule %q: %w", schedule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func checkReplicaCountBounds(minReplicaCount, maxReplicaCount, idleReplicaCount *int32) error {
 	min := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		min = *scaledObject.GetHPAMinReplicas()
+	if minReplicaCount != nil {
+		min = defaultHPAMinReplicas
+		if *minReplicaCount > 0 {
+			min = *minReplicaCount
+		}
+	}
+	max := defaultHPAMaxReplicas
+	if maxReplicaCount != nil {
+		max = *maxReplicaCount
 	}
-	max := scaledObject.GetHPAMaxReplicas()
 
 	if min > max {
 		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", min, max)
 	}
 
-	if scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= min {
-		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
+	if idleReplicaCount != nil && *idleReplicaCount >= min {
+		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *idleReplicaCount, min)
 	}
 
 	return nil
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFallbackGetReplicas(t *testing.T) {
+	tests := []struct {
+		behavior        FallbackBehavior
+		currentReplicas int32
+		expected        int32
+	}{
+		{behavior: "", currentReplicas: 2, expected: 5},
+		{behavior: FallbackBehaviorStatic, currentReplicas: 2, expected: 5},
+		{behavior: FallbackBehaviorCurrentReplicas, currentReplicas: 2, expected: 2},
+		{behavior: FallbackBehaviorCurrentReplicasIfHigher, currentReplicas: 2, expected: 5},
+		{behavior: FallbackBehaviorCurrentReplicasIfHigher, currentReplicas: 8, expected: 8},
+		{behavior: FallbackBehaviorLastKnownMetric, currentReplicas: 8, expected: 5},
+	}
+
+	for _, test := range tests {
+		fallback := &Fallback{FailureThreshold: 3, Replicas: 5, Behavior: test.behavior}
+		assert.Equal(t, test.expected, fallback.GetReplicas(test.currentReplicas), "behavior %q", test.behavior)
+	}
+}
+
+func TestFallbackGetMaxMetricAge(t *testing.T) {
+	fallback := &Fallback{Behavior: FallbackBehaviorLastKnownMetric}
+	assert.Equal(t, DefaultFallbackMaxMetricAge, fallback.GetMaxMetricAge())
+
+	fallback.MaxMetricAge = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, fallback.GetMaxMetricAge())
+}
+
+func TestVerifyFallback(t *testing.T) {
+	tests := []struct {
+		name           string
+		fallback       *Fallback
+		expectedErrMsg string
+	}{
+		{
+			name: "no fallback",
+		},
+		{
+			name:     "lastKnownMetric with maxMetricAge",
+			fallback: &Fallback{Behavior: FallbackBehaviorLastKnownMetric, MaxMetricAge: &metav1.Duration{Duration: time.Minute}},
+		},
+		{
+			name:           "maxMetricAge with static behavior",
+			fallback:       &Fallback{MaxMetricAge: &metav1.Duration{Duration: time.Minute}},
+			expectedErrMsg: `fallback.maxMetricAge is only supported by the "lastKnownMetric" behavior`,
+		},
+		{
+			name:           "negative maxMetricAge",
+			fallback:       &Fallback{Behavior: FallbackBehaviorLastKnownMetric, MaxMetricAge: &metav1.Duration{Duration: -time.Minute}},
+			expectedErrMsg: "fallback.maxMetricAge=-1m0s must be greater than 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{Spec: ScaledObjectSpec{Fallback: test.fallback}}
+			err := verifyFallback(so, "create", false)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
@@ -30,6 +32,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -136,6 +139,14 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		verifyScaledObjects,
 		verifyHpas,
 		verifyReplicaCount,
+		verifyFallback,
+		verifySchedules,
+		verifyPauseSchedules,
+		verifyActivationPolicy,
+		verifyReadinessGate,
+		verifyActivationRamp,
+		verifyScalingDecisionHistory,
+		verifyDryRun,
 	}
 
 	for i := range verifyFunctions {
@@ -145,8 +156,13 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		}
 	}
 
+	warnings, err := verifyTriggersMetadata(so, action)
+	if err != nil {
+		return warnings, err
+	}
+
 	scaledobjectlog.V(1).Info(fmt.Sprintf("scaledobject %s is valid", so.Name))
-	return nil, nil
+	return warnings, nil
 }
 
 func verifyReplicaCount(incomingSo *ScaledObject, action string, _ bool) error {
@@ -158,6 +174,209 @@ func verifyReplicaCount(incomingSo *ScaledObject, action string, _ bool) error {
 	return nil
 }
 
+func verifyFallback(incomingSo *ScaledObject, action string, _ bool) error {
+	err := verifyFallbackMaxMetricAge(incomingSo.Spec.Fallback)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-fallback")
+	}
+	return err
+}
+
+func verifySchedules(incomingSo *ScaledObject, action string, _ bool) error {
+	err := validateSchedules(incomingSo.getSchedules())
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-schedules")
+	}
+	return err
+}
+
+func verifyPauseSchedules(incomingSo *ScaledObject, action string, _ bool) error {
+	err := validatePauseSchedules(incomingSo.Spec.PauseSchedules, true)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-pause-schedules")
+	}
+	return err
+}
+
+func validateSchedules(schedules []ReplicaSchedule) error {
+	names := map[string]bool{}
+	for _, schedule := range schedules {
+		if schedule.Name == "" {
+			return fmt.Errorf("schedule name is required")
+		}
+		if names[schedule.Name] {
+			return fmt.Errorf("schedule %q is defined multiple times", schedule.Name)
+		}
+		names[schedule.Name] = true
+
+		if slices.ContainsFunc([]*int32{schedule.MinReplicaCount, schedule.MaxReplicaCount, schedule.IdleReplicaCount}, func(count *int32) bool {
+			return count != nil && *count < 0
+		}) {
+			return fmt.Errorf("schedule %q: replica counts must not be negative", schedule.Name)
+		}
+		if err := schedule.Validate(); err != nil {
+			return fmt.Errorf("schedule %q: %w", schedule.Name, err)
+		}
+	}
+	return nil
+}
+
+func verifyActivationPolicy(incomingSo *ScaledObject, action string, _ bool) error {
+	err := validateActivationPolicy(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-activation-policy")
+	}
+	return err
+}
+
+func validateActivationPolicy(so *ScaledObject) error {
+	policy := so.Spec.ActivationPolicy
+	if policy == nil {
+		return nil
+	}
+	if so.IsUsingModifiers() {
+		return fmt.Errorf("activationPolicy can't be used together with scalingModifiers")
+	}
+
+	triggerTypes := map[string]string{}
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Name != "" {
+			triggerTypes[trigger.Name] = trigger.Type
+		}
+	}
+	for _, name := range policy.VetoTriggers {
+		triggerType, found := triggerTypes[name]
+		if !found {
+			return fmt.Errorf("activationPolicy.vetoTriggers: trigger %q is not defined", name)
+		}
+		if triggerType == cpuString || triggerType == memoryString {
+			return fmt.Errorf("activationPolicy.vetoTriggers: %s trigger %q can't be a veto trigger", triggerType, name)
+		}
+	}
+
+	if policy.GetMode() != ActivationPolicyQuorum {
+		if policy.Quorum != nil {
+			return fmt.Errorf("activationPolicy.quorum is only supported by the %q mode", ActivationPolicyQuorum)
+		}
+		return nil
+	}
+	if policy.Quorum == nil {
+		return fmt.Errorf("activationPolicy.quorum is required by the %q mode", ActivationPolicyQuorum)
+	}
+	counted := 0
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.Type != cpuString && trigger.Type != memoryString && !policy.IsVetoTrigger(trigger.Name) {
+			counted++
+		}
+	}
+	if *policy.Quorum < 1 || int(*policy.Quorum) > counted {
+		return fmt.Errorf("activationPolicy.quorum=%d must be between 1 and the number of triggers counted by the policy (%d)", *policy.Quorum, counted)
+	}
+	return nil
+}
+
+func verifyReadinessGate(incomingSo *ScaledObject, action string, _ bool) error {
+	err := validateReadinessGate(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-readiness-gate")
+	}
+	return err
+}
+
+func validateReadinessGate(so *ScaledObject) error {
+	gate := so.Spec.ReadinessGate
+	if gate == nil {
+		return nil
+	}
+	for i, workload := range gate.Workloads {
+		if workload.Name == "" {
+			return fmt.Errorf("readinessGate.workloads[%d]: name is required", i)
+		}
+		if kind := workload.GetKind(); kind != "Deployment" && kind != "StatefulSet" {
+			return fmt.Errorf("readinessGate.workloads[%d]: kind %s is not supported, only Deployment and StatefulSet are", i, kind)
+		}
+	}
+	for _, name := range gate.ScaledObjects {
+		if name == "" {
+			return fmt.Errorf("readinessGate.scaledObjects: name is required")
+		}
+		if name == so.Name {
+			return fmt.Errorf("readinessGate.scaledObjects: ScaledObject %s can't depend on itself", name)
+		}
+	}
+	if gate.PodSelector != "" {
+		if _, err := labels.Parse(gate.PodSelector); err != nil {
+			return fmt.Errorf("readinessGate.podSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+func verifyActivationRamp(incomingSo *ScaledObject, action string, _ bool) error {
+	err := validateActivationRamp(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-activation-ramp")
+	}
+	return err
+}
+
+func validateActivationRamp(so *ScaledObject) error {
+	ramp := so.GetActivationRamp()
+	if ramp == nil {
+		return nil
+	}
+	if err := ramp.Validate(); err != nil {
+		return fmt.Errorf("activationRamp: %w", err)
+	}
+	if so.Spec.MaxReplicaCount != nil && ramp.InitialReplicas > *so.Spec.MaxReplicaCount {
+		return fmt.Errorf("activationRamp: initialReplicas=%d must not be greater than MaxReplicaCount=%d", ramp.InitialReplicas, *so.Spec.MaxReplicaCount)
+	}
+	return nil
+}
+
+func verifyScalingDecisionHistory(incomingSo *ScaledObject, action string, _ bool) error {
+	_, err := GetScalingDecisionHistoryLimit(incomingSo.Annotations)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-scaling-decision-history")
+	}
+	return err
+}
+
+func verifyDryRun(incomingSo *ScaledObject, action string, _ bool) error {
+	value, found := incomingSo.Annotations[DryRunAnnotation]
+	if !found {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		err = fmt.Errorf("annotation %s=%q must be a boolean", DryRunAnnotation, value)
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-dry-run")
+		return err
+	}
+	return nil
+}
+
+// verifyFallbackMaxMetricAge checks the fallback.maxMetricAge, shared by ScaledObject and ScaledJob
+func verifyFallbackMaxMetricAge(fallback *Fallback) error {
+	if fallback == nil || fallback.MaxMetricAge == nil {
+		return nil
+	}
+	switch {
+	case fallback.GetBehavior() != FallbackBehaviorLastKnownMetric:
+		return fmt.Errorf("fallback.maxMetricAge is only supported by the %q behavior", FallbackBehaviorLastKnownMetric)
+	case fallback.MaxMetricAge.Duration <= 0:
+		return fmt.Errorf("fallback.maxMetricAge=%s must be greater than 0", fallback.MaxMetricAge.Duration)
+	}
+	return nil
+}
+
 func verifyTriggers(incomingSo *ScaledObject, action string, _ bool) error {
 	err := ValidateTriggers(incomingSo.Spec.Triggers)
 	if err != nil {
@@ -167,7 +386,20 @@ func verifyTriggers(incomingSo *ScaledObject, action string, _ bool) error {
 	return err
 }
 
+func verifyTriggersMetadata(incomingSo *ScaledObject, action string) (admission.Warnings, error) {
+	warnings, err := ValidateTriggersMetadata(incomingSo.Spec.Triggers)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-trigger-metadata")
+	}
+	return warnings, err
+}
+
 func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
+	// a ScaledObject in dry-run mode doesn't create an HPA, so it can shadow the existing autoscaler
+	if incomingSo.IsDryRun() {
+		return nil
+	}
 	hpaList := &autoscalingv2.HorizontalPodAutoscalerList{}
 	opt := &client.ListOptions{
 		Namespace: incomingSo.Namespace,
@@ -239,7 +471,8 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 	}
 
 	for _, so := range soList.Items {
-		if so.Name == incomingSo.Name {
+		// ScaledObjects in dry-run mode never scale the workload, they can shadow each other
+		if so.Name == incomingSo.Name || so.IsDryRun() || incomingSo.IsDryRun() {
 			continue
 		}
 		val, _ := json.MarshalIndent(so, "", "  ")
@@ -362,7 +595,26 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 // (with dummy values that determine whether all necessary triggers are defined)
 // and returns it to be stored in cache and reused.
 func ValidateAndCompileScalingModifiers(so *ScaledObject) (*vm.Program, error) {
-	sm := so.Spec.Advanced.ScalingModifiers
+	return validateAndCompileScalingModifiers(&so.Spec.Advanced.ScalingModifiers, so.Spec.Triggers)
+}
+
+// validateAndCompileScalingModifiers validates the scalingModifiers of a ScaledObject or a ScaledJob
+// against its triggers and compiles the formula, which is cast to float in place
+func validateAndCompileScalingModifiers(scalingModifiers *ScalingModifiers, triggers []ScaleTriggers) (*vm.Program, error) {
+	sm := *scalingModifiers
+
+	if sm.Aggregation != "" {
+		if sm.Formula != "" {
+			return nil, fmt.Errorf("error ScalingModifiers.Formula and ScalingModifiers.Aggregation can't be used together")
+		}
+		if err := validateScalingModifiersAggregation(sm, triggers); err != nil {
+			return nil, errors.Join(fmt.Errorf("error validating aggregation in ScalingModifiers"), err)
+		}
+		if err := validateScalingModifiersTarget(sm); err != nil {
+			return nil, errors.Join(fmt.Errorf("error validating target in ScalingModifiers"), err)
+		}
+		return nil, nil
+	}
 
 	if sm.Formula == "" {
 		return nil, fmt.Errorf("error ScalingModifiers.Formula is mandatory")
@@ -370,16 +622,16 @@ func ValidateAndCompileScalingModifiers(so *ScaledObject) (*vm.Program, error) {
 
 	// cast return value of formula to float if necessary to avoid wrong value return
 	// type (ternary operator doesnt return float)
-	so.Spec.Advanced.ScalingModifiers.Formula = castToFloatIfNecessary(sm.Formula)
+	scalingModifiers.Formula = castToFloatIfNecessary(quoteWindowFunctionArgs(sm.Formula))
 
 	// validate formula if not empty
-	compiledFormula, err := validateScalingModifiersFormula(so)
+	compiledFormula, err := validateScalingModifiersFormula(*scalingModifiers, triggers)
 	if err != nil {
 		err := errors.Join(fmt.Errorf("error validating formula in ScalingModifiers"), err)
 		return nil, err
 	}
 	// validate target if not empty
-	err = validateScalingModifiersTarget(so)
+	err = validateScalingModifiersTarget(sm)
 	if err != nil {
 		err := errors.Join(fmt.Errorf("error validating target in ScalingModifiers"), err)
 		return nil, err
@@ -389,9 +641,7 @@ func ValidateAndCompileScalingModifiers(so *ScaledObject) (*vm.Program, error) {
 
 // validateScalingModifiersFormula helps validate the ScalingModifiers struct,
 // specifically the formula.
-func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
-	sm := so.Spec.Advanced.ScalingModifiers
-
+func validateScalingModifiersFormula(sm ScalingModifiers, triggers []ScaleTriggers) (*vm.Program, error) {
 	// if formula is empty, nothing to validate
 	if sm.Formula == "" {
 		return nil, nil
@@ -407,29 +657,79 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 	// Compile & Run with dummy values to determine if all triggers in formula are
 	// defined (have names)
 	triggersMap := make(map[string]float64)
-	for _, trig := range so.Spec.Triggers {
+	for _, trig := range triggers {
 		// if resource metrics are given, skip
 		if trig.Type == cpuString || trig.Type == memoryString {
 			continue
 		}
 		if trig.Name != "" {
+			if slices.Contains(ScalingModifiersWindowFunctions, trig.Name) {
+				return nil, fmt.Errorf("trigger name %q is reserved for a scalingModifiers function", trig.Name)
+			}
 			triggersMap[trig.Name] = dummyValue
 		}
 	}
-	compiled, err := expr.Compile(sm.Formula, expr.Env(triggersMap), expr.AsFloat64())
+	// window functions are validated against the defined triggers
+	env := ScalingModifiersEnv(triggersMap, func(fn, trigger string, _ time.Duration) (float64, error) {
+		if _, found := triggersMap[trigger]; !found {
+			return 0, fmt.Errorf("%s: unknown trigger %q", fn, trigger)
+		}
+		return dummyValue, nil
+	})
+	compiled, err := expr.Compile(sm.Formula, expr.Env(env), expr.AsFloat64())
 	if err != nil {
 		return nil, err
 	}
-	_, err = expr.Run(compiled, triggersMap)
+	_, err = expr.Run(compiled, env)
 	if err != nil {
 		return nil, err
 	}
 	return compiled, nil
 }
 
-func validateScalingModifiersTarget(so *ScaledObject) error {
-	sm := so.Spec.Advanced.ScalingModifiers
+// validateScalingModifiersAggregation helps validate the ScalingModifiers struct,
+// specifically the aggregation and its weights.
+func validateScalingModifiersAggregation(sm ScalingModifiers, triggers []ScaleTriggers) error {
+	switch sm.Aggregation {
+	case AggregationSum, AggregationAvg, AggregationMin, AggregationMax:
+		if len(sm.Weights) > 0 {
+			return fmt.Errorf("weights are only supported by the %q aggregation", AggregationWeighted)
+		}
+	case AggregationWeighted:
+	default:
+		return fmt.Errorf("aggregation %q is not supported, allowed values are %q", sm.Aggregation,
+			[]ScalingModifiersAggregation{AggregationSum, AggregationAvg, AggregationMin, AggregationMax, AggregationWeighted})
+	}
+
+	// aggregation needs target because it's always transformed to composite-scaler
+	if sm.Target == "" {
+		return fmt.Errorf("aggregation is given but target is empty")
+	}
+
+	triggerNames := make(map[string]bool)
+	for _, trig := range triggers {
+		// resource metrics are not part of the composite metric
+		if trig.Type == cpuString || trig.Type == memoryString {
+			continue
+		}
+		if sm.Aggregation == AggregationWeighted && trig.Name == "" {
+			return fmt.Errorf("trigger of type %q needs a name to be used by the %q aggregation", trig.Type, AggregationWeighted)
+		}
+		triggerNames[trig.Name] = true
+	}
+
+	for name, weight := range sm.Weights {
+		if !triggerNames[name] {
+			return fmt.Errorf("weight is given for unknown trigger %q", name)
+		}
+		if _, err := strconv.ParseFloat(weight, 64); err != nil {
+			return fmt.Errorf("error converting weight %q of trigger %q (string->float): %w", weight, name, err)
+		}
+	}
+	return nil
+}
 
+func validateScalingModifiersTarget(sm ScalingModifiers) error {
 	if sm.Target == "" {
 		return nil
 	}
@@ -440,7 +740,7 @@ func validateScalingModifiersTarget(so *ScaledObject) error {
 		return fmt.Errorf("error converting target for scalingModifiers (string->float) to valid target: %w", err)
 	}
 
-	if so.Spec.Advanced.ScalingModifiers.MetricType == autoscalingv2.UtilizationMetricType {
+	if sm.MetricType == autoscalingv2.UtilizationMetricType {
 		err := fmt.Errorf("error trigger type is Utilization, but it needs to be AverageValue or Value for external metrics")
 		return err
 	}
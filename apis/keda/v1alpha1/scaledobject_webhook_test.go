@@ -196,6 +196,44 @@ var _ = It("shouldn't validate the so creation when there is another so", func()
 	}).Should(HaveOccurred())
 })
 
+var _ = It("should validate the so creation in dry-run mode when there is another unmanaged hpa", func() {
+
+	hpaName := "test-dry-run-hpa"
+	namespaceName := "dry-run-hpa"
+	namespace := createNamespace(namespaceName)
+	hpa := createHpa(hpaName, namespaceName, workloadName, "apps/v1", "Deployment", nil)
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{DryRunAnnotation: "true"}, "")
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), hpa)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).ShouldNot(HaveOccurred())
+})
+
+var _ = It("should validate the so creation in dry-run mode when there is another so", func() {
+
+	so2Name := "test-so2"
+	namespaceName := "dry-run-so"
+	namespace := createNamespace(namespaceName)
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{DryRunAnnotation: "true"}, "")
+	so2 := createScaledObject(so2Name, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = k8sClient.Create(context.Background(), so2)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).ShouldNot(HaveOccurred())
+})
+
 var _ = It("shouldn't validate the so creation when there is another hpa with custom apis", func() {
 
 	hpaName := "test-custom-hpa"
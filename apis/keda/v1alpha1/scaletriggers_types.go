@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -35,6 +36,19 @@ type ScaleTriggers struct {
 	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
 	// +optional
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+	// CooldownPeriod overrides the cooldown period of the ScaledObject after this trigger was last active, in seconds,
+	// only supported by ScaledObject
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+}
+
+// GetTriggerStatusKey returns the key of the trigger in status.triggersLastActiveTime,
+// ie. the name of the trigger or its type and index if it isn't named
+func GetTriggerStatusKey(trigger ScaleTriggers, index int) string {
+	if trigger.Name != "" {
+		return trigger.Name
+	}
+	return fmt.Sprintf("%s-%d", trigger.Type, index)
 }
 
 // AuthenticationRef points to the TriggerAuthentication or ClusterTriggerAuthentication object that
@@ -49,6 +63,7 @@ type AuthenticationRef struct {
 // ValidateTriggers checks that general trigger metadata are valid, it checks:
 // - triggerNames in ScaledObject are unique
 // - useCachedMetrics is defined only for a supported triggers
+// - cooldownPeriod is not negative and defined only for triggers with activity
 func ValidateTriggers(triggers []ScaleTriggers) error {
 	triggersCount := len(triggers)
 	if triggers != nil && triggersCount > 0 {
@@ -62,6 +77,15 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 				}
 			}
 
+			if trigger.CooldownPeriod != nil {
+				if trigger.Type == "cpu" || trigger.Type == "memory" {
+					return fmt.Errorf("property \"cooldownPeriod\" is not supported for %q scaler", trigger.Type)
+				}
+				if *trigger.CooldownPeriod < 0 {
+					return fmt.Errorf("property \"cooldownPeriod\"=%d must not be negative", *trigger.CooldownPeriod)
+				}
+			}
+
 			name := trigger.Name
 			if name != "" {
 				if _, found := triggerNames[name]; found {
@@ -75,3 +99,42 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 
 	return nil
 }
+
+// TriggerMetadataValidator checks a trigger's metadata without connecting to the scaler source,
+// it returns warnings for the deprecated parameters in use
+type TriggerMetadataValidator func(triggerType string, metricType autoscalingv2.MetricTargetType, metadata map[string]string) ([]string, error)
+
+var triggerMetadataValidator TriggerMetadataValidator
+
+// SetTriggerMetadataValidator sets the validator used by the webhooks to check the triggers metadata,
+// the scalers can't be referenced from this package so it is provided by the webhooks binary
+func SetTriggerMetadataValidator(validator TriggerMetadataValidator) {
+	triggerMetadataValidator = validator
+}
+
+// ValidateTriggersMetadata checks the metadata of every trigger with the validator set by SetTriggerMetadataValidator,
+// the problems of all the triggers are returned together. The webhooks validator only checks the metadata of the
+// scalers which declare it, see scalers.ValidatedTriggerTypes, and returns a warning for the other triggers.
+func ValidateTriggersMetadata(triggers []ScaleTriggers) ([]string, error) {
+	if triggerMetadataValidator == nil {
+		return nil, nil
+	}
+
+	var warnings []string
+	var errs []error
+	for i, trigger := range triggers {
+		id := fmt.Sprintf("trigger %d (%s)", i, trigger.Type)
+		if trigger.Name != "" {
+			id = fmt.Sprintf("trigger %q (%s)", trigger.Name, trigger.Type)
+		}
+
+		triggerWarnings, err := triggerMetadataValidator(trigger.Type, trigger.MetricType, trigger.Metadata)
+		for _, warning := range triggerWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", id, warning))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
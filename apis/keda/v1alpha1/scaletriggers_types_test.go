@@ -1,9 +1,12 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateTriggers(t *testing.T) {
@@ -84,6 +87,39 @@ func TestValidateTriggers(t *testing.T) {
 			},
 			expectedErrMsg: "",
 		},
+		{
+			name: "cooldownPeriod property for cron scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:           "trigger5",
+					Type:           "cron",
+					CooldownPeriod: ptr.To[int32](0),
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "unsupported cooldownPeriod property for cpu scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:           "trigger6",
+					Type:           "cpu",
+					CooldownPeriod: ptr.To[int32](60),
+				},
+			},
+			expectedErrMsg: "property \"cooldownPeriod\" is not supported for \"cpu\" scaler",
+		},
+		{
+			name: "negative cooldownPeriod property",
+			triggers: []ScaleTriggers{
+				{
+					Name:           "trigger7",
+					Type:           "kafka",
+					CooldownPeriod: ptr.To[int32](-1),
+				},
+			},
+			expectedErrMsg: "property \"cooldownPeriod\"=-1 must not be negative",
+		},
 	}
 
 	for _, test := range tests {
@@ -98,3 +134,36 @@ func TestValidateTriggers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTriggersMetadata(t *testing.T) {
+	defer SetTriggerMetadataValidator(nil)
+
+	triggers := []ScaleTriggers{
+		{Type: "valid"},
+		{Name: "deprecated", Type: "valid", Metadata: map[string]string{"old": "value"}},
+		{Type: "invalid"},
+	}
+
+	warnings, err := ValidateTriggersMetadata(triggers)
+	assert.NoError(t, err, "no validator set")
+	assert.Empty(t, warnings)
+
+	SetTriggerMetadataValidator(func(triggerType string, _ autoscalingv2.MetricTargetType, metadata map[string]string) ([]string, error) {
+		if triggerType == "invalid" {
+			return nil, errors.New("unknown metadata parameters")
+		}
+		if _, ok := metadata["old"]; ok {
+			return []string{`parameter "old" is deprecated`}, nil
+		}
+		return nil, nil
+	})
+
+	warnings, err = ValidateTriggersMetadata(triggers)
+	assert.EqualError(t, err, "trigger 2 (invalid): unknown metadata parameters")
+	assert.Equal(t, []string{`trigger "deprecated" (valid): parameter "old" is deprecated`}, warnings)
+}
+
+func TestGetTriggerStatusKey(t *testing.T) {
+	assert.Equal(t, "queue", GetTriggerStatusKey(ScaleTriggers{Name: "queue", Type: "rabbitmq"}, 0))
+	assert.Equal(t, "cron-1", GetTriggerStatusKey(ScaleTriggers{Type: "cron"}, 1))
+}
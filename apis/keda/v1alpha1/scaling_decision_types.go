@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScalingDecisionHistoryAnnotation is the number of the latest scaling decisions reported in status.scalingDecisions
+const ScalingDecisionHistoryAnnotation = "autoscaling.keda.sh/scaling-decision-history"
+
+// MaxScalingDecisionHistory is the maximum number of scaling decisions reported in status
+const MaxScalingDecisionHistory = 20
+
+// ScalingDecision records the inputs and the outcome of one evaluation of the triggers of a ScaledObject or ScaledJob
+type ScalingDecision struct {
+	// Time is when the triggers were evaluated
+	Time metav1.Time `json:"time"`
+	// Triggers are the metric values and the activity of each trigger
+	// +optional
+	Triggers []TriggerDecision `json:"triggers,omitempty"`
+	// FormulaValue is the output of the scalingModifiers formula, if any
+	// +optional
+	FormulaValue *resource.Quantity `json:"formulaValue,omitempty"`
+	// IsActive is whether the ScaledObject or ScaledJob was active
+	IsActive bool `json:"isActive"`
+	// IsError is whether any trigger failed
+	// +optional
+	IsError bool `json:"isError,omitempty"`
+	// IsFallback is whether the fallback was in force
+	// +optional
+	IsFallback bool `json:"isFallback,omitempty"`
+	// IsPaused is whether the ScaledObject or ScaledJob was paused
+	// +optional
+	IsPaused bool `json:"isPaused,omitempty"`
+	// ReplicasBefore is the replica count of the ScaleTarget, or the number of running jobs, before the decision
+	ReplicasBefore int64 `json:"replicasBefore"`
+	// ReplicasAfter is the replica count of the ScaleTarget, or the number of jobs, after the decision
+	ReplicasAfter int64 `json:"replicasAfter"`
+}
+
+// TriggerDecision is the input of a single trigger metric to a scaling decision
+type TriggerDecision struct {
+	// Name is the name of the trigger, or its type if the trigger isn't named
+	Name string `json:"name"`
+	// MetricName is the name of the metric of the trigger
+	// +optional
+	MetricName string `json:"metricName,omitempty"`
+	// Value is the value of the metric
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+	// IsActive is whether the trigger was active
+	IsActive bool `json:"isActive"`
+	// IsError is whether the trigger failed
+	// +optional
+	IsError bool `json:"isError,omitempty"`
+}
+
+// GetScalingDecisionHistoryLimit returns the number of scaling decisions to report in status
+// given by ScalingDecisionHistoryAnnotation, 0 if the annotation isn't set
+func GetScalingDecisionHistoryLimit(annotations map[string]string) (int, error) {
+	value, found := annotations[ScalingDecisionHistoryAnnotation]
+	if !found {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("annotation %s=%q must be an integer: %w", ScalingDecisionHistoryAnnotation, value, err)
+	}
+	if limit < 0 || limit > MaxScalingDecisionHistory {
+		return 0, fmt.Errorf("annotation %s=%d must be between 0 and %d", ScalingDecisionHistoryAnnotation, limit, MaxScalingDecisionHistory)
+	}
+	return limit, nil
+}
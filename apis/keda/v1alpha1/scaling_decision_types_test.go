@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScalingDecisionHistoryLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expectedLimit  int
+		expectedErrMsg string
+	}{
+		{
+			name: "annotation not set",
+		},
+		{
+			name:          "valid limit",
+			annotations:   map[string]string{ScalingDecisionHistoryAnnotation: "5"},
+			expectedLimit: 5,
+		},
+		{
+			name:           "not an integer",
+			annotations:    map[string]string{ScalingDecisionHistoryAnnotation: "all"},
+			expectedErrMsg: `annotation autoscaling.keda.sh/scaling-decision-history="all" must be an integer`,
+		},
+		{
+			name:           "above the maximum",
+			annotations:    map[string]string{ScalingDecisionHistoryAnnotation: "21"},
+			expectedErrMsg: "annotation autoscaling.keda.sh/scaling-decision-history=21 must be between 0 and 20",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			limit, err := GetScalingDecisionHistoryLimit(test.annotations)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedLimit, limit)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
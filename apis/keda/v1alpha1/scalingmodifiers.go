@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// ScalingModifiersMaxWindow is the longest window accepted by the scalingModifiers
+// window functions, it also bounds the history of trigger values kept per ScaledObject
+const ScalingModifiersMaxWindow = time.Hour
+
+// ScalingModifiersWindowFunctions are the functions available in scalingModifiers.Formula
+// which are evaluated over the recent values of a trigger, eg. `avg_over(kafka_lag, 5m)`
+var ScalingModifiersWindowFunctions = []string{"avg_over", "max_over", "min_over", "rate", "ewma"}
+
+// windowFunctionCallRegex matches window function calls with bare trigger name and
+// window, eg. `avg_over(kafka_lag, 5m)`
+var windowFunctionCallRegex = regexp.MustCompile(`\b(avg_over|max_over|min_over|rate|ewma)\(\s*([A-Za-z_][A-Za-z0-9_]*)\s*,\s*([0-9][0-9A-Za-z.]*)\s*\)`)
+
+// ScalingModifiersWindowFunc evaluates the window function fn over the values
+// of the trigger in the last window
+type ScalingModifiersWindowFunc func(fn, trigger string, window time.Duration) (float64, error)
+
+// ScalingModifiersEnv returns the environment the scalingModifiers formula is
+// compiled and run with. It contains the trigger values and the window functions
+// which are resolved by windowFunc.
+func ScalingModifiersEnv(values map[string]float64, windowFunc ScalingModifiersWindowFunc) map[string]any {
+	env := make(map[string]any, len(values)+len(ScalingModifiersWindowFunctions))
+	for name, value := range values {
+		env[name] = value
+	}
+	for _, fn := range ScalingModifiersWindowFunctions {
+		fn := fn
+		env[fn] = func(trigger, window string) (float64, error) {
+			duration, err := ParseScalingModifiersWindow(window)
+			if err != nil {
+				return 0, fmt.Errorf("%s(%s, %s): %w", fn, trigger, window, err)
+			}
+			return windowFunc(fn, trigger, duration)
+		}
+	}
+	return env
+}
+
+// ParseScalingModifiersWindow parses the window given to a window function
+func ParseScalingModifiersWindow(window string) (time.Duration, error) {
+	duration, err := time.ParseDuration(window)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q: %w", window, err)
+	}
+	if duration <= 0 || duration > ScalingModifiersMaxWindow {
+		return 0, fmt.Errorf("window %q must be greater than 0 and at most %s", window, ScalingModifiersMaxWindow)
+	}
+	return duration, nil
+}
+
+// quoteWindowFunctionArgs converts window function calls written with bare
+// arguments, eg. `avg_over(kafka_lag, 5m)`, to valid expressions with string
+// arguments `avg_over("kafka_lag", "5m")`
+func quoteWindowFunctionArgs(formula string) string {
+	return windowFunctionCallRegex.ReplaceAllString(formula, `$1("$2", "$3")`)
+}
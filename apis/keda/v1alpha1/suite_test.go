@@ -119,6 +119,8 @@ var _ = BeforeSuite(func() {
 
 	err = (&ScaledObject{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
+	err = (&ScaledJob{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
 	err = (&TriggerAuthentication{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 	err = (&ClusterTriggerAuthentication{}).SetupWebhookWithManager(mgr)
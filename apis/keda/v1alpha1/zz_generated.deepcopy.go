@@ -24,9 +24,67 @@ package v1alpha1
 import (
 	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationPolicy) DeepCopyInto(out *ActivationPolicy) {
+	*out = *in
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VetoTriggers != nil {
+		in, out := &in.VetoTriggers, &out.VetoTriggers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationPolicy.
+func (in *ActivationPolicy) DeepCopy() *ActivationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationRamp) DeepCopyInto(out *ActivationRamp) {
+	*out = *in
+	out.StepInterval = in.StepInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationRamp.
+func (in *ActivationRamp) DeepCopy() *ActivationRamp {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationRamp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationRampStatus) DeepCopyInto(out *ActivationRampStatus) {
+	*out = *in
+	in.LastStepTime.DeepCopyInto(&out.LastStepTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationRampStatus.
+func (in *ActivationRampStatus) DeepCopy() *ActivationRampStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationRampStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 	*out = *in
@@ -35,7 +93,19 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		*out = new(HorizontalPodAutoscalerConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	out.ScalingModifiers = in.ScalingModifiers
+	in.ScalingModifiers.DeepCopyInto(&out.ScalingModifiers)
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ReplicaSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActivationRamp != nil {
+		in, out := &in.ActivationRamp, &out.ActivationRamp
+		*out = new(ActivationRamp)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -451,6 +521,11 @@ func (in *Credential) DeepCopy() *Credential {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
+	if in.MaxMetricAge != nil {
+		in, out := &in.MaxMetricAge, &out.MaxMetricAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fallback.
@@ -620,6 +695,146 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplateParameter) DeepCopyInto(out *JobTemplateParameter) {
+	*out = *in
+	if in.Modulo != nil {
+		in, out := &in.Modulo, &out.Modulo
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateParameter.
+func (in *JobTemplateParameter) DeepCopy() *JobTemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplateParameters) DeepCopyInto(out *JobTemplateParameters) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]JobTemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]JobTemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateParameters.
+func (in *JobTemplateParameters) DeepCopy() *JobTemplateParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplateParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseSchedule) DeepCopyInto(out *PauseSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.PausedReplicaCount != nil {
+		in, out := &in.PausedReplicaCount, &out.PausedReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseSchedule.
+func (in *PauseSchedule) DeepCopy() *PauseSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGate) DeepCopyInto(out *ReadinessGate) {
+	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]ReadinessGateWorkload, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaledObjects != nil {
+		in, out := &in.ScaledObjects, &out.ScaledObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGate.
+func (in *ReadinessGate) DeepCopy() *ReadinessGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGateWorkload) DeepCopyInto(out *ReadinessGateWorkload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGateWorkload.
+func (in *ReadinessGateWorkload) DeepCopy() *ReadinessGateWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGateWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSchedule) DeepCopyInto(out *ReplicaSchedule) {
+	*out = *in
+	if in.MinReplicaCount != nil {
+		in, out := &in.MinReplicaCount, &out.MinReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleReplicaCount != nil {
+		in, out := &in.IdleReplicaCount, &out.IdleReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSchedule.
+func (in *ReplicaSchedule) DeepCopy() *ReplicaSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -665,6 +880,11 @@ func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 		*out = new(AuthenticationRef)
 		**out = **in
 	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleTriggers.
@@ -778,6 +998,24 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(Fallback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PauseSchedules != nil {
+		in, out := &in.PauseSchedules, &out.PauseSchedules
+		*out = make([]PauseSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ScalingModifiers.DeepCopyInto(&out.ScalingModifiers)
+	if in.JobTemplateParameters != nil {
+		in, out := &in.JobTemplateParameters, &out.JobTemplateParameters
+		*out = new(JobTemplateParameters)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobSpec.
@@ -802,6 +1040,20 @@ func (in *ScaledJobStatus) DeepCopyInto(out *ScaledJobStatus) {
 		*out = make(Conditions, len(*in))
 		copy(*out, *in)
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = make(map[string]HealthStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ScalingDecisions != nil {
+		in, out := &in.ScalingDecisions, &out.ScalingDecisions
+		*out = make([]ScalingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobStatus.
@@ -906,6 +1158,11 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.IdleCooldownPeriod != nil {
+		in, out := &in.IdleCooldownPeriod, &out.IdleCooldownPeriod
+		*out = new(int32)
+		**out = **in
+	}
 	if in.IdleReplicaCount != nil {
 		in, out := &in.IdleReplicaCount, &out.IdleReplicaCount
 		*out = new(int32)
@@ -936,7 +1193,24 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 	if in.Fallback != nil {
 		in, out := &in.Fallback, &out.Fallback
 		*out = new(Fallback)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActivationPolicy != nil {
+		in, out := &in.ActivationPolicy, &out.ActivationPolicy
+		*out = new(ActivationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGate != nil {
+		in, out := &in.ReadinessGate, &out.ReadinessGate
+		*out = new(ReadinessGate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PauseSchedules != nil {
+		in, out := &in.PauseSchedules, &out.PauseSchedules
+		*out = make([]PauseSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -994,6 +1268,35 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ActivationTriggers != nil {
+		in, out := &in.ActivationTriggers, &out.ActivationTriggers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TriggersLastActiveTime != nil {
+		in, out := &in.TriggersLastActiveTime, &out.TriggersLastActiveTime
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ActivationRamp != nil {
+		in, out := &in.ActivationRamp, &out.ActivationRamp
+		*out = new(ActivationRampStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRunReplicas != nil {
+		in, out := &in.DryRunReplicas, &out.DryRunReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScalingDecisions != nil {
+		in, out := &in.ScalingDecisions, &out.ScalingDecisions
+		*out = make([]ScalingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -1006,9 +1309,44 @@ func (in *ScaledObjectStatus) DeepCopy() *ScaledObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingDecision) DeepCopyInto(out *ScalingDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FormulaValue != nil {
+		in, out := &in.FormulaValue, &out.FormulaValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingDecision.
+func (in *ScalingDecision) DeepCopy() *ScalingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingModifiers) DeepCopyInto(out *ScalingModifiers) {
 	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingModifiers.
@@ -1190,6 +1528,26 @@ func (in *TriggerAuthenticationStatus) DeepCopy() *TriggerAuthenticationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerDecision) DeepCopyInto(out *TriggerDecision) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerDecision.
+func (in *TriggerDecision) DeepCopy() *TriggerDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
@@ -42,15 +42,19 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	kedaprovider "github.com/kedacore/keda/v2/pkg/provider"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
-// Adapter creates External Metrics Provider
+// Adapter creates External Metrics Provider, and Custom Metrics Provider if enabled
 type Adapter struct {
 	basecmd.AdapterBase
 
 	// Message is printed on successful startup
 	Message string
+
+	// metricsIndex indexes the metrics of the ScaledObjects, it is listed by the debug endpoint
+	metricsIndex *kedaprovider.MetricsIndex
 }
 
 var logger = klogr.New().WithName("keda_metrics_adapter")
@@ -62,9 +66,12 @@ var (
 	disableCompression        bool
 	metricsServiceAddr        string
 	profilingAddr             string
+	enableCustomMetrics       bool
+	enableMetricsWatch        bool
+	enableSharding            bool
 )
 
-func (a *Adapter) makeProvider(ctx context.Context) (provider.ExternalMetricsProvider, <-chan struct{}, error) {
+func (a *Adapter) makeProvider(ctx context.Context) (provider.MetricsProvider, <-chan struct{}, error) {
 	scheme := scheme.Scheme
 	if err := appsv1.SchemeBuilder.AddToScheme(scheme); err != nil {
 		logger.Error(err, "failed to add apps/v1 scheme to runtime scheme")
@@ -128,6 +135,19 @@ func (a *Adapter) makeProvider(ctx context.Context) (provider.ExternalMetricsPro
 		logger.Error(err, "error connecting Metrics Service gRPC client to the server", "address", metricsServiceAddr)
 		return nil, nil, err
 	}
+	if enableSharding {
+		resolver := sharding.NewResolver(mgr.GetAPIReader(), kedautil.GetPodNamespace(), "", 0)
+		if err := mgr.Add(resolver); err != nil {
+			logger.Error(err, "failed to setup shard resolver")
+			return nil, nil, err
+		}
+		grpcClient.EnableSharding(resolver)
+	}
+	a.metricsIndex = kedaprovider.NewMetricsIndex()
+	if err := a.metricsIndex.Start(ctx, mgr.GetCache()); err != nil {
+		logger.Error(err, "failed to setup metrics index")
+		return nil, nil, err
+	}
 	stopCh := make(chan struct{})
 	go func() {
 		if err := mgr.Start(ctx); err != nil {
@@ -136,7 +156,7 @@ func (a *Adapter) makeProvider(ctx context.Context) (provider.ExternalMetricsPro
 			close(stopCh)
 		}
 	}()
-	return kedaprovider.NewProvider(ctx, logger, mgr.GetClient(), *grpcClient), stopCh, nil
+	return kedaprovider.NewProvider(ctx, logger, mgr.GetClient(), *grpcClient, a.metricsIndex, enableMetricsWatch), stopCh, nil
 }
 
 // getMetricHandler returns a http handler that exposes metrics from controller-runtime and apiserver
@@ -161,10 +181,14 @@ func getMetricHandler() http.HandlerFunc {
 // this is needed to consolidate apiserver and controller-runtime metrics
 // we have to use a separate http server & can't rely on the controller-runtime implementation
 // because apiserver doesn't provide a way to register metrics to other prometheus registries
-func RunMetricsServer(ctx context.Context, stopCh <-chan struct{}) {
+// the /debug/external-metrics endpoint lists which ScaledObject and trigger each external metric belongs to
+func RunMetricsServer(ctx context.Context, stopCh <-chan struct{}, metricsIndex *kedaprovider.MetricsIndex) {
 	h := getMetricHandler()
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", h)
+	if metricsIndex != nil {
+		mux.Handle("/debug/external-metrics", metricsIndex)
+	}
 	metricsBindAddress := fmt.Sprintf(":%v", metricsAPIServerPort)
 
 	server := &http.Server{
@@ -237,6 +261,9 @@ func main() {
 	cmd.Flags().Float32Var(&adapterClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
 	cmd.Flags().IntVar(&adapterClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
 	cmd.Flags().BoolVar(&disableCompression, "disable-compression", true, "Disable response compression for k8s restAPI in client-go. ")
+	cmd.Flags().BoolVar(&enableCustomMetrics, "enable-custom-metrics", false, "Serve the metrics of the ScaledObjects with the Custom Metrics API (custom.metrics.k8s.io) too, described by the ScaledObjects, their scale targets and the pods of their scale targets")
+	cmd.Flags().BoolVar(&enableSharding, "enable-sharding", false, "Send the metrics requests to the KEDA operator replica owning the ScaledObject, for a KEDA operator running with --enable-sharding")
+	cmd.Flags().BoolVar(&enableMetricsWatch, "enable-metrics-watch", false, "Keep the metrics pushed by the KEDA Metrics Service each time the ScaledObjects are polled and serve them for two polling intervals, instead of requesting each metric from the KEDA Metrics Service")
 
 	if err := cmd.Flags().Parse(os.Args); err != nil {
 		return
@@ -255,10 +282,13 @@ func main() {
 		return
 	}
 	cmd.WithExternalMetrics(kedaProvider)
+	if enableCustomMetrics {
+		cmd.WithCustomMetrics(kedaProvider)
+	}
 
 	logger.Info(cmd.Message)
 
-	RunMetricsServer(ctx, stopCh)
+	RunMetricsServer(ctx, stopCh, cmd.metricsIndex)
 
 	if err = cmd.Run(stopCh); err != nil {
 		return
@@ -18,6 +18,8 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"net"
 	"os"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -48,6 +51,8 @@ import (
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
 )
@@ -85,6 +90,12 @@ func main() {
 	var k8sClusterDomain string
 	var enableCertRotation bool
 	var validatingWebhookName string
+	var customMetricsAPIServiceName string
+	var enableSharding bool
+	var metricsCachePersistence string
+	var metricsCachePersistenceName string
+	var metricsCachePersistenceInterval time.Duration
+	var metricsCacheMaxStaleness time.Duration
 	pflag.BoolVar(&enablePrometheusMetrics, "enable-prometheus-metrics", true, "Enable the prometheus metric of keda-operator.")
 	pflag.BoolVar(&enableOpenTelemetryMetrics, "enable-opentelemetry-metrics", false, "Enable the opentelemetry metric of keda-operator.")
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the prometheus metric endpoint binds to.")
@@ -106,6 +117,12 @@ func main() {
 	pflag.StringVar(&k8sClusterDomain, "k8s-cluster-domain", "cluster.local", "Kubernetes cluster domain. Defaults to cluster.local")
 	pflag.BoolVar(&enableCertRotation, "enable-cert-rotation", false, "enable automatic generation and rotation of TLS certificates/keys")
 	pflag.StringVar(&validatingWebhookName, "validating-webhook-name", "keda-admission", "ValidatingWebhookConfiguration name. Defaults to keda-admission")
+	pflag.StringVar(&customMetricsAPIServiceName, "custom-metrics-api-service-name", "", "APIService name of the Custom Metrics API served by the metrics server, its caBundle is patched when set. eg. v1beta2.custom.metrics.k8s.io")
+	pflag.BoolVar(&enableSharding, "enable-sharding", false, "Shard the ScaledObjects and ScaledJobs among the operator replicas with Leases, each replica runs the scale loops and serves the metrics of its shard. Requires POD_NAME and POD_IP env variables")
+	pflag.StringVar(&metricsCachePersistence, "metrics-cache-persistence", "", "Persist the metrics cache in a configmap or a secret of the KEDA namespace, the new leader restores it instead of polling every scaler at once. Disabled if empty")
+	pflag.StringVar(&metricsCachePersistenceName, "metrics-cache-persistence-name", "keda-operator-metrics-cache", "Name of the configmap or secret the metrics cache is persisted in")
+	pflag.DurationVar(&metricsCachePersistenceInterval, "metrics-cache-persistence-interval", 30*time.Second, "Interval the metrics cache is persisted at")
+	pflag.DurationVar(&metricsCacheMaxStaleness, "metrics-cache-max-staleness", 5*time.Minute, "Maximum age of the persisted metrics restored by the new leader")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -190,6 +207,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	scalingDecisionHistorySize, err := kedautil.ResolveOsEnvInt("KEDA_SCALING_DECISION_HISTORY_SIZE", 60)
+	if err != nil {
+		setupLog.Error(err, "invalid KEDA_SCALING_DECISION_HISTORY_SIZE")
+		os.Exit(1)
+	}
+
 	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
 	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
 	eventEmitter := eventemitter.NewEventEmitter(mgr.GetClient(), eventRecorder, k8sClusterName)
@@ -215,7 +238,43 @@ func main() {
 		os.Exit(1)
 	}
 
-	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
+	var sharder *sharding.Sharder
+	if enableSharding {
+		sharder, err = newSharder(mgr, metricsServiceAddr, leaseDuration, renewDeadline, retryPeriod)
+		if err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		if err := mgr.Add(sharder); err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+	}
+
+	var metricsCachePersister *metricscache.Persister
+	if metricsCachePersistence != "" {
+		if enableSharding {
+			setupLog.Error(nil, "the metrics cache persistence isn't supported with sharding")
+			os.Exit(1)
+		}
+		metricsCachePersister, err = metricscache.NewPersister(mgr.GetClient(), mgr.GetAPIReader(), metricscache.PersisterOptions{
+			Backend:      metricsCachePersistence,
+			Namespace:    kedautil.GetPodNamespace(),
+			Name:         metricsCachePersistenceName,
+			Interval:     metricsCachePersistenceInterval,
+			MaxStaleness: metricsCacheMaxStaleness,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to set up metrics cache persistence")
+			os.Exit(1)
+		}
+		if err := mgr.Add(metricsCachePersister); err != nil {
+			setupLog.Error(err, "unable to set up metrics cache persistence")
+			os.Exit(1)
+		}
+	}
+
+	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, eventEmitter, secretInformer.Lister(), scalingDecisionHistorySize, sharder, metricsCachePersister)
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
 		Client:       mgr.GetClient(),
@@ -224,8 +283,11 @@ func main() {
 		ScaleClient:  scaleClient,
 		ScaleHandler: scaledHandler,
 		EventEmitter: eventEmitter,
+		Sharder:      sharder,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledObjectMaxReconciles,
+		// every replica reconciles the ScaledObjects of its shard
+		NeedLeaderElection: ptr.To(!enableSharding),
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
@@ -235,10 +297,14 @@ func main() {
 		Scheme:            mgr.GetScheme(),
 		GlobalHTTPTimeout: globalHTTPTimeout,
 		Recorder:          eventRecorder,
+		EventEmitter:      eventEmitter,
+		ScaleHandler:      scaledHandler,
 		SecretsLister:     secretInformer.Lister(),
 		SecretsSynced:     secretInformer.Informer().HasSynced,
+		Sharder:           sharder,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledJobMaxReconciles,
+		NeedLeaderElection:      ptr.To(!enableSharding),
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
@@ -278,18 +344,19 @@ func main() {
 	certReady := make(chan struct{})
 	if enableCertRotation {
 		certManager := certificates.CertManager{
-			SecretName:            certSecretName,
-			CertDir:               certDir,
-			OperatorService:       operatorServiceName,
-			MetricsServerService:  metricsServerServiceName,
-			WebhookService:        webhooksServiceName,
-			K8sClusterDomain:      k8sClusterDomain,
-			CAName:                "KEDA",
-			CAOrganization:        "KEDAORG",
-			ValidatingWebhookName: validatingWebhookName,
-			APIServiceName:        "v1beta1.external.metrics.k8s.io",
-			Logger:                setupLog,
-			Ready:                 certReady,
+			SecretName:                  certSecretName,
+			CertDir:                     certDir,
+			OperatorService:             operatorServiceName,
+			MetricsServerService:        metricsServerServiceName,
+			WebhookService:              webhooksServiceName,
+			K8sClusterDomain:            k8sClusterDomain,
+			CAName:                      "KEDA",
+			CAOrganization:              "KEDAORG",
+			ValidatingWebhookName:       validatingWebhookName,
+			APIServiceName:              "v1beta1.external.metrics.k8s.io",
+			CustomMetricsAPIServiceName: customMetricsAPIServiceName,
+			Logger:                      setupLog,
+			Ready:                       certReady,
 		}
 		if err := certManager.AddCertificateRotation(ctx, mgr); err != nil {
 			setupLog.Error(err, "unable to set up cert rotation")
@@ -299,7 +366,7 @@ func main() {
 		close(certReady)
 	}
 
-	grpcServer := metricsservice.NewGrpcServer(&scaledHandler, metricsServiceAddr, certDir, certReady)
+	grpcServer := metricsservice.NewGrpcServer(&scaledHandler, metricsServiceAddr, certDir, certReady, enableSharding)
 	if err := mgr.Add(&grpcServer); err != nil {
 		setupLog.Error(err, "unable to set up Metrics Service gRPC server")
 		os.Exit(1)
@@ -319,3 +386,24 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newSharder returns the Sharder of the operator replica, its Lease is named after the pod and
+// advertises the address of its Metrics Service gRPC server to the adapter
+func newSharder(mgr ctrl.Manager, metricsServiceAddr string, leaseDuration, renewDeadline, retryPeriod *time.Duration) (*sharding.Sharder, error) {
+	podName, podIP := os.Getenv("POD_NAME"), os.Getenv("POD_IP")
+	if podName == "" || podIP == "" {
+		return nil, fmt.Errorf("POD_NAME and POD_IP env variables are required for sharding")
+	}
+	_, port, err := net.SplitHostPort(metricsServiceAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Metrics Service address %q: %w", metricsServiceAddr, err)
+	}
+	return sharding.NewSharder(mgr.GetClient(), mgr.GetAPIReader(), sharding.Options{
+		Namespace:     kedautil.GetPodNamespace(),
+		Identity:      podName,
+		Address:       net.JoinHostPort(podIP, port),
+		LeaseDuration: ptr.Deref(leaseDuration, 0),
+		RenewDeadline: ptr.Deref(renewDeadline, 0),
+		RetryPeriod:   ptr.Deref(retryPeriod, 0),
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// keda-schema exports the scaler registry as JSON Schema, so editors and admission
+// tooling can validate `spec.triggers[]` without running the scalers.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func main() {
+	var output string
+	var scalerType string
+
+	pflag.StringVarP(&output, "output", "o", "", "File to write the schema to. Defaults to stdout")
+	pflag.StringVar(&scalerType, "scaler", "", "Only export the metadata schema of the given trigger type")
+	pflag.Parse()
+
+	if err := run(output, scalerType); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(output, scalerType string) error {
+	schema := scalers.TriggersJSONSchema()
+	if scalerType != "" {
+		def, ok := scalers.GetScalerDefinition(scalerType)
+		if !ok {
+			return fmt.Errorf("no scaler found for type: %s", scalerType)
+		}
+		schema = scalers.ScalerMetadataJSONSchema(def)
+		schema["title"] = fmt.Sprintf("KEDA %s trigger metadata", scalerType)
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling schema: %w", err)
+	}
+	data = append(data, '\n')
+
+	if output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(output, data, 0o600)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// keda-simulator replays a time series of trigger values through the scaling logic of a ScaledObject
+// or a ScaledJob without a cluster and prints the replica timeline, so configurations can be tuned and
+// tested in CI.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/simulator"
+)
+
+// Output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+type config struct {
+	scalableObject  string
+	series          string
+	seriesFormat    string
+	output          string
+	start           string
+	initialReplicas int32
+	jobDuration     time.Duration
+	targets         map[string]string
+	activations     map[string]string
+}
+
+func main() {
+	var cfg config
+	var verbose bool
+
+	pflag.StringVarP(&cfg.scalableObject, "scalable-object", "f", "", "YAML file of the ScaledObject or ScaledJob to simulate")
+	pflag.StringVarP(&cfg.series, "series", "s", "", "CSV or JSON file of the trigger values over time")
+	pflag.StringVar(&cfg.seriesFormat, "series-format", "", "Format of the series, csv or json. Defaults to the file extension")
+	pflag.StringVarP(&cfg.output, "output", "o", outputTable, "Output format of the timeline, table, json or csv")
+	pflag.StringVar(&cfg.start, "start", "", "RFC 3339 time the relative timestamps of the series start at. Defaults to the Unix epoch")
+	pflag.Int32Var(&cfg.initialReplicas, "initial-replicas", 0, "Replica count of the ScaleTarget, or number of running jobs, before the first sample")
+	pflag.DurationVar(&cfg.jobDuration, "job-duration", time.Minute, "How long the jobs created for a ScaledJob run")
+	pflag.StringToStringVar(&cfg.targets, "target", nil, "Target value of a trigger, overriding the one found in its metadata, as trigger=value")
+	pflag.StringToStringVar(&cfg.activations, "activation", nil, "Activation threshold of a trigger, overriding the one found in its metadata, as trigger=value")
+	pflag.BoolVarP(&verbose, "verbose", "v", false, "Log the scaling logic")
+	pflag.Parse()
+
+	if verbose {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	} else {
+		ctrl.SetLogger(logr.Discard())
+	}
+
+	if err := run(context.Background(), cfg, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config, stdout, stderr io.Writer) error {
+	if cfg.scalableObject == "" || cfg.series == "" {
+		return errors.New("--scalable-object and --series are required")
+	}
+	options, err := getOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	start := time.Unix(0, 0).UTC()
+	if cfg.start != "" {
+		if start, err = time.Parse(time.RFC3339, cfg.start); err != nil {
+			return fmt.Errorf("invalid --start: %w", err)
+		}
+	}
+	seriesFormat := cfg.seriesFormat
+	if seriesFormat == "" {
+		seriesFormat = strings.TrimPrefix(filepath.Ext(cfg.series), ".")
+	}
+	data, err := os.ReadFile(cfg.series)
+	if err != nil {
+		return err
+	}
+	samples, err := simulator.ParseSeries(data, seriesFormat, start)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", cfg.series, err)
+	}
+
+	scalableObject, err := readScalableObject(cfg.scalableObject)
+	if err != nil {
+		return err
+	}
+	var timeline *simulator.Timeline
+	switch obj := scalableObject.(type) {
+	case *kedav1alpha1.ScaledObject:
+		timeline, err = simulator.SimulateScaledObject(ctx, obj, samples, options)
+	case *kedav1alpha1.ScaledJob:
+		timeline, err = simulator.SimulateScaledJob(ctx, obj, samples, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range timeline.Warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+	return writeTimeline(stdout, cfg.output, timeline)
+}
+
+func getOptions(cfg config) (simulator.Options, error) {
+	options := simulator.Options{
+		InitialReplicas: cfg.initialReplicas,
+		JobDuration:     cfg.jobDuration,
+		Targets:         map[string]float64{},
+		Activations:     map[string]float64{},
+	}
+	for trigger, value := range cfg.targets {
+		target, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return options, fmt.Errorf("invalid --target for trigger %q: %w", trigger, err)
+		}
+		options.Targets[trigger] = target
+	}
+	for trigger, value := range cfg.activations {
+		activation, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return options, fmt.Errorf("invalid --activation for trigger %q: %w", trigger, err)
+		}
+		options.Activations[trigger] = activation
+	}
+	return options, nil
+}
+
+// readScalableObject returns the first ScaledObject or ScaledJob of the YAML file
+func readScalableObject(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no ScaledObject or ScaledJob found in %s", path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		var obj interface{}
+		switch typeMeta.Kind {
+		case "ScaledObject":
+			obj = &kedav1alpha1.ScaledObject{}
+		case "ScaledJob":
+			obj = &kedav1alpha1.ScaledJob{}
+		default:
+			continue
+		}
+		if err := yaml.UnmarshalStrict(document, obj); err != nil {
+			return nil, fmt.Errorf("error parsing %s %s: %w", typeMeta.Kind, path, err)
+		}
+		return obj, nil
+	}
+}
+
+func writeTimeline(w io.Writer, output string, timeline *simulator.Timeline) error {
+	switch output {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(timeline)
+	case outputCSV:
+		return csv.NewWriter(w).WriteAll(append([][]string{timelineHeader(timeline)}, timelineRows(timeline)...))
+	case outputTable:
+		writer := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(timelineHeader(timeline), "\t")))
+		for _, row := range timelineRows(timeline) {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q, must be %s, %s or %s", output, outputTable, outputJSON, outputCSV)
+	}
+}
+
+func timelineHeader(timeline *simulator.Timeline) []string {
+	if timeline.Kind == "ScaledJob" {
+		return []string{"time", "active", "error", "fallback", "paused", "formula", "created", "running"}
+	}
+	return []string{"time", "active", "error", "fallback", "paused", "formula", "recommendation", "replicas"}
+}
+
+// timelineRows returns the steps of the timeline, their time being the offset from the first one
+func timelineRows(timeline *simulator.Timeline) [][]string {
+	rows := make([][]string, 0, len(timeline.Steps))
+	for _, step := range timeline.Steps {
+		row := []string{
+			step.Timestamp.Sub(timeline.Steps[0].Timestamp).String(),
+			strconv.FormatBool(step.IsActive),
+			strconv.FormatBool(step.IsError),
+			strconv.FormatBool(step.IsFallback),
+			strconv.FormatBool(step.IsPaused),
+		}
+		formula := "-"
+		if step.FormulaValue != nil {
+			formula = strconv.FormatFloat(*step.FormulaValue, 'f', -1, 64)
+		}
+		if timeline.Kind == "ScaledJob" {
+			row = append(row, formula, strconv.FormatInt(step.CreatedJobs, 10))
+		} else {
+			recommendation := "-"
+			if step.Recommendation != nil {
+				recommendation = strconv.Itoa(int(*step.Recommendation))
+			}
+			row = append(row, formula, recommendation)
+		}
+		rows = append(rows, append(row, strconv.Itoa(int(step.Replicas))))
+	}
+	return rows
+}
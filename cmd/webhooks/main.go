@@ -36,6 +36,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
 )
@@ -133,11 +134,19 @@ func main() {
 }
 
 func setupWebhook(mgr manager.Manager) {
+	// triggers metadata is checked against the scalers registry, without connecting to the scalers sources
+	kedav1alpha1.SetTriggerMetadataValidator(scalers.ValidateTriggerMetadata)
+	setupLog.Info("triggers metadata is validated only for these trigger types", "triggerTypes", scalers.ValidatedTriggerTypes())
+
 	// setup webhooks
 	if err := (&kedav1alpha1.ScaledObject{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ScaledObject")
 		os.Exit(1)
 	}
+	if err := (&kedav1alpha1.ScaledJob{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ScaledJob")
+		os.Exit(1)
+	}
 	if err := (&kedav1alpha1.TriggerAuthentication{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "TriggerAuthentication")
 		os.Exit(1)
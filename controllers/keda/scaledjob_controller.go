@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -36,14 +37,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
@@ -56,9 +60,13 @@ type ScaledJobReconciler struct {
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
 	Recorder          record.EventRecorder
+	EventEmitter      eventemitter.EventHandler
+	// ScaleHandler is shared with the ScaledObjectReconciler, a dedicated one is created if it isn't set
+	ScaleHandler scaling.ScaleHandler
+	// Sharder decides which ScaledJobs are handled by this operator replica, all of them are if it isn't set
+	Sharder *sharding.Sharder
 
 	scaledJobGenerations *sync.Map
-	scaleHandler         scaling.ScaleHandler
 	SecretsLister        corev1listers.SecretLister
 	SecretsSynced        cache.InformerSynced
 }
@@ -80,9 +88,11 @@ func init() {
 
 // SetupWithManager initializes the ScaledJobReconciler instance and starts a new controller managed by the passed Manager instance.
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister)
+	if r.ScaleHandler == nil {
+		r.ScaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.EventEmitter, r.SecretsLister, 0, r.Sharder, nil)
+	}
 	r.scaledJobGenerations = &sync.Map{}
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
@@ -90,8 +100,12 @@ func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options control
 			predicate.Or(
 				kedacontrollerutil.PausedPredicate{},
 				predicate.GenerationChangedPredicate{},
-			))).
-		Complete(r)
+			)))
+	if r.Sharder != nil {
+		// reconcile the ScaledJobs moved from or to this replica to stop or start their scale loop
+		controllerBuilder = controllerBuilder.WatchesRawSource(r.Sharder.Source(&kedav1alpha1.ScaledJobList{}), &handler.EnqueueRequestForObject{})
+	}
+	return controllerBuilder.Complete(r)
 }
 
 // Reconcile performs reconciliation on the identified ScaledJob resource based on the request information passed, returns the result and an error (if any).
@@ -113,6 +127,12 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// the ScaledJob is handled by the operator replica owning it
+	if !r.Sharder.Owns(scaledJob.GenerateIdentifier()) {
+		reqLogger.V(1).Info("ScaledJob is owned by another shard, stopping its scale loop")
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledJob)
+	}
+
 	reqLogger.Info("Reconciling ScaledJob")
 
 	// Check if the ScaledJob instance is marked to be deleted, which is
@@ -169,7 +189,13 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		reqLogger.Error(err, "Error updating TriggerAuthentication Status")
 	}
 
-	return ctrl.Result{}, err
+	// reconcile again when a pause schedule starts or ends
+	var requeueAfter time.Duration
+	if next, found := scaledJob.GetNextPauseScheduleTransition(time.Now()); found {
+		requeueAfter = requeueBefore(requeueAfter, next)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
 }
 
 // reconcileScaledJob implements reconciler logic for K8s Jobs based ScaledJob
@@ -189,7 +215,7 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 	}
 
 	// Check ScaledJob is Ready or not
-	_, err = r.scaleHandler.GetScalersCache(ctx, scaledJob)
+	_, err = r.ScaleHandler.GetScalersCache(ctx, scaledJob)
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
 		return "Failed to ensure ScaledJob is correctly created", err
@@ -215,18 +241,25 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 	return "ScaledJob is defined correctly and is ready to scaling", nil
 }
 
-// checkIfPaused checks the presence of "autoscaling.keda.sh/paused" annotation on the scaledJob and stop the scale loop.
+// checkIfPaused checks the presence of "autoscaling.keda.sh/paused" annotation on the scaledJob, or an active pause schedule
+// when the annotation is not present, and stop the scale loop.
 func (r *ScaledJobReconciler) checkIfPaused(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, conditions *kedav1alpha1.Conditions) (bool, error) {
 	pausedAnnotationValue, pausedAnnotation := scaledJob.GetAnnotations()[kedav1alpha1.PausedAnnotation]
 	pausedStatus := conditions.GetPausedCondition().Status == metav1.ConditionTrue
 	shouldPause := false
+	var pausedUntil time.Time
 	if pausedAnnotation {
 		var err error
 		shouldPause, err = strconv.ParseBool(pausedAnnotationValue)
 		if err != nil {
 			shouldPause = true
 		}
+	} else {
+		var pauseSchedule *kedav1alpha1.PauseSchedule
+		pauseSchedule, pausedUntil = scaledJob.GetActivePauseSchedule(time.Now())
+		shouldPause = pauseSchedule != nil
 	}
+	namespacedName := types.NamespacedName{Namespace: scaledJob.Namespace, Name: scaledJob.Name}
 	if shouldPause {
 		if !pausedStatus {
 			logger.Info("ScaledJob is paused, stopping scaling loop.")
@@ -237,6 +270,8 @@ func (r *ScaledJobReconciler) checkIfPaused(ctx context.Context, logger logr.Log
 				return false, err
 			}
 			conditions.SetPausedCondition(metav1.ConditionTrue, kedav1alpha1.ScaledJobConditionPausedReason, msg)
+			r.EventEmitter.Emit(scaledJob, namespacedName, corev1.EventTypeNormal, eventemitter.ScaledJobPausedType,
+				eventreason.ScaledJobPaused, pausedEventMessage("ScaledJob", pausedAnnotation, pausedUntil))
 		}
 		return true, nil
 	}
@@ -244,6 +279,7 @@ func (r *ScaledJobReconciler) checkIfPaused(ctx context.Context, logger logr.Log
 		logger.Info("Unpausing ScaledJob.")
 		msg := kedav1alpha1.ScaledJobConditionUnpausedMessage
 		conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledJobConditionUnpausedReason, msg)
+		r.EventEmitter.Emit(scaledJob, namespacedName, corev1.EventTypeNormal, eventemitter.ScaledJobUnpausedType, eventreason.ScaledJobUnpaused, msg)
 	}
 	return false, nil
 }
@@ -301,7 +337,7 @@ func (r *ScaledJobReconciler) requestScaleLoop(ctx context.Context, logger logr.
 		return err
 	}
 
-	if err = r.scaleHandler.HandleScalableObject(ctx, scaledJob); err != nil {
+	if err = r.ScaleHandler.HandleScalableObject(ctx, scaledJob); err != nil {
 		return err
 	}
 
@@ -320,7 +356,7 @@ func (r *ScaledJobReconciler) stopScaleLoop(ctx context.Context, logger logr.Log
 		return err
 	}
 
-	if err = r.scaleHandler.DeleteScalableObject(ctx, scaledJob); err != nil {
+	if err = r.ScaleHandler.DeleteScalableObject(ctx, scaledJob); err != nil {
 		return err
 	}
 
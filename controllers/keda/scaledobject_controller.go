@@ -19,8 +19,8 @@ package keda
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -49,6 +50,8 @@ import (
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
@@ -71,6 +74,8 @@ type ScaledObjectReconciler struct {
 	ScaleClient  scale.ScalesGetter
 	ScaleHandler scaling.ScaleHandler
 	EventEmitter eventemitter.EventHandler
+	// Sharder decides which ScaledObjects are handled by this operator replica, all of them are if it isn't set
+	Sharder *sharding.Sharder
 
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
@@ -120,7 +125,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 		return fmt.Errorf("ScaledObjectReconciler.Recorder is not initialized")
 	}
 	// Start controller
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
@@ -129,6 +134,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 			predicate.Or(
 				kedacontrollerutil.PausedPredicate{},
 				kedacontrollerutil.PausedReplicasPredicate{},
+				kedacontrollerutil.DryRunPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
@@ -140,8 +146,12 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 				predicate.LabelChangedPredicate{},
 				predicate.AnnotationChangedPredicate{},
 				kedacontrollerutil.HPASpecChangedPredicate{},
-			))).
-		Complete(r)
+			)))
+	if r.Sharder != nil {
+		// reconcile the ScaledObjects moved from or to this replica to stop or start their scale loop
+		controllerBuilder = controllerBuilder.WatchesRawSource(r.Sharder.Source(&kedav1alpha1.ScaledObjectList{}), &handler.EnqueueRequestForObject{})
+	}
+	return controllerBuilder.Complete(r)
 }
 
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
@@ -162,6 +172,12 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// the ScaledObject is handled by the operator replica owning it
+	if !r.Sharder.Owns(scaledObject.GenerateIdentifier()) {
+		reqLogger.V(1).Info("ScaledObject is owned by another shard, stopping its scale loop")
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledObject)
+	}
+
 	reqLogger.Info("Reconciling ScaledObject")
 
 	// Check if the ScaledObject instance is marked to be deleted, which is
@@ -185,6 +201,16 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	// set the active schedule before the HPA is reconciled, as the schedule may override the replica counts
+	requeueAfter, err := r.reconcileActiveSchedule(ctx, reqLogger, scaledObject)
+	if err != nil {
+		reqLogger.Error(err, "failed to reconcile the active schedule of ScaledObject")
+	}
+	// reconcile again when a pause schedule starts or ends
+	if next, found := scaledObject.GetNextPauseScheduleTransition(time.Now()); found {
+		requeueAfter = requeueBefore(requeueAfter, next)
+	}
+
 	conditions := scaledObject.Status.Conditions.DeepCopy()
 	// reconcile ScaledObject and set status appropriately
 	msg, err := r.reconcileScaledObject(ctx, reqLogger, scaledObject, &conditions)
@@ -211,14 +237,67 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		reqLogger.Error(err, "Failed to update TriggerAuthentication Status after removing a finalizer")
 	}
 
-	return ctrl.Result{}, err
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
+}
+
+// reconcileActiveSchedule reports the schedule in force in status.activeSchedule and returns the time
+// until the window of any schedule opens or closes, when the ScaledObject has to be reconciled again
+func (r *ScaledObjectReconciler) reconcileActiveSchedule(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (time.Duration, error) {
+	now := time.Now()
+	schedule, err := scaledObject.FindActiveSchedule(now)
+	if err != nil {
+		return 0, err
+	}
+
+	activeSchedule := ""
+	if schedule != nil {
+		activeSchedule = schedule.Name
+	}
+	if scaledObject.Status.ActiveSchedule != activeSchedule {
+		status := scaledObject.Status.DeepCopy()
+		status.ActiveSchedule = activeSchedule
+		if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+			return 0, err
+		}
+		logger.Info("Active schedule of ScaledObject changed", "activeSchedule", activeSchedule)
+	}
+
+	next, found, err := scaledObject.NextScheduleTransition(now)
+	if err != nil || !found {
+		return 0, err
+	}
+	return requeueBefore(0, next), nil
+}
+
+// pausedEventMessage describes whether the object was paused by the paused annotation or by the pause schedule
+// active until the given time
+func pausedEventMessage(kind string, byAnnotation bool, until time.Time) string {
+	if byAnnotation {
+		return fmt.Sprintf("%s is paused by annotation", kind)
+	}
+	return fmt.Sprintf("%s is paused by pause schedule until %s", kind, until.UTC().Format(time.RFC3339))
+}
+
+// requeueBefore returns the requeue duration so the object is reconciled again at next,
+// or requeueAfter if it is sooner. A zero requeueAfter means no requeue was requested yet.
+func requeueBefore(requeueAfter time.Duration, next time.Time) time.Duration {
+	untilNext := time.Until(next)
+	if untilNext < time.Second {
+		untilNext = time.Second
+	}
+	if requeueAfter == 0 || untilNext < requeueAfter {
+		return untilNext
+	}
+	return requeueAfter
 }
 
 // reconcileScaledObject implements reconciler logic for ScaledObject
 func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, conditions *kedav1alpha1.Conditions) (string, error) {
 	// Check the presence of "autoscaling.keda.sh/paused" annotation on the scaledObject (since the presence of this annotation will pause
-	// autoscaling no matter what number of replicas is provided), and if so, stop the scale loop and delete the HPA on the scaled object.
-	needsToPause := scaledObject.NeedToBePausedByAnnotation()
+	// autoscaling no matter what number of replicas is provided) or an active pause schedule, and if so, stop the scale loop and delete
+	// the HPA on the scaled object.
+	now := time.Now()
+	needsToPause := scaledObject.NeedToBePaused(now)
 	if needsToPause {
 		scaledToPausedCount := true
 		if conditions.GetPausedCondition().Status == metav1.ConditionTrue {
@@ -238,13 +317,24 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 				msg = "failed to delete HPA for paused ScaledObject"
 				return msg, err
 			}
+			if conditions.GetPausedCondition().Status != metav1.ConditionTrue {
+				_, pausedUntil := scaledObject.GetActivePauseSchedule(now)
+				r.EventEmitter.Emit(scaledObject, types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}, corev1.EventTypeNormal,
+					eventemitter.ScaledObjectPausedType, eventreason.ScaledObjectPaused, pausedEventMessage("ScaledObject", scaledObject.HasPausedAnnotation(), pausedUntil))
+			}
 			conditions.SetPausedCondition(metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionPausedReason, msg)
 			metricscollector.RecordScaledObjectPaused(scaledObject.Namespace, scaledObject.Name, true)
 			return msg, nil
 		}
 	} else if conditions.GetPausedCondition().Status == metav1.ConditionTrue {
-		conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledObjectUnpaused", "pause annotation removed for ScaledObject")
+		msg := "pause annotation removed for ScaledObject"
+		if !scaledObject.HasPausedAnnotation() && len(scaledObject.Spec.PauseSchedules) > 0 {
+			msg = "pause annotation removed or pause schedule ended for ScaledObject"
+		}
+		conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledObjectUnpaused", msg)
 		metricscollector.RecordScaledObjectPaused(scaledObject.Namespace, scaledObject.Name, false)
+		r.EventEmitter.Emit(scaledObject, types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}, corev1.EventTypeNormal,
+			eventemitter.ScaledObjectUnpausedType, eventreason.ScaledObjectUnpaused, msg)
 	}
 
 	// Check scale target Name is specified
@@ -275,6 +365,17 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct triggers specification", err
 	}
 
+	if scaledObject.IsDryRun() {
+		return r.reconcileDryRunScaledObject(ctx, logger, scaledObject)
+	}
+	if scaledObject.Status.DryRunReplicas != nil {
+		status := scaledObject.Status.DeepCopy()
+		status.DryRunReplicas = nil
+		if err := kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+			return "failed to clear the dry-run replica count of ScaledObject", err
+		}
+	}
+
 	// Create a new HPA or update existing one according to ScaledObject
 	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
 	if err != nil {
@@ -298,12 +399,32 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		}
 		logger.Info("Initializing Scaling logic according to ScaledObject Specification")
 	}
-	if scaledObject.HasPausedReplicaAnnotation() && conditions.GetPausedCondition().Status != metav1.ConditionTrue {
+	if pausedCount, _ := executor.GetPausedReplicaCount(scaledObject); pausedCount != nil && conditions.GetPausedCondition().Status != metav1.ConditionTrue {
 		return "ScaledObject paused replicas are being scaled", fmt.Errorf("ScaledObject paused replicas are being scaled")
 	}
 	return kedav1alpha1.ScaledObjectConditionReadySuccessMessage, nil
 }
 
+// reconcileDryRunScaledObject ensures that no HPA exists for the ScaledObject in dry-run mode and that its ScaleLoop runs,
+// the ScaleLoop evaluates the triggers and reports the replica count the ScaleTarget would be scaled to
+func (r *ScaledObjectReconciler) reconcileDryRunScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (string, error) {
+	if deleted, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); !deleted {
+		return "failed to delete HPA for ScaledObject in dry-run mode", err
+	}
+
+	scaleObjectSpecChanged, err := r.scaledObjectGenerationChanged(logger, scaledObject)
+	if err != nil {
+		return "failed to check whether ScaledObject's Generation was changed", err
+	}
+	if scaleObjectSpecChanged {
+		if err := r.requestScaleLoop(ctx, logger, scaledObject); err != nil {
+			return "failed to start a new scale loop with scaling logic", err
+		}
+		logger.Info("Initializing Scaling logic according to ScaledObject Specification in dry-run mode")
+	}
+	return kedav1alpha1.ScaledObjectConditionReadySuccessMessage, nil
+}
+
 // ensureScaledObjectLabel ensures that scaledobject.keda.sh/name=<scaledObject.Name> label exist in the ScaledObject
 // This is how the MetricsAdapter will know which ScaledObject a metric is for when the HPA queries it.
 func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
@@ -322,12 +443,8 @@ func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, lo
 }
 
 func (r *ScaledObjectReconciler) checkIfTargetResourceReachPausedCount(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) bool {
-	pausedReplicaCount, pausedReplicasAnnotationFound := scaledObject.GetAnnotations()[kedav1alpha1.PausedReplicasAnnotation]
-	if !pausedReplicasAnnotationFound {
-		return true
-	}
-	pausedReplicaCountNum, err := strconv.ParseInt(pausedReplicaCount, 10, 32)
-	if err != nil {
+	pausedReplicaCount, err := executor.GetPausedReplicaCount(scaledObject)
+	if err != nil || pausedReplicaCount == nil {
 		return true
 	}
 
@@ -346,7 +463,7 @@ func (r *ScaledObjectReconciler) checkIfTargetResourceReachPausedCount(ctx conte
 	if errScale != nil {
 		return true
 	}
-	return scale.Spec.Replicas == int32(pausedReplicaCountNum)
+	return scale.Spec.Replicas == *pausedReplicaCount
 }
 
 // checkTargetResourceIsScalable checks if resource targeted for scaling exists and exposes /scale subresource
@@ -369,7 +486,8 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	}
 
 	// do we need the scale to update the status later?
-	present := scaledObject.HasPausedAnnotation()
+	pauseSchedule, _ := scaledObject.GetActivePauseSchedule(time.Now())
+	present := scaledObject.HasPausedAnnotation() || pauseSchedule != nil
 	removePausedStatus := scaledObject.Status.PausedReplicaCount != nil && !present
 	wantStatusUpdate := scaledObject.Status.ScaleTargetKind != gvkString ||
 		statusGvkString != gvkString ||
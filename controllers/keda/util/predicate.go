@@ -92,6 +92,18 @@ func (PausedPredicate) Update(e event.UpdateEvent) bool {
 	return newPausedValue != oldPausedValue
 }
 
+type DryRunPredicate struct {
+	predicate.Funcs
+}
+
+func (DryRunPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectNew.GetAnnotations()[kedav1alpha1.DryRunAnnotation] != e.ObjectOld.GetAnnotations()[kedav1alpha1.DryRunAnnotation]
+}
+
 type HPASpecChangedPredicate struct {
 	predicate.Funcs
 }
@@ -47,8 +47,10 @@ type CertManager struct {
 	CAOrganization        string
 	ValidatingWebhookName string
 	APIServiceName        string
-	Logger                logr.Logger
-	Ready                 chan struct{}
+	// CustomMetricsAPIServiceName is the APIService of the Custom Metrics API, if it is served by the metrics server
+	CustomMetricsAPIServiceName string
+	Logger                      logr.Logger
+	Ready                       chan struct{}
 }
 
 // AddCertificateRotation registers all needed services to generate the certificates and patches needed resources with the caBundle
@@ -63,6 +65,12 @@ func (cm CertManager) AddCertificateRotation(ctx context.Context, mgr manager.Ma
 			Type: rotator.APIService,
 		},
 	}
+	if cm.CustomMetricsAPIServiceName != "" {
+		rotatorHooks = append(rotatorHooks, rotator.WebhookInfo{
+			Name: cm.CustomMetricsAPIServiceName,
+			Type: rotator.APIService,
+		})
+	}
 
 	err := cm.ensureSecret(ctx, mgr, cm.SecretName)
 	if err != nil {
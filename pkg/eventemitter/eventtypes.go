@@ -22,4 +22,19 @@ const (
 
 	// ScaledObjectFailedType is for event when creating ScaledObject failed
 	ScaledObjectFailedType = "keda.scaledobject.failed.v1"
+
+	// ScaledObjectPausedType is for event when ScaledObject is paused
+	ScaledObjectPausedType = "keda.scaledobject.paused.v1"
+
+	// ScaledObjectUnpausedType is for event when ScaledObject is unpaused
+	ScaledObjectUnpausedType = "keda.scaledobject.unpaused.v1"
+
+	// ScaledObjectDryRunReplicasChangedType is for event when the replica count a ScaledObject in dry-run mode would scale to changes
+	ScaledObjectDryRunReplicasChangedType = "keda.scaledobject.dryrun.replicas.changed.v1"
+
+	// ScaledJobPausedType is for event when ScaledJob is paused
+	ScaledJobPausedType = "keda.scaledjob.paused.v1"
+
+	// ScaledJobUnpausedType is for event when ScaledJob is unpaused
+	ScaledJobUnpausedType = "keda.scaledjob.unpaused.v1"
 )
@@ -41,6 +41,18 @@ const (
 	// ScaledJobDeleted is for event when ScaledJob is deleted
 	ScaledJobDeleted = "ScaledJobDeleted"
 
+	// ScaledObjectPaused is for event when ScaledObject is paused
+	ScaledObjectPaused = "ScaledObjectPaused"
+
+	// ScaledJobPaused is for event when ScaledJob is paused
+	ScaledJobPaused = "ScaledJobPaused"
+
+	// ScaledObjectUnpaused is for event when ScaledObject is unpaused
+	ScaledObjectUnpaused = "ScaledObjectUnpaused"
+
+	// ScaledJobUnpaused is for event when ScaledJob is unpaused
+	ScaledJobUnpaused = "ScaledJobUnpaused"
+
 	// KEDAScalersStarted is for event when scalers watch started for ScaledObject or ScaledJob
 	KEDAScalersStarted = "KEDAScalersStarted"
 
@@ -65,6 +77,12 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetActivationWaiting is for event when the activation of the scale target for ScaledObject is held back by its readiness gate
+	KEDAScaleTargetActivationWaiting = "KEDAScaleTargetActivationWaiting"
+
+	// KEDAScaleTargetDryRunReplicasChanged is for event when the replica count a ScaledObject in dry-run mode would scale its scale target to changes
+	KEDAScaleTargetDryRunReplicasChanged = "KEDAScaleTargetDryRunReplicasChanged"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
@@ -18,15 +18,19 @@ package fallback
 
 import (
 	"context"
+	"time"
 
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/scale"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 var log = logf.Log.WithName("fallback")
@@ -36,15 +40,19 @@ func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.Me
 		return false
 	}
 
-	if metricSpec.External.Target.Type != v2.AverageValueMetricType {
-		log.V(0).Info("Fallback can only be enabled for triggers with metric of type AverageValue", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	if metricSpec.External.Target.Type != v2.AverageValueMetricType && metricSpec.External.Target.Type != v2.ValueMetricType {
+		log.V(0).Info("Fallback can only be enabled for triggers with metric of type AverageValue or Value", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 		return false
 	}
 
 	return true
 }
 
-func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) ([]external_metrics.ExternalMetricValue, bool, error) {
+// GetMetricsWithFallback returns the scaler metrics or, if the scaler failed more than fallback.failureThreshold times,
+// metrics computed according to the fallback behavior. The last known metrics are kept in lastKnownMetrics
+// for the lastKnownMetric behavior, scaleClient is used to get the current replica count of scale targets
+// that are not Deployments or StatefulSets.
+func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, scaleClient scale.ScalesGetter, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, lastKnownMetrics metricscache.MetricsCache) ([]external_metrics.ExternalMetricValue, bool, error) {
 	status := scaledObject.Status.DeepCopy()
 
 	initHealthStatus(status)
@@ -56,6 +64,10 @@ func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, me
 		healthStatus.Status = kedav1alpha1.HealthStatusHappy
 		status.Health[metricName] = *healthStatus
 
+		if scaledObject.Spec.Fallback != nil && scaledObject.Spec.Fallback.GetBehavior() == kedav1alpha1.FallbackBehaviorLastKnownMetric {
+			storeLastKnownMetrics(scaledObject.GenerateIdentifier(), metricName, metrics, true, lastKnownMetrics)
+		}
+
 		updateStatus(ctx, client, scaledObject, status, metricSpec)
 		return metrics, false, nil
 	}
@@ -73,7 +85,7 @@ func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, me
 		log.Info("Failed to validate ScaledObject Spec. Please check that parameters are positive integers", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 		return nil, false, suppressedError
 	case *healthStatus.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold:
-		return doFallback(scaledObject, metricSpec, metricName, suppressedError), true, nil
+		return doFallback(ctx, client, scaleClient, scaledObject, metricSpec, metricName, lastKnownMetrics, suppressedError), true, nil
 	default:
 		return nil, false, suppressedError
 	}
@@ -98,20 +110,89 @@ func validateFallback(scaledObject *kedav1alpha1.ScaledObject) bool {
 		scaledObject.Spec.Fallback.Replicas >= 0
 }
 
-func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metricName string, suppressedError error) []external_metrics.ExternalMetricValue {
-	replicas := int64(scaledObject.Spec.Fallback.Replicas)
-	normalisationValue := metricSpec.External.Target.AverageValue.AsApproximateFloat64()
+func doFallback(ctx context.Context, client runtimeclient.Client, scaleClient scale.ScalesGetter, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metricName string, lastKnownMetrics metricscache.MetricsCache, suppressedError error) []external_metrics.ExternalMetricValue {
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	fallback := scaledObject.Spec.Fallback
+	behavior := fallback.GetBehavior()
+
+	if behavior == kedav1alpha1.FallbackBehaviorLastKnownMetric {
+		if record, found := getLastKnownMetrics(scaledObject.GenerateIdentifier(), metricName, fallback.GetMaxMetricAge(), lastKnownMetrics); found {
+			logger.Info("Suppressing error, falling back to the last known metric", "suppressedError", suppressedError, "metricName", metricName)
+			return record.Metric
+		}
+		logger.V(1).Info("No last known metric within fallback.maxMetricAge, falling back to fallback.replicas", "maxMetricAge", fallback.GetMaxMetricAge())
+	}
+
+	// current replicas are needed by the behaviors based on them and by
+	// Value metrics, where HPA multiplies the metric ratio by the replica count
+	currentReplicas := int32(1)
+	needsCurrentReplicas := behavior == kedav1alpha1.FallbackBehaviorCurrentReplicas ||
+		behavior == kedav1alpha1.FallbackBehaviorCurrentReplicasIfHigher ||
+		metricSpec.External.Target.Type == v2.ValueMetricType
+	if needsCurrentReplicas {
+		replicas, err := resolver.GetCurrentReplicas(ctx, client, scaleClient, scaledObject)
+		if err != nil {
+			logger.Error(err, "Failed to get current replicas, falling back to fallback.replicas")
+			behavior = kedav1alpha1.FallbackBehaviorStatic
+		} else {
+			currentReplicas = replicas
+		}
+	}
+
+	replicas := fallback.Replicas
+	if behavior != kedav1alpha1.FallbackBehaviorStatic {
+		replicas = fallback.GetReplicas(currentReplicas)
+	}
+
+	var metricValue float64
+	if metricSpec.External.Target.Type == v2.ValueMetricType {
+		// HPA computes desired replicas as currentReplicas * value / target
+		metricValue = metricSpec.External.Target.Value.AsApproximateFloat64() * float64(replicas) / float64(max(currentReplicas, 1))
+	} else {
+		metricValue = metricSpec.External.Target.AverageValue.AsApproximateFloat64() * float64(replicas)
+	}
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewMilliQuantity(int64(normalisationValue*1000)*replicas, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(metricValue*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 	fallbackMetrics := []external_metrics.ExternalMetricValue{metric}
 
-	log.Info("Suppressing error, falling back to fallback.replicas", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "suppressedError", suppressedError, "fallback.replicas", replicas)
+	logger.Info("Suppressing error, falling back to fallback.replicas", "suppressedError", suppressedError, "fallback.behavior", behavior, "fallback.replicas", replicas)
 	return fallbackMetrics
 }
 
+// storeLastKnownMetrics keeps the metrics retrieved from the scaler, metrics without timestamp are stamped with the current time
+func storeLastKnownMetrics(identifier, metricName string, metrics []external_metrics.ExternalMetricValue, isActive bool, lastKnownMetrics metricscache.MetricsCache) {
+	stored := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		if metric.Timestamp.IsZero() {
+			metric.Timestamp = metav1.Now()
+		}
+		stored = append(stored, metric)
+	}
+	lastKnownMetrics.StoreRecord(identifier, metricName, metricscache.MetricsRecord{IsActive: isActive, Metric: stored})
+}
+
+// getLastKnownMetrics returns the last metrics retrieved from the scaler if they are not older than maxMetricAge
+func getLastKnownMetrics(identifier, metricName string, maxMetricAge time.Duration, lastKnownMetrics metricscache.MetricsCache) (metricscache.MetricsRecord, bool) {
+	record, found := lastKnownMetrics.ReadRecord(identifier, metricName)
+	if !found || len(record.Metric) == 0 {
+		return metricscache.MetricsRecord{}, false
+	}
+
+	metrics := make([]external_metrics.ExternalMetricValue, 0, len(record.Metric))
+	for _, metric := range record.Metric {
+		if time.Since(metric.Timestamp.Time) > maxMetricAge {
+			return metricscache.MetricsRecord{}, false
+		}
+		metric.Timestamp = metav1.Now()
+		metrics = append(metrics, metric)
+	}
+	record.Metric = metrics
+	return record, true
+}
+
 func updateStatus(ctx context.Context, client runtimeclient.Client, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, metricSpec v2.MetricSpec) {
 	patch := runtimeclient.MergeFrom(scaledObject.DeepCopy())
 
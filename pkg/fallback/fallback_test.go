@@ -21,11 +21,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	appsv1 "k8s.io/api/apps/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +36,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
 )
 
 const metricName = "some_metric_name"
@@ -70,7 +73,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -101,7 +104,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -117,7 +120,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -146,7 +149,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -176,7 +179,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -232,7 +235,7 @@ var _ = Describe("fallback", func() {
 		client.EXPECT().Status().Return(statusWriter)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		metrics, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ToNot(HaveOccurred())
 		value := metrics[0].Value.AsApproximateFloat64()
@@ -262,7 +265,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -296,12 +299,162 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 		Expect(err).ToNot(HaveOccurred())
 		condition := so.Status.Conditions.GetFallbackCondition()
 		Expect(condition.IsTrue()).Should(BeTrue())
 	})
 
+	It("should return a metric normalised by the current replicas when the metrics spec target type is value", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := createValueMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+		expectDeploymentReplicas(client, 4)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
+
+		Expect(err).ToNot(HaveOccurred())
+		// HPA scales to 4 * 25 / 10 = 10 replicas
+		value := metrics[0].Value.AsApproximateFloat64()
+		Expect(value).Should(Equal(float64(25)))
+	})
+
+	It("should return a metric for the current replicas with currentReplicas behavior", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorCurrentReplicas,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+		expectDeploymentReplicas(client, 4)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
+
+		Expect(err).ToNot(HaveOccurred())
+		value := metrics[0].Value.AsApproximateFloat64()
+		Expect(value).Should(Equal(float64(40)))
+	})
+
+	It("should return a metric for the higher replica count with currentReplicasIfHigher behavior", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorCurrentReplicasIfHigher,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+		expectDeploymentReplicas(client, 12)
+
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
+
+		Expect(err).ToNot(HaveOccurred())
+		value := metrics[0].Value.AsApproximateFloat64()
+		Expect(value).Should(Equal(float64(120)))
+	})
+
+	It("should return the last known metric with lastKnownMetric behavior", func() {
+		lastKnownMetrics := metricscache.NewMetricsCache()
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(0),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorLastKnownMetric,
+			}, nil,
+		)
+		metricSpec := createMetricSpec(10)
+
+		primeGetMetrics(scaler, float64(7))
+		expectStatusPatch(ctrl, client)
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, lastKnownMetrics)
+		Expect(err).ToNot(HaveOccurred())
+
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		expectStatusPatch(ctrl, client)
+		metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, lastKnownMetrics)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallbackActive).Should(BeTrue())
+		value := metrics[0].Value.AsApproximateFloat64()
+		Expect(value).Should(Equal(float64(7)))
+	})
+
+	It("should return a normalised metric when the last known metric is too old", func() {
+		lastKnownMetrics := metricscache.NewMetricsCache()
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(0),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorLastKnownMetric,
+				MaxMetricAge:     &metav1.Duration{Duration: time.Minute},
+			}, nil,
+		)
+		metricSpec := createMetricSpec(10)
+		lastKnownMetrics.StoreRecord(so.GenerateIdentifier(), metricName, metricscache.MetricsRecord{
+			Metric: []external_metrics.ExternalMetricValue{{
+				MetricName: metricName,
+				Value:      *resource.NewQuantity(7, resource.DecimalSI),
+				Timestamp:  metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+			}},
+		})
+
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		expectStatusPatch(ctrl, client)
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, lastKnownMetrics)
+
+		Expect(err).ToNot(HaveOccurred())
+		value := metrics[0].Value.AsApproximateFloat64()
+		Expect(value).Should(Equal(float64(100)))
+	})
+
 	It("should set the fallback condition to false if the config is invalid", func() {
 		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
 		startingNumberOfFailures := int32(3)
@@ -330,7 +483,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
-		_, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		_, _, err = GetMetricsWithFallback(context.Background(), client, nil, metrics, err, metricName, so, metricSpec, metricscache.NewMetricsCache())
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
 		condition := so.Status.Conditions.GetFallbackCondition()
@@ -380,6 +533,14 @@ func expectStatusPatch(ctrl *gomock.Controller, client *mock_client.MockClient)
 	client.EXPECT().Status().Return(statusWriter)
 }
 
+func expectDeploymentReplicas(client *mock_client.MockClient, replicas int32) {
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	})
+}
+
 func buildScaledObject(fallbackConfig *kedav1alpha1.Fallback, status *kedav1alpha1.ScaledObjectStatus) *kedav1alpha1.ScaledObject {
 	scaledObject := &kedav1alpha1.ScaledObject{
 		ObjectMeta: metav1.ObjectMeta{Name: "clean-up-test", Namespace: "default"},
@@ -405,6 +566,10 @@ func buildScaledObject(fallbackConfig *kedav1alpha1.Fallback, status *kedav1alph
 	if status != nil {
 		scaledObject.Status = *status
 	}
+	scaledObject.Status.ScaleTargetGVKR = &kedav1alpha1.GroupVersionKindResource{
+		Group: "apps",
+		Kind:  "Deployment",
+	}
 
 	scaledObject.Status.Conditions = *kedav1alpha1.GetInitializedConditions()
 
@@ -432,3 +597,15 @@ func createMetricSpec(averageValue int) v2.MetricSpec {
 		},
 	}
 }
+
+func createValueMetricSpec(value int) v2.MetricSpec {
+	qty := resource.NewQuantity(int64(value), resource.DecimalSI)
+	return v2.MetricSpec{
+		External: &v2.ExternalMetricSource{
+			Target: v2.MetricTarget{
+				Type:  v2.ValueMetricType,
+				Value: qty,
+			},
+		},
+	}
+}
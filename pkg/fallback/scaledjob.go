@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fallback
+
+import (
+	"context"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+)
+
+func isScaledJobFallbackEnabled(scaledJob *kedav1alpha1.ScaledJob) bool {
+	fallback := scaledJob.Spec.Fallback
+	if fallback == nil {
+		return false
+	}
+	if fallback.FailureThreshold < 0 || fallback.Replicas < 0 {
+		log.Info("Failed to validate ScaledJob Spec. Please check that parameters are positive integers", "scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+		return false
+	}
+	return true
+}
+
+// GetScaledJobMetricsWithFallback returns the ScaledJob scaler metrics and activity or, if the scaler failed more than
+// fallback.failureThreshold times, the ones given by the fallback behavior: fallback.replicas jobs, or the last known
+// metrics with the lastKnownMetric behavior. The health of the metric is tracked in status, which is persisted by
+// UpdateScaledJobStatus once all the metrics are processed.
+func GetScaledJobMetricsWithFallback(scaledJob *kedav1alpha1.ScaledJob, status *kedav1alpha1.ScaledJobStatus, metrics []external_metrics.ExternalMetricValue, isActive bool, suppressedError error, metricName string, metricSpec v2.MetricSpec, lastKnownMetrics metricscache.MetricsCache) ([]external_metrics.ExternalMetricValue, bool, bool, error) {
+	if status.Health == nil {
+		status.Health = make(map[string]kedav1alpha1.HealthStatus)
+	}
+	healthStatus, found := status.Health[metricName]
+	if !found || healthStatus.NumberOfFailures == nil {
+		zero := int32(0)
+		healthStatus = kedav1alpha1.HealthStatus{NumberOfFailures: &zero, Status: kedav1alpha1.HealthStatusHappy}
+	}
+
+	if suppressedError == nil {
+		zero := int32(0)
+		status.Health[metricName] = kedav1alpha1.HealthStatus{NumberOfFailures: &zero, Status: kedav1alpha1.HealthStatusHappy}
+
+		if scaledJob.Spec.Fallback != nil && scaledJob.Spec.Fallback.GetBehavior() == kedav1alpha1.FallbackBehaviorLastKnownMetric {
+			storeLastKnownMetrics(scaledJob.GenerateIdentifier(), metricName, metrics, isActive, lastKnownMetrics)
+		}
+		return metrics, isActive, false, nil
+	}
+
+	failures := *healthStatus.NumberOfFailures + 1
+	status.Health[metricName] = kedav1alpha1.HealthStatus{NumberOfFailures: &failures, Status: kedav1alpha1.HealthStatusFailing}
+
+	if !isScaledJobFallbackEnabled(scaledJob) || failures <= scaledJob.Spec.Fallback.FailureThreshold {
+		return nil, false, false, suppressedError
+	}
+
+	fallbackMetrics, fallbackActive := doScaledJobFallback(scaledJob, metricSpec, metricName, lastKnownMetrics, suppressedError)
+	return fallbackMetrics, fallbackActive, true, nil
+}
+
+func doScaledJobFallback(scaledJob *kedav1alpha1.ScaledJob, metricSpec v2.MetricSpec, metricName string, lastKnownMetrics metricscache.MetricsCache, suppressedError error) ([]external_metrics.ExternalMetricValue, bool) {
+	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+	fallback := scaledJob.Spec.Fallback
+
+	if fallback.GetBehavior() == kedav1alpha1.FallbackBehaviorLastKnownMetric {
+		if record, found := getLastKnownMetrics(scaledJob.GenerateIdentifier(), metricName, fallback.GetMaxMetricAge(), lastKnownMetrics); found {
+			logger.Info("Suppressing error, falling back to the last known metric", "suppressedError", suppressedError, "metricName", metricName)
+			return record.Metric, record.IsActive
+		}
+		logger.V(1).Info("No last known metric within fallback.maxMetricAge, falling back to fallback.replicas", "maxMetricAge", fallback.GetMaxMetricAge())
+	}
+
+	target := metricSpec.External.Target.AverageValue
+	if metricSpec.External.Target.Type == v2.ValueMetricType {
+		target = metricSpec.External.Target.Value
+	}
+	var targetValue float64
+	if target != nil {
+		targetValue = target.AsApproximateFloat64()
+	}
+
+	replicas := int64(fallback.Replicas)
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(int64(targetValue*1000)*replicas, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	logger.Info("Suppressing error, falling back to fallback.replicas", "suppressedError", suppressedError, "fallback.replicas", replicas)
+	return []external_metrics.ExternalMetricValue{metric}, replicas > 0
+}
+
+// UpdateScaledJobStatus persists the ScaledJob health tracked by GetScaledJobMetricsWithFallback and
+// sets the Fallback condition, the status is only patched when it changed
+func UpdateScaledJobStatus(ctx context.Context, client runtimeclient.Client, scaledJob *kedav1alpha1.ScaledJob, status *kedav1alpha1.ScaledJobStatus) {
+	if fallbackExistsInScaledJob(scaledJob, status) {
+		status.Conditions.SetFallbackCondition(metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled job")
+	} else {
+		status.Conditions.SetFallbackCondition(metav1.ConditionFalse, "NoFallbackFound", "No fallbacks are active on this scaled job")
+	}
+
+	if equality.Semantic.DeepEqual(scaledJob.Status, *status) {
+		return
+	}
+
+	patch := runtimeclient.MergeFrom(scaledJob.DeepCopy())
+	scaledJob.Status = *status
+	err := client.Status().Patch(ctx, scaledJob, patch)
+	if err != nil {
+		log.Error(err, "failed to patch ScaledJob Status", "scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+	}
+}
+
+func fallbackExistsInScaledJob(scaledJob *kedav1alpha1.ScaledJob, status *kedav1alpha1.ScaledJobStatus) bool {
+	if !isScaledJobFallbackEnabled(scaledJob) {
+		return false
+	}
+
+	for _, element := range status.Health {
+		if element.Status == kedav1alpha1.HealthStatusFailing && *element.NumberOfFailures > scaledJob.Spec.Fallback.FailureThreshold {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fallback
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+)
+
+var _ = Describe("scaledjob fallback", func() {
+	var (
+		client *mock_client.MockClient
+		scaler *mock_scalers.MockScaler
+		ctrl   *gomock.Controller
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		client = mock_client.NewMockClient(ctrl)
+		scaler = mock_scalers.NewMockScaler(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should reset the health status when scaler metrics are available", func() {
+		primeGetMetrics(scaler, float64(5))
+		failures := int32(3)
+		sj := buildScaledJob(&kedav1alpha1.Fallback{FailureThreshold: int32(3), Replicas: int32(2)})
+		sj.Status.Health = map[string]kedav1alpha1.HealthStatus{
+			metricName: {NumberOfFailures: &failures, Status: kedav1alpha1.HealthStatusFailing},
+		}
+		status := sj.Status.DeepCopy()
+
+		metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, isActive, fallbackActive, err := GetScaledJobMetricsWithFallback(sj, status, metrics, isActive, err, metricName, createMetricSpec(3), metricscache.NewMetricsCache())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isActive).Should(BeTrue())
+		Expect(fallbackActive).Should(BeFalse())
+		Expect(metrics[0].Value.AsApproximateFloat64()).Should(Equal(float64(5)))
+		Expect(status.Health[metricName]).To(haveFailureAndStatus(0, kedav1alpha1.HealthStatusHappy))
+	})
+
+	It("should propagate the error while the failure threshold is not reached", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		sj := buildScaledJob(&kedav1alpha1.Fallback{FailureThreshold: int32(3), Replicas: int32(2)})
+		status := sj.Status.DeepCopy()
+
+		metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, fallbackActive, err := GetScaledJobMetricsWithFallback(sj, status, metrics, isActive, err, metricName, createMetricSpec(3), metricscache.NewMetricsCache())
+
+		Expect(err).Should(HaveOccurred())
+		Expect(fallbackActive).Should(BeFalse())
+		Expect(status.Health[metricName]).To(haveFailureAndStatus(1, kedav1alpha1.HealthStatusFailing))
+	})
+
+	It("should return a metric for fallback.replicas jobs when number of failures are beyond threshold", func() {
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		failures := int32(3)
+		sj := buildScaledJob(&kedav1alpha1.Fallback{FailureThreshold: int32(3), Replicas: int32(2)})
+		sj.Status.Health = map[string]kedav1alpha1.HealthStatus{
+			metricName: {NumberOfFailures: &failures, Status: kedav1alpha1.HealthStatusFailing},
+		}
+		status := sj.Status.DeepCopy()
+
+		metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, isActive, fallbackActive, err := GetScaledJobMetricsWithFallback(sj, status, metrics, isActive, err, metricName, createMetricSpec(3), metricscache.NewMetricsCache())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isActive).Should(BeTrue())
+		Expect(fallbackActive).Should(BeTrue())
+		Expect(metrics[0].Value.AsApproximateFloat64()).Should(Equal(float64(6)))
+		Expect(status.Health[metricName]).To(haveFailureAndStatus(4, kedav1alpha1.HealthStatusFailing))
+	})
+
+	It("should return the last known metric with lastKnownMetric behavior", func() {
+		lastKnownMetrics := metricscache.NewMetricsCache()
+		sj := buildScaledJob(&kedav1alpha1.Fallback{
+			FailureThreshold: int32(0),
+			Replicas:         int32(2),
+			Behavior:         kedav1alpha1.FallbackBehaviorLastKnownMetric,
+		})
+		status := sj.Status.DeepCopy()
+
+		primeGetMetrics(scaler, float64(7))
+		metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		_, _, _, err = GetScaledJobMetricsWithFallback(sj, status, metrics, isActive, err, metricName, createMetricSpec(3), lastKnownMetrics)
+		Expect(err).ToNot(HaveOccurred())
+
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("Some error"))
+		metrics, isActive, err = scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, isActive, fallbackActive, err := GetScaledJobMetricsWithFallback(sj, status, metrics, isActive, err, metricName, createMetricSpec(3), lastKnownMetrics)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(isActive).Should(BeTrue())
+		Expect(fallbackActive).Should(BeTrue())
+		Expect(metrics[0].Value.AsApproximateFloat64()).Should(Equal(float64(7)))
+	})
+
+	It("should set the fallback condition and patch the status only when it changed", func() {
+		failures := int32(4)
+		sj := buildScaledJob(&kedav1alpha1.Fallback{FailureThreshold: int32(3), Replicas: int32(2)})
+		status := sj.Status.DeepCopy()
+		status.Health = map[string]kedav1alpha1.HealthStatus{
+			metricName: {NumberOfFailures: &failures, Status: kedav1alpha1.HealthStatusFailing},
+		}
+
+		expectStatusPatch(ctrl, client)
+		UpdateScaledJobStatus(context.Background(), client, sj, status)
+		condition := sj.Status.Conditions.GetFallbackCondition()
+		Expect(condition.IsTrue()).Should(BeTrue())
+
+		// nothing changed, the status must not be patched again
+		UpdateScaledJobStatus(context.Background(), client, sj, sj.Status.DeepCopy())
+	})
+})
+
+func buildScaledJob(fallbackConfig *kedav1alpha1.Fallback) *kedav1alpha1.ScaledJob {
+	scaledJob := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "clean-up-test", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledJobSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{
+					Type:     "rabbitmq",
+					Metadata: map[string]string{"queueName": "test"},
+				},
+			},
+			Fallback: fallbackConfig,
+		},
+	}
+	scaledJob.Status.Conditions = *kedav1alpha1.GetInitializedConditions()
+
+	return scaledJob
+}
@@ -45,6 +45,9 @@ type MetricsCollector interface {
 	// RecordScaledObjectPaused marks whether the current ScaledObject is paused.
 	RecordScaledObjectPaused(namespace string, scaledObject string, active bool)
 
+	// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would scale to
+	RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32)
+
 	// RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 	RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error)
 
@@ -119,6 +122,13 @@ func RecordScaledObjectPaused(namespace string, scaledObject string, active bool
 	}
 }
 
+// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would scale to
+func RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32) {
+	for _, element := range collectors {
+		element.RecordScaledObjectDryRunReplicas(namespace, scaledObject, replicas)
+	}
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func RecordScalerError(namespace string, scaledObject string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	for _, element := range collectors {
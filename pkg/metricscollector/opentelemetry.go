@@ -38,7 +38,8 @@ var (
 	otCloudEventEmittedCounter api.Int64Counter
 	otCloudEventQueueStatusVal OtelMetricFloat64Val
 
-	otelScalerActiveVal OtelMetricFloat64Val
+	otelScalerActiveVal               OtelMetricFloat64Val
+	otelScaledObjectDryRunReplicasVal OtelMetricFloat64Val
 )
 
 type OtelMetrics struct {
@@ -141,6 +142,15 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaled.object.dry.run.replicas",
+		api.WithDescription("Replica count a ScaledObject in dry-run mode would scale to"),
+		api.WithFloat64Callback(ScaledObjectDryRunReplicasCallback),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	_, err = meter.Int64ObservableGauge(
 		"keda.build.info",
 		api.WithDescription("A metric with a constant '1' value labeled by version, git_commit and goversion from which KEDA was built."),
@@ -282,6 +292,23 @@ func (o *OtelMetrics) RecordScaledObjectPaused(namespace string, scaledObject st
 	}
 }
 
+func ScaledObjectDryRunReplicasCallback(_ context.Context, obsrv api.Float64Observer) error {
+	if otelScaledObjectDryRunReplicasVal.measurementOption != nil {
+		obsrv.Observe(otelScaledObjectDryRunReplicasVal.val, otelScaledObjectDryRunReplicasVal.measurementOption)
+	}
+	otelScaledObjectDryRunReplicasVal = OtelMetricFloat64Val{}
+	return nil
+}
+
+// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would scale to
+func (o *OtelMetrics) RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32) {
+	otelScaledObjectDryRunReplicasVal.val = float64(replicas)
+	otelScaledObjectDryRunReplicasVal.measurementOption = api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("scaledObject").String(scaledObject),
+	)
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func (o *OtelMetrics) RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	if err != nil {
@@ -84,6 +84,15 @@ var (
 		},
 		[]string{"namespace", "scaledObject"},
 	)
+	scaledObjectDryRunReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "dry_run_replicas",
+			Help:      "Replica count a ScaledObject in dry-run mode would scale to",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
 	scalerErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -174,6 +183,7 @@ func NewPromMetrics() *PromMetrics {
 	metrics.Registry.MustRegister(scalerErrors)
 	metrics.Registry.MustRegister(scaledObjectErrors)
 	metrics.Registry.MustRegister(scaledObjectPaused)
+	metrics.Registry.MustRegister(scaledObjectDryRunReplicas)
 	metrics.Registry.MustRegister(scaledJobErrors)
 
 	metrics.Registry.MustRegister(triggerTotalsGaugeVec)
@@ -229,6 +239,12 @@ func (p *PromMetrics) RecordScaledObjectPaused(namespace string, scaledObject st
 	scaledObjectPaused.With(labels).Set(float64(activeVal))
 }
 
+// RecordScaledObjectDryRunReplicas records the replica count a ScaledObject in dry-run mode would scale to
+func (p *PromMetrics) RecordScaledObjectDryRunReplicas(namespace string, scaledObject string, replicas int32) {
+	labels := prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}
+	scaledObjectDryRunReplicas.With(labels).Set(float64(replicas))
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func (p *PromMetrics) RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	if err != nil {
@@ -35,7 +35,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MetricsService_GetMetrics_FullMethodName = "/api.MetricsService/GetMetrics"
+	MetricsService_GetMetrics_FullMethodName          = "/api.MetricsService/GetMetrics"
+	MetricsService_GetScalingDecisions_FullMethodName = "/api.MetricsService/GetScalingDecisions"
+	MetricsService_GetMetricsBatch_FullMethodName     = "/api.MetricsService/GetMetricsBatch"
+	MetricsService_WatchMetrics_FullMethodName        = "/api.MetricsService/WatchMetrics"
 )
 
 // MetricsServiceClient is the client API for MetricsService service.
@@ -43,6 +46,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MetricsServiceClient interface {
 	GetMetrics(ctx context.Context, in *ScaledObjectRef, opts ...grpc.CallOption) (*v1beta1.ExternalMetricValueList, error)
+	GetScalingDecisions(ctx context.Context, in *ScalableObjectRef, opts ...grpc.CallOption) (*ScalingDecisionList, error)
+	GetMetricsBatch(ctx context.Context, in *ScaledObjectRefList, opts ...grpc.CallOption) (*ScaledObjectMetricsList, error)
+	WatchMetrics(ctx context.Context, in *WatchMetricsRequest, opts ...grpc.CallOption) (MetricsService_WatchMetricsClient, error)
 }
 
 type metricsServiceClient struct {
@@ -62,11 +68,64 @@ func (c *metricsServiceClient) GetMetrics(ctx context.Context, in *ScaledObjectR
 	return out, nil
 }
 
+func (c *metricsServiceClient) GetScalingDecisions(ctx context.Context, in *ScalableObjectRef, opts ...grpc.CallOption) (*ScalingDecisionList, error) {
+	out := new(ScalingDecisionList)
+	err := c.cc.Invoke(ctx, MetricsService_GetScalingDecisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsServiceClient) GetMetricsBatch(ctx context.Context, in *ScaledObjectRefList, opts ...grpc.CallOption) (*ScaledObjectMetricsList, error) {
+	out := new(ScaledObjectMetricsList)
+	err := c.cc.Invoke(ctx, MetricsService_GetMetricsBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricsServiceClient) WatchMetrics(ctx context.Context, in *WatchMetricsRequest, opts ...grpc.CallOption) (MetricsService_WatchMetricsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MetricsService_ServiceDesc.Streams[0], MetricsService_WatchMetrics_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &metricsServiceWatchMetricsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MetricsService_WatchMetricsClient interface {
+	Recv() (*ScaledObjectMetricsList, error)
+	grpc.ClientStream
+}
+
+type metricsServiceWatchMetricsClient struct {
+	grpc.ClientStream
+}
+
+func (x *metricsServiceWatchMetricsClient) Recv() (*ScaledObjectMetricsList, error) {
+	m := new(ScaledObjectMetricsList)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MetricsServiceServer is the server API for MetricsService service.
 // All implementations must embed UnimplementedMetricsServiceServer
 // for forward compatibility
 type MetricsServiceServer interface {
 	GetMetrics(context.Context, *ScaledObjectRef) (*v1beta1.ExternalMetricValueList, error)
+	GetScalingDecisions(context.Context, *ScalableObjectRef) (*ScalingDecisionList, error)
+	GetMetricsBatch(context.Context, *ScaledObjectRefList) (*ScaledObjectMetricsList, error)
+	WatchMetrics(*WatchMetricsRequest, MetricsService_WatchMetricsServer) error
 	mustEmbedUnimplementedMetricsServiceServer()
 }
 
@@ -77,6 +136,15 @@ type UnimplementedMetricsServiceServer struct {
 func (UnimplementedMetricsServiceServer) GetMetrics(context.Context, *ScaledObjectRef) (*v1beta1.ExternalMetricValueList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (UnimplementedMetricsServiceServer) GetScalingDecisions(context.Context, *ScalableObjectRef) (*ScalingDecisionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScalingDecisions not implemented")
+}
+func (UnimplementedMetricsServiceServer) GetMetricsBatch(context.Context, *ScaledObjectRefList) (*ScaledObjectMetricsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetricsBatch not implemented")
+}
+func (UnimplementedMetricsServiceServer) WatchMetrics(*WatchMetricsRequest, MetricsService_WatchMetricsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMetrics not implemented")
+}
 func (UnimplementedMetricsServiceServer) mustEmbedUnimplementedMetricsServiceServer() {}
 
 // UnsafeMetricsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -108,6 +176,63 @@ func _MetricsService_GetMetrics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetricsService_GetScalingDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScalableObjectRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServiceServer).GetScalingDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricsService_GetScalingDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServiceServer).GetScalingDecisions(ctx, req.(*ScalableObjectRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricsService_GetMetricsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaledObjectRefList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServiceServer).GetMetricsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetricsService_GetMetricsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServiceServer).GetMetricsBatch(ctx, req.(*ScaledObjectRefList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricsService_WatchMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMetricsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MetricsServiceServer).WatchMetrics(m, &metricsServiceWatchMetricsServer{stream})
+}
+
+type MetricsService_WatchMetricsServer interface {
+	Send(*ScaledObjectMetricsList) error
+	grpc.ServerStream
+}
+
+type metricsServiceWatchMetricsServer struct {
+	grpc.ServerStream
+}
+
+func (x *metricsServiceWatchMetricsServer) Send(m *ScaledObjectMetricsList) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // MetricsService_ServiceDesc is the grpc.ServiceDesc for MetricsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,7 +244,21 @@ var MetricsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMetrics",
 			Handler:    _MetricsService_GetMetrics_Handler,
 		},
+		{
+			MethodName: "GetScalingDecisions",
+			Handler:    _MetricsService_GetScalingDecisions_Handler,
+		},
+		{
+			MethodName: "GetMetricsBatch",
+			Handler:    _MetricsService_GetMetricsBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMetrics",
+			Handler:       _MetricsService_WatchMetrics_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "metrics.proto",
 }
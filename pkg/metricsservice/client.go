@@ -18,7 +18,9 @@ package metricsservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -32,8 +34,11 @@ import (
 )
 
 type GrpcClient struct {
-	client     api.MetricsServiceClient
-	connection *grpc.ClientConn
+	client      api.MetricsServiceClient
+	connection  *grpc.ClientConn
+	dialOptions []grpc.DialOption
+	// shards is set when the operator is sharded, the requests are sent to the replica owning the ScaledObject
+	shards *shardClients
 }
 
 func NewGrpcClient(url, certDir string) (*GrpcClient, error) {
@@ -62,11 +67,11 @@ func NewGrpcClient(url, certDir string) (*GrpcClient, error) {
 		return nil, err
 	}
 
-	return &GrpcClient{client: api.NewMetricsServiceClient(conn), connection: conn}, nil
+	return &GrpcClient{client: api.NewMetricsServiceClient(conn), connection: conn, dialOptions: opts}, nil
 }
 
 func (c *GrpcClient) GetMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error) {
-	v1beta1ExtMetrics, err := c.client.GetMetrics(ctx, &api.ScaledObjectRef{Name: scaledObjectName, Namespace: scaledObjectNamespace, MetricName: metricName})
+	v1beta1ExtMetrics, err := c.getMetrics(ctx, &api.ScaledObjectRef{Name: scaledObjectName, Namespace: scaledObjectNamespace, MetricName: metricName})
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +85,96 @@ func (c *GrpcClient) GetMetrics(ctx context.Context, scaledObjectName, scaledObj
 	return extMetrics, nil
 }
 
+// ScaledObjectMetrics are the metric values of a ScaledObject returned by GetMetricsBatch and WatchMetrics
+type ScaledObjectMetrics struct {
+	ScaledObjectName      string
+	ScaledObjectNamespace string
+	MetricName            string
+	Metrics               *external_metrics.ExternalMetricValueList
+	// Err is the error getting the metric values, if any
+	Err error
+	// TTL is how long the metric values of WatchMetrics can be served
+	TTL time.Duration
+}
+
+// GetMetricsBatch returns the metric values of many ScaledObjects with a single request, in the same order,
+// a failure to get the metric values of a ScaledObject is reported in its result
+func (c *GrpcClient) GetMetricsBatch(ctx context.Context, refs []*api.ScaledObjectRef) ([]ScaledObjectMetrics, error) {
+	list, err := c.getMetricsBatch(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	return convertScaledObjectMetricsList(list)
+}
+
+// WatchMetrics calls the handler with the metric values of a ScaledObject each time they are refreshed by the operator,
+// for the ScaledObjects of the namespaces, or all of them if empty. It blocks until the stream or the context ends.
+// When the operator is sharded, the metrics of each replica are watched until the context ends and the handler
+// can be called concurrently.
+func (c *GrpcClient) WatchMetrics(ctx context.Context, namespaces []string, handler func([]ScaledObjectMetrics)) error {
+	if c.shards != nil {
+		c.watchShardsMetrics(ctx, namespaces, handler)
+		return nil
+	}
+	return watchMetrics(ctx, c.client, namespaces, handler)
+}
+
+func watchMetrics(ctx context.Context, client api.MetricsServiceClient, namespaces []string, handler func([]ScaledObjectMetrics)) error {
+	stream, err := client.WatchMetrics(ctx, &api.WatchMetricsRequest{Namespaces: namespaces})
+	if err != nil {
+		return err
+	}
+	for {
+		list, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		items, err := convertScaledObjectMetricsList(list)
+		if err != nil {
+			return err
+		}
+		handler(items)
+	}
+}
+
+func convertScaledObjectMetricsList(list *api.ScaledObjectMetricsList) ([]ScaledObjectMetrics, error) {
+	items := make([]ScaledObjectMetrics, 0, len(list.Items))
+	for _, item := range list.Items {
+		converted := ScaledObjectMetrics{
+			ScaledObjectName:      item.Ref.GetName(),
+			ScaledObjectNamespace: item.Ref.GetNamespace(),
+			MetricName:            item.Ref.GetMetricName(),
+			TTL:                   item.Ttl.AsDuration(),
+		}
+		if item.Error != "" {
+			converted.Err = errors.New(item.Error)
+		} else {
+			extMetrics := &external_metrics.ExternalMetricValueList{}
+			if item.Metrics != nil {
+				err := v1beta1.Convert_v1beta1_ExternalMetricValueList_To_external_metrics_ExternalMetricValueList(item.Metrics, extMetrics, nil)
+				if err != nil {
+					return nil, fmt.Errorf("error when converting metric values %w", err)
+				}
+			}
+			converted.Metrics = extMetrics
+		}
+		items = append(items, converted)
+	}
+	return items, nil
+}
+
+// GetScalingDecisions returns the latest scaling decisions of the ScaledObject or ScaledJob, oldest first
+func (c *GrpcClient) GetScalingDecisions(ctx context.Context, kind, namespace, name string) ([]*api.ScalingDecision, error) {
+	list, err := c.client.GetScalingDecisions(ctx, &api.ScalableObjectRef{Kind: kind, Namespace: namespace, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return list.Decisions, nil
+}
+
 // WaitForConnectionReady waits for gRPC connection to be ready
 // returns true if the connection was successful, false if we hit a timeut from context
 func (c *GrpcClient) WaitForConnectionReady(ctx context.Context, logger logr.Logger) bool {
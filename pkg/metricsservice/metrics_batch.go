@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+)
+
+const (
+	// maxConcurrentMetricsRequests is the number of metrics of a batch queried concurrently
+	maxConcurrentMetricsRequests = 16
+
+	// watchedMetricsTTLPollingIntervals is the number of polling intervals the watched metrics can be served for,
+	// after that they are considered stale and the adapter queries them again
+	watchedMetricsTTLPollingIntervals = 2
+)
+
+// GetMetricsBatch returns the metric values of each ScaledObject reference, a failure to get
+// the metrics of a reference is reported in its result and doesn't fail the whole batch
+func (s *GrpcServer) GetMetricsBatch(ctx context.Context, in *api.ScaledObjectRefList) (*api.ScaledObjectMetricsList, error) {
+	return &api.ScaledObjectMetricsList{Items: s.getMetricsBatch(ctx, in.Refs)}, nil
+}
+
+// WatchMetrics sends the metric values of the ScaledObjects of the requested namespaces, or of all of them,
+// each time their scale loop refreshed them. The values are served for two polling intervals by the adapter,
+// the metrics of triggers with useCachedMetrics are the ones read by the scale loop.
+func (s *GrpcServer) WatchMetrics(in *api.WatchMetricsRequest, stream api.MetricsService_WatchMetricsServer) error {
+	ctx := stream.Context()
+	log.V(1).Info("Watching metrics", "namespaces", in.Namespaces)
+	for refresh := range (*s.scalerHandler).WatchScaledObjectMetrics(ctx) {
+		if len(in.Namespaces) > 0 && !slices.Contains(in.Namespaces, refresh.Namespace) {
+			continue
+		}
+		if len(refresh.MetricNames) == 0 {
+			continue
+		}
+		refs := make([]*api.ScaledObjectRef, 0, len(refresh.MetricNames))
+		for _, metricName := range refresh.MetricNames {
+			refs = append(refs, &api.ScaledObjectRef{Name: refresh.Name, Namespace: refresh.Namespace, MetricName: metricName})
+		}
+		items := s.getMetricsBatch(ctx, refs)
+		ttl := durationpb.New(watchedMetricsTTLPollingIntervals * refresh.PollingInterval)
+		for _, item := range items {
+			item.Ttl = ttl
+		}
+		if err := stream.Send(&api.ScaledObjectMetricsList{Items: items}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getMetricsBatch returns the metric values of the ScaledObject references, in the same order
+func (s *GrpcServer) getMetricsBatch(ctx context.Context, refs []*api.ScaledObjectRef) []*api.ScaledObjectMetrics {
+	items := make([]*api.ScaledObjectMetrics, len(refs))
+	semaphore := make(chan struct{}, maxConcurrentMetricsRequests)
+	wg := sync.WaitGroup{}
+	for i, ref := range refs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, ref *api.ScaledObjectRef) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			item := &api.ScaledObjectMetrics{Ref: ref}
+			metrics, err := s.GetMetrics(ctx, ref)
+			if err != nil {
+				item.Error = err.Error()
+			} else {
+				item.Metrics = metrics
+			}
+			items[i] = item
+		}(i, ref)
+	}
+	wg.Wait()
+	return items
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+func newMetricValueList(metricName string, value int64) *external_metrics.ExternalMetricValueList {
+	return &external_metrics.ExternalMetricValueList{Items: []external_metrics.ExternalMetricValue{
+		{MetricName: metricName, Value: *resource.NewQuantity(value, resource.DecimalSI)},
+	}}
+}
+
+func newTestGrpcServer(t *testing.T) (*GrpcServer, *mock_scaling.MockScaleHandler) {
+	mockScaleHandler := mock_scaling.NewMockScaleHandler(gomock.NewController(t))
+	var scaleHandler scaling.ScaleHandler = mockScaleHandler
+	server := NewGrpcServer(&scaleHandler, "", "", nil, false)
+	return &server, mockScaleHandler
+}
+
+func TestGetMetricsBatch(t *testing.T) {
+	server, mockScaleHandler := newTestGrpcServer(t)
+	mockScaleHandler.EXPECT().GetScaledObjectMetrics(gomock.Any(), "worker", "default", "s0-queue").Return(newMetricValueList("s0-queue", 5), nil)
+	mockScaleHandler.EXPECT().GetScaledObjectMetrics(gomock.Any(), "api", "default", "s0-queue").Return(nil, errors.New("connection refused"))
+
+	list, err := server.GetMetricsBatch(context.Background(), &api.ScaledObjectRefList{Refs: []*api.ScaledObjectRef{
+		{Name: "worker", Namespace: "default", MetricName: "s0-queue"},
+		{Name: "api", Namespace: "default", MetricName: "s0-queue"},
+	}})
+	require.NoError(t, err, "a failure of a ScaledObject doesn't fail the batch")
+	require.Len(t, list.Items, 2)
+
+	assert.Equal(t, "worker", list.Items[0].Ref.Name)
+	assert.Empty(t, list.Items[0].Error)
+	if assert.Len(t, list.Items[0].Metrics.Items, 1) {
+		assert.Equal(t, int64(5), list.Items[0].Metrics.Items[0].Value.Value())
+	}
+	assert.Equal(t, "api", list.Items[1].Ref.Name)
+	assert.Contains(t, list.Items[1].Error, "connection refused")
+	assert.Nil(t, list.Items[1].Metrics)
+
+	items, err := convertScaledObjectMetricsList(list)
+	require.NoError(t, err)
+	assert.Equal(t, "s0-queue", items[0].MetricName)
+	assert.Equal(t, int64(5), items[0].Metrics.Items[0].Value.Value())
+	assert.EqualError(t, items[1].Err, list.Items[1].Error)
+}
+
+// watchMetricsStream records the metrics sent by WatchMetrics
+type watchMetricsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *api.ScaledObjectMetricsList
+}
+
+func (s *watchMetricsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchMetricsStream) Send(list *api.ScaledObjectMetricsList) error {
+	s.sent <- list
+	return nil
+}
+
+func TestWatchMetrics(t *testing.T) {
+	server, mockScaleHandler := newTestGrpcServer(t)
+	refreshes := make(chan scaling.MetricsRefresh, 2)
+	refreshes <- scaling.MetricsRefresh{NamespacedName: types.NamespacedName{Namespace: "other", Name: "worker"}, MetricNames: []string{"s0-queue"}}
+	refreshes <- scaling.MetricsRefresh{NamespacedName: types.NamespacedName{Namespace: "default", Name: "worker"}, MetricNames: []string{"s0-queue", "s1-cron"}, PollingInterval: 30 * time.Second}
+	close(refreshes)
+	mockScaleHandler.EXPECT().WatchScaledObjectMetrics(gomock.Any()).Return(refreshes)
+	mockScaleHandler.EXPECT().GetScaledObjectMetrics(gomock.Any(), "worker", "default", "s0-queue").Return(newMetricValueList("s0-queue", 5), nil)
+	mockScaleHandler.EXPECT().GetScaledObjectMetrics(gomock.Any(), "worker", "default", "s1-cron").Return(newMetricValueList("s1-cron", 1), nil)
+
+	stream := &watchMetricsStream{ctx: context.Background(), sent: make(chan *api.ScaledObjectMetricsList, 2)}
+	require.NoError(t, server.WatchMetrics(&api.WatchMetricsRequest{Namespaces: []string{"default"}}, stream))
+	close(stream.sent)
+
+	var sent []*api.ScaledObjectMetricsList
+	for list := range stream.sent {
+		sent = append(sent, list)
+	}
+	require.Len(t, sent, 1, "the ScaledObjects of the other namespaces aren't watched")
+	require.Len(t, sent[0].Items, 2)
+	assert.Equal(t, "s0-queue", sent[0].Items[0].Ref.MetricName)
+	assert.Equal(t, "s1-cron", sent[0].Items[1].Ref.MetricName)
+	assert.Equal(t, time.Minute, sent[0].Items[0].Ttl.AsDuration(), "the metrics are served for two polling intervals")
+}
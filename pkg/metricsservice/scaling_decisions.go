@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+)
+
+// convertScalingDecisions converts the scaling decisions to their gRPC representation
+func convertScalingDecisions(decisions []kedav1alpha1.ScalingDecision) *api.ScalingDecisionList {
+	list := &api.ScalingDecisionList{Decisions: make([]*api.ScalingDecision, 0, len(decisions))}
+	for _, decision := range decisions {
+		converted := &api.ScalingDecision{
+			Time:           timestamppb.New(decision.Time.Time),
+			IsActive:       decision.IsActive,
+			IsError:        decision.IsError,
+			IsFallback:     decision.IsFallback,
+			IsPaused:       decision.IsPaused,
+			ReplicasBefore: decision.ReplicasBefore,
+			ReplicasAfter:  decision.ReplicasAfter,
+		}
+		if decision.FormulaValue != nil {
+			converted.FormulaValue = wrapperspb.Double(decision.FormulaValue.AsApproximateFloat64())
+		}
+		for _, trigger := range decision.Triggers {
+			convertedTrigger := &api.TriggerDecision{
+				Name:       trigger.Name,
+				MetricName: trigger.MetricName,
+				IsActive:   trigger.IsActive,
+				IsError:    trigger.IsError,
+			}
+			if trigger.Value != nil {
+				convertedTrigger.Value = trigger.Value.AsApproximateFloat64()
+			}
+			converted.Triggers = append(converted.Triggers, convertedTrigger)
+		}
+		list.Decisions = append(list.Decisions, converted)
+	}
+	return list
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestConvertScalingDecisions(t *testing.T) {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	decisions := []kedav1alpha1.ScalingDecision{
+		{
+			Time: metav1.NewTime(now),
+			Triggers: []kedav1alpha1.TriggerDecision{
+				{Name: "queue", MetricName: "s0-queue", Value: resource.NewMilliQuantity(2500, resource.DecimalSI), IsActive: true},
+				{Name: "cpu", IsError: true},
+			},
+			FormulaValue:   resource.NewQuantity(5, resource.DecimalSI),
+			IsActive:       true,
+			ReplicasBefore: 1,
+			ReplicasAfter:  3,
+		},
+		{
+			Time:           metav1.NewTime(now.Add(30 * time.Second)),
+			IsPaused:       true,
+			ReplicasBefore: 3,
+			ReplicasAfter:  3,
+		},
+	}
+
+	list := convertScalingDecisions(decisions)
+	assert.Len(t, list.Decisions, 2)
+
+	first := list.Decisions[0]
+	assert.True(t, now.Equal(first.Time.AsTime()))
+	assert.Equal(t, 5.0, first.FormulaValue.GetValue())
+	assert.True(t, first.IsActive)
+	assert.Equal(t, int64(1), first.ReplicasBefore)
+	assert.Equal(t, int64(3), first.ReplicasAfter)
+	assert.Len(t, first.Triggers, 2)
+	assert.Equal(t, "s0-queue", first.Triggers[0].MetricName)
+	assert.Equal(t, 2.5, first.Triggers[0].Value)
+	assert.True(t, first.Triggers[0].IsActive)
+	assert.True(t, first.Triggers[1].IsError)
+
+	second := list.Decisions[1]
+	assert.Nil(t, second.FormulaValue)
+	assert.Empty(t, second.Triggers)
+	assert.True(t, second.IsPaused)
+
+	assert.Empty(t, convertScalingDecisions(nil).Decisions)
+}
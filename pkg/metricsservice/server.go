@@ -22,6 +22,8 @@ import (
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -38,6 +40,8 @@ type GrpcServer struct {
 	certDir       string
 	certsReady    chan struct{}
 	scalerHandler *scaling.ScaleHandler
+	// sharded is set when the ScaledObjects are sharded among the operator replicas, each of them serves the metrics of its shard
+	sharded bool
 	api.UnimplementedMetricsServiceServer
 }
 
@@ -59,13 +63,23 @@ func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*
 	return v1beta1ExtMetrics, nil
 }
 
-// NewGrpcServer creates a new instance of GrpcServer
-func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}) GrpcServer {
+// GetScalingDecisions returns the latest scaling decisions of the specified ScaledObject or ScaledJob, oldest first
+func (s *GrpcServer) GetScalingDecisions(_ context.Context, in *api.ScalableObjectRef) (*api.ScalingDecisionList, error) {
+	if in.Kind != "ScaledObject" && in.Kind != "ScaledJob" {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported kind %q, expected ScaledObject or ScaledJob", in.Kind)
+	}
+	decisions := (*s.scalerHandler).GetScalingDecisions(in.Kind, in.Namespace, in.Name)
+	return convertScalingDecisions(decisions), nil
+}
+
+// NewGrpcServer creates a new instance of GrpcServer, it runs in the leader unless the operator is sharded
+func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}, sharded bool) GrpcServer {
 	return GrpcServer{
 		address:       address,
 		scalerHandler: scaleHandler,
 		certDir:       certDir,
 		certsReady:    certsReady,
+		sharded:       sharded,
 	}
 }
 
@@ -117,6 +131,7 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 // NeedLeaderElection is needed to implement LeaderElectionRunnable interface
 // of controller-runtime. This assures that the component is started/stoped
 // when this particular instance is selected/deselected as a leader.
+// Every replica serves the metrics of its shard when the operator is sharded.
 func (s *GrpcServer) NeedLeaderElection() bool {
-	return true
+	return !s.sharded
 }
@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+const (
+	// shardWatchSyncInterval is the interval the metrics watches are started or stopped at when the replicas change
+	shardWatchSyncInterval = 5 * time.Second
+	// shardWatchRetryInterval is the interval between the attempts to watch the metrics of a replica
+	shardWatchRetryInterval = 5 * time.Second
+)
+
+// ShardResolver finds the operator replica owning a ScaledObject when the operator is sharded, see sharding.Resolver
+type ShardResolver interface {
+	Owner(key string) (sharding.Member, bool)
+	Members() []sharding.Member
+}
+
+// shardClients holds the connections to the Metrics Service of the operator replicas
+type shardClients struct {
+	resolver    ShardResolver
+	dialOptions []grpc.DialOption
+
+	lock    sync.Mutex
+	clients map[string]*shardClient
+}
+
+type shardClient struct {
+	client     api.MetricsServiceClient
+	connection *grpc.ClientConn
+}
+
+// EnableSharding sends the requests to the operator replica owning the ScaledObject, which holds the metrics
+// cached by its scale loop, and falls back to the Metrics Service address when the replica is unavailable.
+// The replicas are dialed with the authority of the Metrics Service address, so that their certificate is verified.
+func (c *GrpcClient) EnableSharding(resolver ShardResolver) {
+	authority := c.connection.Target()
+	if host, _, err := net.SplitHostPort(authority); err == nil {
+		authority = host
+	}
+	dialOptions := make([]grpc.DialOption, 0, len(c.dialOptions)+1)
+	dialOptions = append(dialOptions, c.dialOptions...)
+	dialOptions = append(dialOptions, grpc.WithAuthority(authority))
+	c.shards = &shardClients{
+		resolver:    resolver,
+		dialOptions: dialOptions,
+		clients:     map[string]*shardClient{},
+	}
+}
+
+// clientFor returns the client of the replica owning the ScaledObject, or nil if it isn't known
+func (s *shardClients) clientFor(namespace, scaledObjectName string) api.MetricsServiceClient {
+	if s == nil {
+		return nil
+	}
+	member, ok := s.resolver.Owner(kedav1alpha1.GenerateIdentifier("ScaledObject", namespace, scaledObjectName))
+	if !ok || member.Address == "" {
+		return nil
+	}
+	return s.client(member.Address)
+}
+
+// client returns the client of the replica, the replicas which left are disconnected when a new one is dialed
+func (s *shardClients) client(address string) api.MetricsServiceClient {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	shard, ok := s.clients[address]
+	if !ok {
+		connection, err := grpc.Dial(address, s.dialOptions...)
+		if err != nil {
+			return nil
+		}
+		shard = &shardClient{client: api.NewMetricsServiceClient(connection), connection: connection}
+		s.clients[address] = shard
+		s.prune()
+	}
+	return shard.client
+}
+
+// prune closes the connections to the replicas which left, the lock must be held
+func (s *shardClients) prune() {
+	live := map[string]bool{}
+	for _, member := range s.resolver.Members() {
+		live[member.Address] = true
+	}
+	for address, shard := range s.clients {
+		if !live[address] {
+			_ = shard.connection.Close()
+			delete(s.clients, address)
+		}
+	}
+}
+
+// isShardUnavailable returns whether the request failed because the replica couldn't be reached
+func isShardUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+func (c *GrpcClient) getMetrics(ctx context.Context, ref *api.ScaledObjectRef) (*v1beta1.ExternalMetricValueList, error) {
+	if shard := c.shards.clientFor(ref.Namespace, ref.Name); shard != nil {
+		metrics, err := shard.GetMetrics(ctx, ref)
+		if !isShardUnavailable(err) {
+			return metrics, err
+		}
+	}
+	return c.client.GetMetrics(ctx, ref)
+}
+
+// shardBatch are the references of a batch sent to the same replica, with their index in the batch
+type shardBatch struct {
+	client  api.MetricsServiceClient
+	indexes []int
+	refs    []*api.ScaledObjectRef
+}
+
+// getMetricsBatch splits the batch by replica when the operator is sharded
+func (c *GrpcClient) getMetricsBatch(ctx context.Context, refs []*api.ScaledObjectRef) (*api.ScaledObjectMetricsList, error) {
+	if c.shards == nil {
+		return c.client.GetMetricsBatch(ctx, &api.ScaledObjectRefList{Refs: refs})
+	}
+
+	batches := map[api.MetricsServiceClient]*shardBatch{}
+	for i, ref := range refs {
+		shard := c.shards.clientFor(ref.Namespace, ref.Name)
+		if shard == nil {
+			shard = c.client
+		}
+		batch, ok := batches[shard]
+		if !ok {
+			batch = &shardBatch{client: shard}
+			batches[shard] = batch
+		}
+		batch.indexes = append(batch.indexes, i)
+		batch.refs = append(batch.refs, ref)
+	}
+
+	items := make([]*api.ScaledObjectMetrics, len(refs))
+	errs := make(chan error, len(batches))
+	wg := sync.WaitGroup{}
+	for _, batch := range batches {
+		wg.Add(1)
+		go func(batch *shardBatch) {
+			defer wg.Done()
+			request := &api.ScaledObjectRefList{Refs: batch.refs}
+			list, err := batch.client.GetMetricsBatch(ctx, request)
+			if isShardUnavailable(err) && batch.client != c.client {
+				list, err = c.client.GetMetricsBatch(ctx, request)
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(list.Items) != len(batch.refs) {
+				errs <- fmt.Errorf("expected %d metrics in the batch, got %d", len(batch.refs), len(list.Items))
+				return
+			}
+			for j, item := range list.Items {
+				items[batch.indexes[j]] = item
+			}
+		}(batch)
+	}
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return &api.ScaledObjectMetricsList{Items: items}, nil
+}
+
+// watchShardsMetrics watches the metrics of each replica, the watches follow the replicas until the context is done
+func (c *GrpcClient) watchShardsMetrics(ctx context.Context, namespaces []string, handler func([]ScaledObjectMetrics)) {
+	watches := map[string]context.CancelFunc{}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		live := map[string]bool{}
+		for _, member := range c.shards.resolver.Members() {
+			if member.Address == "" {
+				continue
+			}
+			live[member.Address] = true
+			if _, ok := watches[member.Address]; ok {
+				continue
+			}
+			client := c.shards.client(member.Address)
+			if client == nil {
+				continue
+			}
+			watchCtx, cancel := context.WithCancel(ctx)
+			watches[member.Address] = cancel
+			go wait.UntilWithContext(watchCtx, func(ctx context.Context) {
+				_ = watchMetrics(ctx, client, namespaces, handler)
+			}, shardWatchRetryInterval)
+		}
+		for address, cancel := range watches {
+			if !live[address] {
+				cancel()
+				delete(watches, address)
+			}
+		}
+	}, shardWatchSyncInterval)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsservice
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+// fakeMetricsServer returns metrics named after the server, to tell which replica served them
+type fakeMetricsServer struct {
+	name string
+	api.UnimplementedMetricsServiceServer
+}
+
+func (s *fakeMetricsServer) GetMetrics(_ context.Context, _ *api.ScaledObjectRef) (*v1beta1.ExternalMetricValueList, error) {
+	return &v1beta1.ExternalMetricValueList{Items: []v1beta1.ExternalMetricValue{{MetricName: s.name}}}, nil
+}
+
+func (s *fakeMetricsServer) GetMetricsBatch(ctx context.Context, in *api.ScaledObjectRefList) (*api.ScaledObjectMetricsList, error) {
+	list := &api.ScaledObjectMetricsList{}
+	for _, ref := range in.Refs {
+		metrics, _ := s.GetMetrics(ctx, ref)
+		list.Items = append(list.Items, &api.ScaledObjectMetrics{Ref: ref, Metrics: metrics})
+	}
+	return list, nil
+}
+
+func startFakeMetricsServer(t *testing.T, name string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	api.RegisterMetricsServiceServer(server, &fakeMetricsServer{name: name})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+type fakeShardResolver map[string]sharding.Member
+
+func (r fakeShardResolver) Owner(key string) (sharding.Member, bool) {
+	member, ok := r[key]
+	return member, ok
+}
+
+func (r fakeShardResolver) Members() []sharding.Member {
+	var members []sharding.Member
+	for _, member := range r {
+		members = append(members, member)
+	}
+	return members
+}
+
+func TestShardedGrpcClient(t *testing.T) {
+	defaultAddress := startFakeMetricsServer(t, "default")
+	shardAddress := startFakeMetricsServer(t, "shard")
+	unavailableListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unavailableAddress := unavailableListener.Addr().String()
+	require.NoError(t, unavailableListener.Close())
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	conn, err := grpc.Dial(defaultAddress, opts...)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := &GrpcClient{client: api.NewMetricsServiceClient(conn), connection: conn, dialOptions: opts}
+	client.EnableSharding(fakeShardResolver{
+		kedav1alpha1.GenerateIdentifier("ScaledObject", "default", "worker"): {Name: "keda-operator-a", Address: shardAddress},
+		kedav1alpha1.GenerateIdentifier("ScaledObject", "default", "api"):    {Name: "keda-operator-b", Address: unavailableAddress},
+	})
+
+	tests := []struct {
+		scaledObject string
+		server       string
+	}{
+		{scaledObject: "worker", server: "shard"},
+		{scaledObject: "api", server: "default"},
+		{scaledObject: "unknown", server: "default"},
+	}
+	for _, test := range tests {
+		t.Run(test.scaledObject, func(t *testing.T) {
+			metrics, err := client.GetMetrics(context.Background(), test.scaledObject, "default", "s0-queue")
+			require.NoError(t, err)
+			require.Len(t, metrics.Items, 1)
+			assert.Equal(t, test.server, metrics.Items[0].MetricName)
+		})
+	}
+
+	var refs []*api.ScaledObjectRef
+	for _, test := range tests {
+		refs = append(refs, &api.ScaledObjectRef{Name: test.scaledObject, Namespace: "default", MetricName: "s0-queue"})
+	}
+	items, err := client.GetMetricsBatch(context.Background(), refs)
+	require.NoError(t, err)
+	require.Len(t, items, len(tests))
+	for i, test := range tests {
+		assert.Equal(t, test.scaledObject, items[i].ScaledObjectName)
+		require.NoError(t, items[i].Err)
+		assert.Equal(t, test.server, items[i].Metrics.Items[0].MetricName, test.scaledObject)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	scaling "github.com/kedacore/keda/v2/pkg/scaling"
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
 	external_metrics "k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -94,6 +96,20 @@ func (mr *MockScaleHandlerMockRecorder) GetScalersCache(ctx, scalableObject inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScalersCache", reflect.TypeOf((*MockScaleHandler)(nil).GetScalersCache), ctx, scalableObject)
 }
 
+// GetScalingDecisions mocks base method.
+func (m *MockScaleHandler) GetScalingDecisions(kind, namespace, name string) []v1alpha1.ScalingDecision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScalingDecisions", kind, namespace, name)
+	ret0, _ := ret[0].([]v1alpha1.ScalingDecision)
+	return ret0
+}
+
+// GetScalingDecisions indicates an expected call of GetScalingDecisions.
+func (mr *MockScaleHandlerMockRecorder) GetScalingDecisions(kind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScalingDecisions", reflect.TypeOf((*MockScaleHandler)(nil).GetScalingDecisions), kind, namespace, name)
+}
+
 // HandleScalableObject mocks base method.
 func (m *MockScaleHandler) HandleScalableObject(ctx context.Context, scalableObject interface{}) error {
 	m.ctrl.T.Helper()
@@ -107,3 +123,17 @@ func (mr *MockScaleHandlerMockRecorder) HandleScalableObject(ctx, scalableObject
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).HandleScalableObject), ctx, scalableObject)
 }
+
+// WatchScaledObjectMetrics mocks base method.
+func (m *MockScaleHandler) WatchScaledObjectMetrics(ctx context.Context) <-chan scaling.MetricsRefresh {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchScaledObjectMetrics", ctx)
+	ret0, _ := ret[0].(<-chan scaling.MetricsRefresh)
+	return ret0
+}
+
+// WatchScaledObjectMetrics indicates an expected call of WatchScaledObjectMetrics.
+func (mr *MockScaleHandlerMockRecorder) WatchScaledObjectMetrics(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchScaledObjectMetrics", reflect.TypeOf((*MockScaleHandler)(nil).WatchScaledObjectMetrics), ctx)
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/custom_metrics"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
+)
+
+// The metrics of the custom metrics API are described by:
+//   - the ScaledObject itself, eg. for consumers which aren't aware of the scaledobject.keda.sh/name label selector,
+//   - the scale target of the ScaledObject, for the HPA Object metric type,
+//   - the pods of the scale target, for the HPA Pods metric type, the value of the metric being split evenly across them.
+var (
+	scaledObjectsGroupResource = schema.GroupResource{Group: kedav1alpha1.GroupVersion.Group, Resource: "scaledobjects"}
+	podsGroupResource          = schema.GroupResource{Resource: "pods"}
+)
+
+// GetMetricByName returns the metric of the ScaledObject described by the object, which is either the ScaledObject
+// or its scale target
+func (p *KedaProvider) GetMetricByName(ctx context.Context, name types.NamespacedName, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValue, error) {
+	logger.V(1).Info("KEDA Metrics Server received request for custom metrics", "object", name, "resource", info.GroupResource.String(), "metric name", info.Metric, "metricSelector", metricSelector.String())
+	if !info.Namespaced {
+		return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+	}
+
+	// the share of a pod depends on the pods selected, so pods metrics are only served by selector
+	if info.GroupResource == podsGroupResource {
+		return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+	}
+
+	scaledObject, err := p.getScaledObjectForObject(ctx, name, info)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := p.getScaledObjectMetrics(ctx, scaledObject, info.Metric)
+	if err != nil {
+		return nil, err
+	}
+	return p.newMetricValue(info, name.Namespace, name.Name, metrics, sumMetricValues(metrics))
+}
+
+// GetMetricBySelector returns the metric of each ScaledObject matching the selector, or the metric of the ScaledObject
+// split across the pods matching the selector
+func (p *KedaProvider) GetMetricBySelector(ctx context.Context, namespace string, selector labels.Selector, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValueList, error) {
+	logger.V(1).Info("KEDA Metrics Server received request for custom metrics", "namespace", namespace, "resource", info.GroupResource.String(), "selector", selector.String(), "metric name", info.Metric, "metricSelector", metricSelector.String())
+	if !info.Namespaced {
+		return nil, provider.NewMetricNotFoundError(info.GroupResource, info.Metric)
+	}
+
+	list := &custom_metrics.MetricValueList{}
+	switch info.GroupResource {
+	case scaledObjectsGroupResource:
+		scaledObjects := &kedav1alpha1.ScaledObjectList{}
+		if err := p.client.List(ctx, scaledObjects, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, scaledObject := range scaledObjects.Items {
+			if slices.Contains(scaledObject.Status.ExternalMetricNames, info.Metric) {
+				names = append(names, scaledObject.Name)
+			}
+		}
+		metricsList, err := p.getMetricsBatch(ctx, namespace, names, info.Metric)
+		if err != nil {
+			return nil, err
+		}
+		for i, metrics := range metricsList {
+			value, err := p.newMetricValue(info, namespace, names[i], metrics, sumMetricValues(metrics))
+			if err != nil {
+				return nil, err
+			}
+			list.Items = append(list.Items, *value)
+		}
+	case podsGroupResource:
+		scaledObject, err := p.getScaledObjectForMetric(ctx, namespace, info, metricSelector)
+		if err != nil {
+			return nil, err
+		}
+		metrics, err := p.getScaledObjectMetrics(ctx, scaledObject, info.Metric)
+		if err != nil {
+			return nil, err
+		}
+		podNames, err := p.listPodNames(ctx, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		value := splitMetricValue(sumMetricValues(metrics), len(podNames))
+		for _, podName := range podNames {
+			metricValue, err := p.newMetricValue(info, namespace, podName, metrics, value)
+			if err != nil {
+				return nil, err
+			}
+			list.Items = append(list.Items, *metricValue)
+		}
+	default:
+		return nil, provider.NewMetricNotFoundError(info.GroupResource, info.Metric)
+	}
+	return list, nil
+}
+
+// getScaledObjectForObject returns the ScaledObject of the given name, or the ScaledObject scaling
+// the given object, if it has the metric
+func (p *KedaProvider) getScaledObjectForObject(ctx context.Context, name types.NamespacedName, info provider.CustomMetricInfo) (*kedav1alpha1.ScaledObject, error) {
+	if info.GroupResource == scaledObjectsGroupResource {
+		scaledObject := &kedav1alpha1.ScaledObject{}
+		if err := p.client.Get(ctx, name, scaledObject); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(scaledObject.Status.ExternalMetricNames, info.Metric) {
+			return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+		}
+		return scaledObject, nil
+	}
+
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := p.client.List(ctx, scaledObjects, client.InNamespace(name.Namespace)); err != nil {
+		return nil, err
+	}
+	scaledObject := findScaledObjectForScaleTarget(scaledObjects.Items, info.GroupResource, name.Name, info.Metric)
+	if scaledObject == nil {
+		return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+	}
+	return scaledObject, nil
+}
+
+// getScaledObjectForMetric returns the ScaledObject of the namespace having the metric, the metric selector
+// scaledobject.keda.sh/name is required when several ScaledObjects have a metric of this name
+func (p *KedaProvider) getScaledObjectForMetric(ctx context.Context, namespace string, info provider.CustomMetricInfo, metricSelector labels.Selector) (*kedav1alpha1.ScaledObject, error) {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := p.client.List(ctx, scaledObjects, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	scaledObjectName := ""
+	if selector, err := labels.ConvertSelectorToLabelsMap(metricSelector.String()); err == nil {
+		scaledObjectName = selector.Get(kedav1alpha1.ScaledObjectOwnerAnnotation)
+	}
+	scaledObject, err := findScaledObjectForMetric(scaledObjects.Items, info.Metric, scaledObjectName)
+	if err != nil {
+		return nil, err
+	}
+	if scaledObject == nil {
+		return nil, provider.NewMetricNotFoundError(info.GroupResource, info.Metric)
+	}
+	return scaledObject, nil
+}
+
+// findScaledObjectForScaleTarget returns the ScaledObject scaling the named object of the resource, if it has the metric
+func findScaledObjectForScaleTarget(scaledObjects []kedav1alpha1.ScaledObject, groupResource schema.GroupResource, name, metricName string) *kedav1alpha1.ScaledObject {
+	for i := range scaledObjects {
+		scaledObject := &scaledObjects[i]
+		gvkr := scaledObject.Status.ScaleTargetGVKR
+		if scaledObject.Spec.ScaleTargetRef == nil || scaledObject.Spec.ScaleTargetRef.Name != name || gvkr == nil {
+			continue
+		}
+		if gvkr.Group != groupResource.Group || gvkr.Resource != groupResource.Resource {
+			continue
+		}
+		if slices.Contains(scaledObject.Status.ExternalMetricNames, metricName) {
+			return scaledObject
+		}
+	}
+	return nil
+}
+
+// findScaledObjectForMetric returns the ScaledObject having the metric, which must be named scaledObjectName if it is set
+func findScaledObjectForMetric(scaledObjects []kedav1alpha1.ScaledObject, metricName, scaledObjectName string) (*kedav1alpha1.ScaledObject, error) {
+	var found *kedav1alpha1.ScaledObject
+	for i := range scaledObjects {
+		scaledObject := &scaledObjects[i]
+		if scaledObjectName != "" && scaledObject.Name != scaledObjectName {
+			continue
+		}
+		if !slices.Contains(scaledObject.Status.ExternalMetricNames, metricName) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("metric %s is defined by ScaledObjects %s and %s, the metric selector %s=<scaledObject name> is required", metricName, found.Name, scaledObject.Name, kedav1alpha1.ScaledObjectOwnerAnnotation)
+		}
+		found = scaledObject
+	}
+	return found, nil
+}
+
+// getScaledObjectMetrics returns the metric of the ScaledObject
+func (p *KedaProvider) getScaledObjectMetrics(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricName string) (*external_metrics.ExternalMetricValueList, error) {
+	return p.getMetrics(ctx, scaledObject.Name, scaledObject.Namespace, metricName)
+}
+
+// getMetricsBatch returns the metric of each ScaledObject of the namespace, in the same order, the metrics
+// which aren't watched are requested from the Metrics Service gRPC server with a single request
+func (p *KedaProvider) getMetricsBatch(ctx context.Context, namespace string, scaledObjectNames []string, metricName string) ([]*external_metrics.ExternalMetricValueList, error) {
+	metricsList := make([]*external_metrics.ExternalMetricValueList, len(scaledObjectNames))
+	var refs []*api.ScaledObjectRef
+	var indexes []int
+	for i, name := range scaledObjectNames {
+		if metrics, ok := p.watchedMetrics.get(namespace, name, metricName); ok {
+			metricsList[i] = metrics
+			continue
+		}
+		refs = append(refs, &api.ScaledObjectRef{Name: name, Namespace: namespace, MetricName: metricName})
+		indexes = append(indexes, i)
+	}
+	if len(refs) == 0 {
+		return metricsList, nil
+	}
+
+	if err := p.waitForGrpcConnection(ctx); err != nil {
+		return nil, err
+	}
+	results, err := p.grpcClient.GetMetricsBatch(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(refs) {
+		return nil, fmt.Errorf("expected the metrics of %d ScaledObjects, got %d", len(refs), len(results))
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			return nil, fmt.Errorf("error getting metric %s of ScaledObject %s/%s: %w", metricName, namespace, result.ScaledObjectName, result.Err)
+		}
+		metricsList[indexes[i]] = result.Metrics
+	}
+	logger.V(1).WithValues("scaledObjectNamespace", namespace, "scaledObjectNames", scaledObjectNames, "metrics", metricsList).Info("Receiving metrics")
+	return metricsList, nil
+}
+
+// listPodNames returns the names of the pods of the namespace matching the selector, only their metadata is cached
+func (p *KedaProvider) listPodNames(ctx context.Context, namespace string, selector labels.Selector) ([]string, error) {
+	pods := &metav1.PartialObjectMetadataList{}
+	pods.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "PodList"})
+	if err := p.client.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		names = append(names, pod.Name)
+	}
+	return names, nil
+}
+
+// newMetricValue returns the value of the metric for the described object
+func (p *KedaProvider) newMetricValue(info provider.CustomMetricInfo, namespace, name string, metrics *external_metrics.ExternalMetricValueList, value resource.Quantity) (*custom_metrics.MetricValue, error) {
+	gvk, err := p.client.RESTMapper().KindFor(info.GroupResource.WithVersion(""))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := metav1.Now()
+	if len(metrics.Items) > 0 {
+		timestamp = metrics.Items[0].Timestamp
+	}
+	return &custom_metrics.MetricValue{
+		DescribedObject: custom_metrics.ObjectReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  namespace,
+			Name:       name,
+		},
+		Metric:    custom_metrics.MetricIdentifier{Name: info.Metric},
+		Timestamp: timestamp,
+		Value:     value,
+	}, nil
+}
+
+// sumMetricValues returns the sum of the values of the metric
+func sumMetricValues(metrics *external_metrics.ExternalMetricValueList) resource.Quantity {
+	sum := resource.NewMilliQuantity(0, resource.DecimalSI)
+	for _, metric := range metrics.Items {
+		sum.Add(metric.Value)
+	}
+	return *sum
+}
+
+// splitMetricValue returns the share of the value of each of the pods
+func splitMetricValue(value resource.Quantity, podCount int) resource.Quantity {
+	if podCount <= 1 {
+		return value
+	}
+	return *resource.NewMilliQuantity(value.MilliValue()/int64(podCount), resource.DecimalSI)
+}
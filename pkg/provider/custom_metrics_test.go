@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newScaledObject(name, target string, gvkr *kedav1alpha1.GroupVersionKindResource, metricNames ...string) kedav1alpha1.ScaledObject {
+	return kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: target}},
+		Status:     kedav1alpha1.ScaledObjectStatus{ScaleTargetGVKR: gvkr, ExternalMetricNames: metricNames},
+	}
+}
+
+func TestFindScaledObjectForScaleTarget(t *testing.T) {
+	deployment := &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+	statefulSet := &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "StatefulSet", Resource: "statefulsets"}
+	scaledObjects := []kedav1alpha1.ScaledObject{
+		newScaledObject("worker-sts", "worker", statefulSet, "s0-queue"),
+		newScaledObject("worker", "worker", deployment, "s0-queue"),
+		newScaledObject("pending", "api", nil, "s0-queue"),
+	}
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	scaledObject := findScaledObjectForScaleTarget(scaledObjects, deployments, "worker", "s0-queue")
+	if assert.NotNil(t, scaledObject) {
+		assert.Equal(t, "worker", scaledObject.Name)
+	}
+	assert.Nil(t, findScaledObjectForScaleTarget(scaledObjects, deployments, "worker", "s1-cron"), "the ScaledObject doesn't have the metric")
+	assert.Nil(t, findScaledObjectForScaleTarget(scaledObjects, deployments, "api", "s0-queue"), "the scale target isn't resolved yet")
+}
+
+func TestFindScaledObjectForMetric(t *testing.T) {
+	scaledObjects := []kedav1alpha1.ScaledObject{
+		newScaledObject("worker", "worker", nil, "s0-queue", "s1-cron"),
+		newScaledObject("api", "api", nil, "s0-queue"),
+	}
+
+	scaledObject, err := findScaledObjectForMetric(scaledObjects, "s1-cron", "")
+	assert.NoError(t, err)
+	if assert.NotNil(t, scaledObject) {
+		assert.Equal(t, "worker", scaledObject.Name)
+	}
+
+	_, err = findScaledObjectForMetric(scaledObjects, "s0-queue", "")
+	assert.ErrorContains(t, err, "the metric selector scaledobject.keda.sh/name=<scaledObject name> is required")
+
+	scaledObject, err = findScaledObjectForMetric(scaledObjects, "s0-queue", "api")
+	assert.NoError(t, err)
+	if assert.NotNil(t, scaledObject) {
+		assert.Equal(t, "api", scaledObject.Name)
+	}
+
+	scaledObject, err = findScaledObjectForMetric(scaledObjects, "s2-kafka", "")
+	assert.NoError(t, err)
+	assert.Nil(t, scaledObject)
+}
+
+func TestSplitMetricValue(t *testing.T) {
+	metrics := &external_metrics.ExternalMetricValueList{Items: []external_metrics.ExternalMetricValue{
+		{Value: resource.MustParse("7")},
+		{Value: resource.MustParse("500m")},
+	}}
+	sum := sumMetricValues(metrics)
+	assert.Equal(t, int64(7500), sum.MilliValue())
+
+	share := splitMetricValue(sum, 3)
+	assert.Equal(t, int64(2500), share.MilliValue())
+	share = splitMetricValue(sum, 0)
+	assert.Equal(t, int64(7500), share.MilliValue())
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// MetricSource is an external metric served by the adapter and the ScaledObject and trigger it belongs to
+type MetricSource struct {
+	Namespace    string `json:"namespace"`
+	MetricName   string `json:"metricName"`
+	ScaledObject string `json:"scaledObject"`
+	// TriggerIndex, TriggerName and TriggerType describe the trigger of the metric,
+	// they are not set for the composite metric of the scalingModifiers
+	TriggerIndex *int   `json:"triggerIndex,omitempty"`
+	TriggerName  string `json:"triggerName,omitempty"`
+	TriggerType  string `json:"triggerType,omitempty"`
+}
+
+// MetricsIndex indexes the external metrics of the ScaledObjects, ie. their status.externalMetricNames
+// and status.compositeScalerName, it is kept up to date by the ScaledObject informer of the adapter
+type MetricsIndex struct {
+	lock sync.RWMutex
+	// metrics holds the sources of the metrics by namespace and metric name
+	metrics map[string]map[string][]MetricSource
+	// scaledObjects holds the sources of each ScaledObject, to remove them when it is updated or deleted
+	scaledObjects map[types.NamespacedName][]MetricSource
+
+	hasSynced func() bool
+}
+
+// NewMetricsIndex returns an empty MetricsIndex, it is filled once started
+func NewMetricsIndex() *MetricsIndex {
+	return &MetricsIndex{
+		metrics:       map[string]map[string][]MetricSource{},
+		scaledObjects: map[types.NamespacedName][]MetricSource{},
+	}
+}
+
+// Start registers the index on the ScaledObject informer of the cache, it must be called before the cache is started
+func (i *MetricsIndex) Start(ctx context.Context, informers ctrlcache.Informers) error {
+	informer, err := informers.GetInformer(ctx, &kedav1alpha1.ScaledObject{})
+	if err != nil {
+		return fmt.Errorf("error getting ScaledObject informer: %w", err)
+	}
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: i.onScaledObject,
+		UpdateFunc: func(_, newObj interface{}) {
+			i.onScaledObject(newObj)
+		},
+		DeleteFunc: i.onScaledObjectDeleted,
+	})
+	if err != nil {
+		return fmt.Errorf("error registering ScaledObject event handler: %w", err)
+	}
+	i.hasSynced = registration.HasSynced
+	return nil
+}
+
+// HasSynced returns whether the index contains all the ScaledObjects, metrics shouldn't be
+// reported as not found based on the index before it is synced
+func (i *MetricsIndex) HasSynced() bool {
+	return i.hasSynced != nil && i.hasSynced()
+}
+
+func (i *MetricsIndex) onScaledObject(obj interface{}) {
+	if scaledObject, ok := obj.(*kedav1alpha1.ScaledObject); ok {
+		i.Update(scaledObject)
+	}
+}
+
+func (i *MetricsIndex) onScaledObjectDeleted(obj interface{}) {
+	if deleted, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = deleted.Obj
+	}
+	if scaledObject, ok := obj.(*kedav1alpha1.ScaledObject); ok {
+		i.Delete(types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name})
+	}
+}
+
+// Update replaces the metrics of the ScaledObject in the index
+func (i *MetricsIndex) Update(scaledObject *kedav1alpha1.ScaledObject) {
+	key := types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}
+	sources := getMetricSources(scaledObject)
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.remove(key)
+	if len(sources) == 0 {
+		return
+	}
+	i.scaledObjects[key] = sources
+	namespaceMetrics, ok := i.metrics[key.Namespace]
+	if !ok {
+		namespaceMetrics = map[string][]MetricSource{}
+		i.metrics[key.Namespace] = namespaceMetrics
+	}
+	for _, source := range sources {
+		namespaceMetrics[source.MetricName] = append(namespaceMetrics[source.MetricName], source)
+	}
+}
+
+// Delete removes the metrics of the ScaledObject from the index
+func (i *MetricsIndex) Delete(key types.NamespacedName) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.remove(key)
+}
+
+// remove removes the metrics of the ScaledObject, the lock must be held
+func (i *MetricsIndex) remove(key types.NamespacedName) {
+	sources, ok := i.scaledObjects[key]
+	if !ok {
+		return
+	}
+	delete(i.scaledObjects, key)
+	namespaceMetrics := i.metrics[key.Namespace]
+	for _, source := range sources {
+		var kept []MetricSource
+		for _, other := range namespaceMetrics[source.MetricName] {
+			if other.ScaledObject != key.Name {
+				kept = append(kept, other)
+			}
+		}
+		if len(kept) == 0 {
+			delete(namespaceMetrics, source.MetricName)
+		} else {
+			namespaceMetrics[source.MetricName] = kept
+		}
+	}
+	if len(namespaceMetrics) == 0 {
+		delete(i.metrics, key.Namespace)
+	}
+}
+
+// HasMetric returns whether a ScaledObject of the namespace has the metric
+func (i *MetricsIndex) HasMetric(namespace, metricName string) bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return len(i.metrics[namespace][metricName]) > 0
+}
+
+// HasScaledObjectMetric returns whether the ScaledObject has the metric
+func (i *MetricsIndex) HasScaledObjectMetric(namespace, scaledObjectName, metricName string) bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	for _, source := range i.metrics[namespace][metricName] {
+		if source.ScaledObject == scaledObjectName {
+			return true
+		}
+	}
+	return false
+}
+
+// MetricNames returns the names of the metrics of all the ScaledObjects, sorted and without duplicates
+func (i *MetricsIndex) MetricNames() []string {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	seen := map[string]bool{}
+	var names []string
+	for _, namespaceMetrics := range i.metrics {
+		for metricName := range namespaceMetrics {
+			if !seen[metricName] {
+				seen[metricName] = true
+				names = append(names, metricName)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List returns the metric sources of the namespace, or of all the namespaces if it is empty,
+// sorted by namespace, metric name and ScaledObject
+func (i *MetricsIndex) List(namespace string) []MetricSource {
+	i.lock.RLock()
+	sources := []MetricSource{}
+	for key, scaledObjectSources := range i.scaledObjects {
+		if namespace == "" || key.Namespace == namespace {
+			sources = append(sources, scaledObjectSources...)
+		}
+	}
+	i.lock.RUnlock()
+
+	sort.Slice(sources, func(a, b int) bool {
+		if sources[a].Namespace != sources[b].Namespace {
+			return sources[a].Namespace < sources[b].Namespace
+		}
+		if sources[a].MetricName != sources[b].MetricName {
+			return sources[a].MetricName < sources[b].MetricName
+		}
+		return sources[a].ScaledObject < sources[b].ScaledObject
+	})
+	return sources
+}
+
+// ServeHTTP lists the metric sources as JSON, the namespace query parameter filters them by namespace
+func (i *MetricsIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	sources := i.List(req.URL.Query().Get("namespace"))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sources); err != nil {
+		logger.Error(err, "error encoding the metrics index")
+	}
+}
+
+// getMetricSources returns the external metrics of the ScaledObject with the trigger they belong to
+func getMetricSources(scaledObject *kedav1alpha1.ScaledObject) []MetricSource {
+	var sources []MetricSource
+	for _, metricName := range scaledObject.Status.ExternalMetricNames {
+		source := MetricSource{
+			Namespace:    scaledObject.Namespace,
+			MetricName:   metricName,
+			ScaledObject: scaledObject.Name,
+		}
+		if triggerIndex, ok := getMetricTriggerIndex(metricName); ok && triggerIndex < len(scaledObject.Spec.Triggers) {
+			trigger := scaledObject.Spec.Triggers[triggerIndex]
+			source.TriggerIndex = &triggerIndex
+			source.TriggerName = trigger.Name
+			source.TriggerType = trigger.Type
+		}
+		sources = append(sources, source)
+	}
+	if scaledObject.Status.CompositeScalerName != "" {
+		sources = append(sources, MetricSource{
+			Namespace:    scaledObject.Namespace,
+			MetricName:   scaledObject.Status.CompositeScalerName,
+			ScaledObject: scaledObject.Name,
+		})
+	}
+	return sources
+}
+
+// getMetricTriggerIndex returns the index of the trigger from the prefix of the metric name, see scalers.GenerateMetricNameWithIndex
+func getMetricTriggerIndex(metricName string) (int, bool) {
+	prefix, _, found := strings.Cut(metricName, "-")
+	if !found || !strings.HasPrefix(prefix, "s") {
+		return 0, false
+	}
+	triggerIndex, err := strconv.Atoi(prefix[1:])
+	if err != nil || triggerIndex < 0 {
+		return 0, false
+	}
+	return triggerIndex, true
+}
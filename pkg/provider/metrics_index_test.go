@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newIndexedScaledObject(namespace, name string, triggers []kedav1alpha1.ScaleTriggers, metricNames ...string) *kedav1alpha1.ScaledObject {
+	scaledObject := newScaledObject(name, name, nil, metricNames...)
+	scaledObject.Namespace = namespace
+	scaledObject.Spec.Triggers = triggers
+	return &scaledObject
+}
+
+func newSyncedMetricsIndex() *MetricsIndex {
+	index := NewMetricsIndex()
+	index.hasSynced = func() bool { return true }
+	return index
+}
+
+func TestMetricsIndex(t *testing.T) {
+	index := newSyncedMetricsIndex()
+	index.Update(newIndexedScaledObject("default", "worker", []kedav1alpha1.ScaleTriggers{
+		{Type: "rabbitmq", Name: "queue"},
+		{Type: "cron"},
+	}, "s0-rabbitmq-jobs", "s1-cron-utc"))
+	index.Update(newIndexedScaledObject("default", "api", nil, "s0-rabbitmq-jobs"))
+	index.Update(newIndexedScaledObject("other", "worker", nil, "s0-kafka-events"))
+
+	assert.True(t, index.HasMetric("default", "s0-rabbitmq-jobs"))
+	assert.False(t, index.HasMetric("other", "s0-rabbitmq-jobs"))
+	assert.True(t, index.HasScaledObjectMetric("default", "api", "s0-rabbitmq-jobs"))
+	assert.False(t, index.HasScaledObjectMetric("default", "api", "s1-cron-utc"))
+	assert.Equal(t, []string{"s0-kafka-events", "s0-rabbitmq-jobs", "s1-cron-utc"}, index.MetricNames())
+
+	assert.Equal(t, []MetricSource{
+		{Namespace: "default", MetricName: "s0-rabbitmq-jobs", ScaledObject: "api"},
+		{Namespace: "default", MetricName: "s0-rabbitmq-jobs", ScaledObject: "worker", TriggerIndex: ptr.To(0), TriggerName: "queue", TriggerType: "rabbitmq"},
+		{Namespace: "default", MetricName: "s1-cron-utc", ScaledObject: "worker", TriggerIndex: ptr.To(1), TriggerType: "cron"},
+	}, index.List("default"))
+
+	// the metrics of the updated ScaledObject replace the previous ones
+	composite := newIndexedScaledObject("default", "worker", nil)
+	composite.Status.CompositeScalerName = kedav1alpha1.CompositeMetricName
+	index.Update(composite)
+	assert.False(t, index.HasMetric("default", "s1-cron-utc"))
+	assert.True(t, index.HasScaledObjectMetric("default", "worker", kedav1alpha1.CompositeMetricName))
+	assert.True(t, index.HasScaledObjectMetric("default", "api", "s0-rabbitmq-jobs"))
+
+	index.Delete(types.NamespacedName{Namespace: "other", Name: "worker"})
+	assert.False(t, index.HasMetric("other", "s0-kafka-events"))
+	assert.Empty(t, index.List("other"))
+	assert.Equal(t, []string{kedav1alpha1.CompositeMetricName, "s0-rabbitmq-jobs"}, index.MetricNames())
+}
+
+func TestMetricsIndexServeHTTP(t *testing.T) {
+	index := newSyncedMetricsIndex()
+	index.Update(newIndexedScaledObject("default", "worker", []kedav1alpha1.ScaleTriggers{{Type: "rabbitmq"}}, "s0-rabbitmq-jobs"))
+	index.Update(newIndexedScaledObject("other", "worker", nil, "s0-kafka-events"))
+
+	recorder := httptest.NewRecorder()
+	index.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/external-metrics?namespace=default", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var sources []MetricSource
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &sources))
+	assert.Equal(t, []MetricSource{
+		{Namespace: "default", MetricName: "s0-rabbitmq-jobs", ScaledObject: "worker", TriggerIndex: ptr.To(0), TriggerType: "rabbitmq"},
+	}, sources)
+}
+
+func TestGetMetricTriggerIndex(t *testing.T) {
+	tests := []struct {
+		metricName string
+		index      int
+		found      bool
+	}{
+		{metricName: "s0-rabbitmq-jobs", index: 0, found: true},
+		{metricName: "s12-cron-utc", index: 12, found: true},
+		{metricName: kedav1alpha1.CompositeMetricName, found: false},
+		{metricName: "sx-queue", found: false},
+		{metricName: "s1", found: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.metricName, func(t *testing.T) {
+			index, found := getMetricTriggerIndex(test.metricName)
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.index, index)
+		})
+	}
+}
+
+func TestListAllExternalMetrics(t *testing.T) {
+	kedaProvider := &KedaProvider{metricsIndex: NewMetricsIndex()}
+	assert.Equal(t, []provider.ExternalMetricInfo{{Metric: "externalmetrics"}}, kedaProvider.ListAllExternalMetrics(), "the index isn't synced")
+
+	kedaProvider.metricsIndex = newSyncedMetricsIndex()
+	assert.Equal(t, []provider.ExternalMetricInfo{{Metric: "externalmetrics"}}, kedaProvider.ListAllExternalMetrics(), "there is no metric")
+
+	kedaProvider.metricsIndex.Update(newIndexedScaledObject("default", "worker", nil, "s1-cron-utc", "s0-rabbitmq-jobs"))
+	assert.Equal(t, []provider.ExternalMetricInfo{{Metric: "s0-rabbitmq-jobs"}, {Metric: "s1-cron-utc"}}, kedaProvider.ListAllExternalMetrics())
+}
+
+func TestGetExternalMetricNotFound(t *testing.T) {
+	kedaProvider := &KedaProvider{metricsIndex: newSyncedMetricsIndex()}
+	kedaProvider.metricsIndex.Update(newIndexedScaledObject("default", "worker", nil, "s0-rabbitmq-jobs"))
+
+	selector := labels.SelectorFromSet(labels.Set{kedav1alpha1.ScaledObjectOwnerAnnotation: "worker"})
+	_, err := kedaProvider.GetExternalMetric(context.Background(), "default", selector, provider.ExternalMetricInfo{Metric: "s0-kafka-events"})
+	assert.True(t, apierrors.IsNotFound(err), "the metric is unknown")
+
+	selector = labels.SelectorFromSet(labels.Set{kedav1alpha1.ScaledObjectOwnerAnnotation: "api"})
+	_, err = kedaProvider.GetExternalMetric(context.Background(), "default", selector, provider.ExternalMetricInfo{Metric: "s0-rabbitmq-jobs"})
+	assert.True(t, apierrors.IsNotFound(err), "the ScaledObject of the selector doesn't have the metric")
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
@@ -31,32 +32,48 @@ import (
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 )
 
-// KedaProvider implements External Metrics Provider
+// KedaProvider implements External Metrics Provider and Custom Metrics Provider
 type KedaProvider struct {
 	defaults.DefaultExternalMetricsProvider
+	defaults.DefaultCustomMetricsProvider
 
 	client client.Client
 	ctx    context.Context
 
 	grpcClient metricsservice.GrpcClient
+
+	metricsIndex *MetricsIndex
+
+	// watchedMetrics are the metrics pushed by the Metrics Service, nil if they aren't watched
+	watchedMetrics *watchedMetrics
 }
 
 var (
 	logger logr.Logger
 
+	externalMetricsGroupResource = schema.GroupResource{Group: external_metrics.GroupName}
+
 	grpcClientConnected bool
 )
 
-// NewProvider returns an instance of KedaProvider
-func NewProvider(ctx context.Context, adapterLogger logr.Logger, client client.Client, grpcClient metricsservice.GrpcClient) provider.ExternalMetricsProvider {
+// NewProvider returns an instance of KedaProvider, the metrics index is used to list the metrics
+// and to reject the requests for unknown metrics without querying the Metrics Service.
+// If watchMetrics is set, the metrics pushed by the Metrics Service are served until they are stale.
+func NewProvider(ctx context.Context, adapterLogger logr.Logger, client client.Client, grpcClient metricsservice.GrpcClient, metricsIndex *MetricsIndex, watchMetrics bool) provider.MetricsProvider {
 	provider := &KedaProvider{
-		client:     client,
-		ctx:        ctx,
-		grpcClient: grpcClient,
+		client:       client,
+		ctx:          ctx,
+		grpcClient:   grpcClient,
+		metricsIndex: metricsIndex,
 	}
 	logger = adapterLogger.WithName("provider")
 	logger.Info("starting")
 
+	if watchMetrics {
+		provider.watchedMetrics = newWatchedMetrics()
+		go provider.watchMetrics(ctx)
+	}
+
 	go func() {
 		if !grpcClient.WaitForConnectionReady(ctx, logger) {
 			grpcClientConnected = false
@@ -85,17 +102,6 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return nil, err
 	}
 
-	// Get Metrics from Metrics Service gRPC Server
-	if !p.grpcClient.WaitForConnectionReady(ctx, logger) {
-		grpcClientConnected = false
-		logger.Error(fmt.Errorf("timeout while waiting to establish gRPC connection to KEDA Metrics Service server"), "timeout", "server", p.grpcClient.GetServerURL())
-		return nil, err
-	}
-	if !grpcClientConnected {
-		grpcClientConnected = true
-		logger.Info("Connection to KEDA Metrics Service gRPC server has been successfully established", "server", p.grpcClient.GetServerURL())
-	}
-
 	// selector is in form: `scaledobject.keda.sh/name: scaledobject-name`
 	scaledObjectName := selector.Get(kedav1alpha1.ScaledObjectOwnerAnnotation)
 	if scaledObjectName == "" {
@@ -105,8 +111,68 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return &external_metrics.ExternalMetricValueList{}, err
 	}
 
-	metrics, err := p.grpcClient.GetMetrics(ctx, scaledObjectName, namespace, info.Metric)
+	// reject unknown metrics before waiting for the Metrics Service, only once the index contains all the ScaledObjects
+	if p.metricsIndex != nil && p.metricsIndex.HasSynced() {
+		if !p.metricsIndex.HasMetric(namespace, info.Metric) {
+			logger.V(1).Info("Metric not found", "namespace", namespace, "metric name", info.Metric)
+			return nil, provider.NewMetricNotFoundError(externalMetricsGroupResource, info.Metric)
+		}
+		if !p.metricsIndex.HasScaledObjectMetric(namespace, scaledObjectName, info.Metric) {
+			logger.V(1).Info("Metric not found for the ScaledObject", "namespace", namespace, "metric name", info.Metric, "scaledObjectName", scaledObjectName)
+			return nil, provider.NewMetricNotFoundForSelectorError(externalMetricsGroupResource, info.Metric, scaledObjectName, metricSelector)
+		}
+	}
+
+	return p.getMetrics(ctx, scaledObjectName, namespace, info.Metric)
+}
+
+// getMetrics returns the metric of the ScaledObject, the watched metric if it isn't stale
+// otherwise the metric is requested from the Metrics Service gRPC server
+func (p *KedaProvider) getMetrics(ctx context.Context, scaledObjectName, namespace, metricName string) (*external_metrics.ExternalMetricValueList, error) {
+	if metrics, ok := p.watchedMetrics.get(namespace, scaledObjectName, metricName); ok {
+		logger.V(1).WithValues("scaledObjectName", scaledObjectName, "scaledObjectNamespace", namespace, "metrics", metrics).Info("Serving watched metrics")
+		return metrics, nil
+	}
+
+	// Get Metrics from Metrics Service gRPC Server
+	if err := p.waitForGrpcConnection(ctx); err != nil {
+		return nil, err
+	}
+
+	metrics, err := p.grpcClient.GetMetrics(ctx, scaledObjectName, namespace, metricName)
 	logger.V(1).WithValues("scaledObjectName", scaledObjectName, "scaledObjectNamespace", namespace, "metrics", metrics).Info("Receiving metrics")
 
 	return metrics, err
 }
+
+// ListAllExternalMetrics returns the metrics of the ScaledObjects from the metrics index, the
+// placeholder metric of the default provider is returned until the index is synced or if there is no metric
+func (p *KedaProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
+	if p.metricsIndex == nil || !p.metricsIndex.HasSynced() {
+		return p.DefaultExternalMetricsProvider.ListAllExternalMetrics()
+	}
+	metricNames := p.metricsIndex.MetricNames()
+	if len(metricNames) == 0 {
+		return p.DefaultExternalMetricsProvider.ListAllExternalMetrics()
+	}
+	metrics := make([]provider.ExternalMetricInfo, 0, len(metricNames))
+	for _, metricName := range metricNames {
+		metrics = append(metrics, provider.ExternalMetricInfo{Metric: metricName})
+	}
+	return metrics
+}
+
+// waitForGrpcConnection waits for the connection to the Metrics Service gRPC server to be ready
+func (p *KedaProvider) waitForGrpcConnection(ctx context.Context) error {
+	if !p.grpcClient.WaitForConnectionReady(ctx, logger) {
+		grpcClientConnected = false
+		err := fmt.Errorf("timeout while waiting to establish gRPC connection to KEDA Metrics Service server")
+		logger.Error(err, "timeout", "server", p.grpcClient.GetServerURL())
+		return err
+	}
+	if !grpcClientConnected {
+		grpcClientConnected = true
+		logger.Info("Connection to KEDA Metrics Service gRPC server has been successfully established", "server", p.grpcClient.GetServerURL())
+	}
+	return nil
+}
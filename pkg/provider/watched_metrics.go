@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/metricsservice"
+)
+
+const (
+	// watchMetricsRetryInterval is the interval between the attempts to watch the metrics
+	watchMetricsRetryInterval = 5 * time.Second
+	// watchedMetricsPruneInterval is the interval the expired watched metrics are removed at
+	watchedMetricsPruneInterval = time.Minute
+)
+
+// watchedMetrics holds the metric values pushed by the WatchMetrics stream of the Metrics Service,
+// each value is served until its TTL expires
+type watchedMetrics struct {
+	lock    sync.RWMutex
+	entries map[watchedMetricKey]watchedMetric
+	now     func() time.Time
+}
+
+type watchedMetricKey struct {
+	namespace        string
+	scaledObjectName string
+	metricName       string
+}
+
+type watchedMetric struct {
+	metrics    *external_metrics.ExternalMetricValueList
+	expiration time.Time
+}
+
+func newWatchedMetrics() *watchedMetrics {
+	return &watchedMetrics{
+		entries: map[watchedMetricKey]watchedMetric{},
+		now:     time.Now,
+	}
+}
+
+// update stores the metric values, the values which couldn't be read by the operator
+// are removed so that they are queried again
+func (w *watchedMetrics) update(items []metricsservice.ScaledObjectMetrics) {
+	now := w.now()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, item := range items {
+		key := watchedMetricKey{namespace: item.ScaledObjectNamespace, scaledObjectName: item.ScaledObjectName, metricName: item.MetricName}
+		if item.Err != nil || item.TTL <= 0 {
+			delete(w.entries, key)
+			continue
+		}
+		w.entries[key] = watchedMetric{metrics: item.Metrics, expiration: now.Add(item.TTL)}
+	}
+}
+
+// get returns the metric values of the ScaledObject if they didn't expire yet
+func (w *watchedMetrics) get(namespace, scaledObjectName, metricName string) (*external_metrics.ExternalMetricValueList, bool) {
+	if w == nil {
+		return nil, false
+	}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	entry, ok := w.entries[watchedMetricKey{namespace: namespace, scaledObjectName: scaledObjectName, metricName: metricName}]
+	if !ok || !w.now().Before(entry.expiration) {
+		return nil, false
+	}
+	return entry.metrics.DeepCopy(), true
+}
+
+// prune removes the expired metric values, eg. the ones of deleted ScaledObjects
+func (w *watchedMetrics) prune() {
+	now := w.now()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for key, entry := range w.entries {
+		if !now.Before(entry.expiration) {
+			delete(w.entries, key)
+		}
+	}
+}
+
+// watchMetrics keeps the watched metrics up to date with the WatchMetrics stream of the Metrics Service,
+// the stream is opened again when it ends until the context is done
+func (p *KedaProvider) watchMetrics(ctx context.Context) {
+	go wait.UntilWithContext(ctx, func(context.Context) {
+		p.watchedMetrics.prune()
+	}, watchedMetricsPruneInterval)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.waitForGrpcConnection(ctx); err != nil {
+			return
+		}
+		logger.Info("Watching metrics of the KEDA Metrics Service gRPC server", "server", p.grpcClient.GetServerURL())
+		err := p.grpcClient.WatchMetrics(ctx, nil, p.watchedMetrics.update)
+		if ctx.Err() == nil {
+			logger.Error(err, "watch of the metrics ended, metrics are requested from the KEDA Metrics Service gRPC server until it is restarted", "server", p.grpcClient.GetServerURL())
+		}
+	}, watchMetricsRetryInterval)
+}
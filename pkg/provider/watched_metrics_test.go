@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/metricsservice"
+)
+
+func TestWatchedMetrics(t *testing.T) {
+	now := time.Unix(0, 0)
+	watched := newWatchedMetrics()
+	watched.now = func() time.Time { return now }
+
+	metrics := &external_metrics.ExternalMetricValueList{Items: []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queue", Value: *resource.NewQuantity(5, resource.DecimalSI)},
+	}}
+	watched.update([]metricsservice.ScaledObjectMetrics{
+		{ScaledObjectName: "worker", ScaledObjectNamespace: "default", MetricName: "s0-queue", Metrics: metrics, TTL: time.Minute},
+		{ScaledObjectName: "api", ScaledObjectNamespace: "default", MetricName: "s0-queue", Metrics: metrics},
+	})
+
+	got, ok := watched.get("default", "worker", "s0-queue")
+	assert.True(t, ok)
+	assert.Equal(t, metrics, got)
+	_, ok = watched.get("default", "api", "s0-queue")
+	assert.False(t, ok, "metrics without TTL aren't kept")
+	_, ok = watched.get("other", "worker", "s0-queue")
+	assert.False(t, ok)
+
+	// a failure of the operator to read the metric removes it, so that it's requested again
+	watched.update([]metricsservice.ScaledObjectMetrics{
+		{ScaledObjectName: "worker", ScaledObjectNamespace: "default", MetricName: "s0-queue", Err: errors.New("connection refused"), TTL: time.Minute},
+	})
+	_, ok = watched.get("default", "worker", "s0-queue")
+	assert.False(t, ok)
+
+	watched.update([]metricsservice.ScaledObjectMetrics{
+		{ScaledObjectName: "worker", ScaledObjectNamespace: "default", MetricName: "s0-queue", Metrics: metrics, TTL: time.Minute},
+	})
+	now = now.Add(time.Minute)
+	_, ok = watched.get("default", "worker", "s0-queue")
+	assert.False(t, ok, "the metric expired")
+	assert.Len(t, watched.entries, 1)
+	watched.prune()
+	assert.Empty(t, watched.entries)
+
+	var notWatched *watchedMetrics
+	_, ok = notWatched.get("default", "worker", "s0-queue")
+	assert.False(t, ok)
+}
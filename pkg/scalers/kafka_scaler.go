@@ -24,7 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
+	"sort"
 	"strings"
 	"sync"
 
@@ -51,48 +51,68 @@ const (
 )
 
 type kafkaMetadata struct {
-	bootstrapServers       []string
-	group                  string
-	topic                  string
-	partitionLimitation    []int32
-	lagThreshold           int64
-	activationLagThreshold int64
-	offsetResetPolicy      offsetResetPolicy
-	allowIdleConsumers     bool
-	excludePersistentLag   bool
-	version                sarama.KafkaVersion
+	BootstrapServers       []string `keda:"name=bootstrapServers, order=resolvedEnv;triggerMetadata"`
+	Group                  string   `keda:"name=consumerGroup, order=resolvedEnv;triggerMetadata"`
+	Topic                  string   `keda:"name=topic, order=resolvedEnv;triggerMetadata, optional"`
+	PartitionLimitation    string   `keda:"name=partitionLimitation, order=triggerMetadata, optional"`
+	limitedPartitions      []int32
+	LagThreshold           int64             `keda:"name=lagThreshold, order=triggerMetadata, default=10, range=1:"`
+	ActivationLagThreshold int64             `keda:"name=activationLagThreshold, order=triggerMetadata, default=0, range=0:"`
+	OffsetResetPolicy      offsetResetPolicy `keda:"name=offsetResetPolicy, order=triggerMetadata, default=latest, enum=earliest;latest"`
+	AllowIdleConsumers     bool              `keda:"name=allowIdleConsumers, order=triggerMetadata, optional"`
+	ExcludePersistentLag   bool              `keda:"name=excludePersistentLag, order=triggerMetadata, optional"`
+	Version                string            `keda:"name=version, order=triggerMetadata, default=1.0.0"`
+	saramaVersion          sarama.KafkaVersion
 
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
-	scaleToZeroOnInvalidOffset bool
-	limitToPartitionsWithLag   bool
+	ScaleToZeroOnInvalidOffset bool `keda:"name=scaleToZeroOnInvalidOffset, order=triggerMetadata, optional"`
+	LimitToPartitionsWithLag   bool `keda:"name=limitToPartitionsWithLag, order=triggerMetadata, optional"`
 
-	// SASL
-	saslType kafkaSaslType
-	username string
-	password string
+	// SASL, the mode can be set in the ScaledObject or in the TriggerAuthentication but not in both
+	SASL          kafkaSaslType `keda:"name=sasl, order=triggerMetadata, enum=plaintext;scram_sha256;scram_sha512;oauthbearer;gssapi, optional"`
+	SASLAuthParam kafkaSaslType `keda:"name=sasl, order=authParams, enum=plaintext;scram_sha256;scram_sha512;oauthbearer;gssapi, optional"`
+	saslType      kafkaSaslType
+	Username      string `keda:"name=username, order=authParams, optional"`
+	Password      string `keda:"name=password, order=authParams, optional"`
 
 	// GSSAPI
+	Keytab             string `keda:"name=keytab, order=authParams, optional"`
+	Realm              string `keda:"name=realm, order=authParams, optional"`
+	KerberosConfig     string `keda:"name=kerberosConfig, order=authParams, optional"`
 	keytabPath         string
-	realm              string
 	kerberosConfigPath string
 
 	// OAUTHBEARER
+	Scopes                string `keda:"name=scopes, order=authParams, optional"`
 	scopes                []string
-	oauthTokenEndpointURI string
-	oauthExtensions       map[string]string
-
-	// TLS
-	enableTLS   bool
-	cert        string
-	key         string
-	keyPassword string
-	ca          string
-	unsafeSsl   bool
+	OAuthTokenEndpointURI string            `keda:"name=oauthTokenEndpointUri, order=authParams, optional"`
+	OAuthExtensions       map[string]string `keda:"name=oauthExtensions, order=authParams, optional"`
+
+	// TLS, it can be enabled in the ScaledObject or in the TriggerAuthentication but not in both
+	TLS          string `keda:"name=tls, order=triggerMetadata, enum=enable;disable, optional"`
+	TLSAuthParam string `keda:"name=tls, order=authParams, enum=enable;disable, optional"`
+	enableTLS    bool
+	Cert         string `keda:"name=cert, order=authParams, optional"`
+	Key          string `keda:"name=key, order=authParams, optional"`
+	KeyPassword  string `keda:"name=keyPassword, order=authParams, optional"`
+	CA           string `keda:"name=ca, order=authParams, optional"`
+	UnsafeSsl    bool   `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
 
 	triggerIndex int
 }
 
+// Validate checks the relationships between the kafka parameters
+func (m *kafkaMetadata) Validate() error {
+	if m.AllowIdleConsumers && m.LimitToPartitionsWithLag {
+		return fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
+	}
+	if len(m.Topic) == 0 && m.LimitToPartitionsWithLag {
+		return fmt.Errorf("topic must be specified when using limitToPartitionsWithLag")
+	}
+	return nil
+}
+
 type offsetResetPolicy string
 
 const (
@@ -153,137 +173,83 @@ func NewKafkaScaler(config *ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
+func parseKafkaAuthParams(meta *kafkaMetadata) error {
 	meta.saslType = KafkaSASLTypeNone
-	var saslAuthType string
-	switch {
-	case config.TriggerMetadata["sasl"] != "":
-		saslAuthType = config.TriggerMetadata["sasl"]
-	default:
-		saslAuthType = ""
-	}
-	if val, ok := config.AuthParams["sasl"]; ok {
-		if saslAuthType != "" {
+	mode := meta.SASL
+	if meta.SASLAuthParam != "" {
+		if mode != "" {
 			return errors.New("unable to set `sasl` in both ScaledObject and TriggerAuthentication together")
 		}
-		saslAuthType = val
+		mode = meta.SASLAuthParam
 	}
 
-	if saslAuthType != "" {
-		saslAuthType = strings.TrimSpace(saslAuthType)
-		mode := kafkaSaslType(saslAuthType)
-
-		switch {
-		case mode == KafkaSASLTypePlaintext || mode == KafkaSASLTypeSCRAMSHA256 || mode == KafkaSASLTypeSCRAMSHA512 || mode == KafkaSASLTypeOAuthbearer:
-			err := parseSaslParams(config, meta, mode)
-			if err != nil {
-				return err
-			}
-		case mode == KafkaSASLTypeGSSAPI:
-			err := parseKerberosParams(config, meta, mode)
-			if err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("err SASL mode %s given", mode)
+	// the mode is one of the supported SASL types, checked when decoded
+	mode = kafkaSaslType(strings.TrimSpace(string(mode)))
+	if mode == KafkaSASLTypeGSSAPI {
+		if err := parseKerberosParams(meta, mode); err != nil {
+			return err
 		}
-	}
-
-	meta.enableTLS = false
-	enableTLS := false
-	if val, ok := config.TriggerMetadata["tls"]; ok {
-		switch val {
-		case stringEnable:
-			enableTLS = true
-		case stringDisable:
-			enableTLS = false
-		default:
-			return fmt.Errorf("error incorrect TLS value given, got %s", val)
+	} else if mode != "" {
+		if err := parseSaslParams(meta, mode); err != nil {
+			return err
 		}
 	}
 
-	if val, ok := config.AuthParams["tls"]; ok {
-		val = strings.TrimSpace(val)
+	enableTLS := strings.TrimSpace(meta.TLS) == stringEnable
+	if meta.TLSAuthParam != "" {
 		if enableTLS {
 			return errors.New("unable to set `tls` in both ScaledObject and TriggerAuthentication together")
 		}
-		switch val {
-		case stringEnable:
-			enableTLS = true
-		case stringDisable:
-			enableTLS = false
-		default:
-			return fmt.Errorf("error incorrect TLS value given, got %s", val)
-		}
+		enableTLS = strings.TrimSpace(meta.TLSAuthParam) == stringEnable
 	}
 
+	meta.enableTLS = false
 	if enableTLS {
-		return parseTLS(config, meta)
+		return parseTLS(meta)
 	}
 
 	return nil
 }
 
-func parseTLS(config *ScalerConfig, meta *kafkaMetadata) error {
-	certGiven := config.AuthParams["cert"] != ""
-	keyGiven := config.AuthParams["key"] != ""
-	if certGiven && !keyGiven {
+func parseTLS(meta *kafkaMetadata) error {
+	if meta.Cert != "" && meta.Key == "" {
 		return errors.New("key must be provided with cert")
 	}
-	if keyGiven && !certGiven {
+	if meta.Key != "" && meta.Cert == "" {
 		return errors.New("cert must be provided with key")
 	}
-	meta.ca = config.AuthParams["ca"]
-	meta.cert = config.AuthParams["cert"]
-	meta.key = config.AuthParams["key"]
-	meta.unsafeSsl = defaultUnsafeSsl
-
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		unsafeSsl, err := strconv.ParseBool(val)
-		if err != nil {
-			return fmt.Errorf("error parsing unsafeSsl: %w", err)
-		}
-		meta.unsafeSsl = unsafeSsl
-	}
-
-	if value, found := config.AuthParams["keyPassword"]; found {
-		meta.keyPassword = value
-	} else {
-		meta.keyPassword = ""
-	}
 	meta.enableTLS = true
 	return nil
 }
 
-func parseKerberosParams(config *ScalerConfig, meta *kafkaMetadata, mode kafkaSaslType) error {
-	if config.AuthParams["username"] == "" {
-		return errors.New("no username given")
+func parseKerberosParams(meta *kafkaMetadata, mode kafkaSaslType) error {
+	if meta.Username == "" {
+		return fmt.Errorf("%w: no username given", ErrScalerConfigMissingField)
 	}
-	meta.username = strings.TrimSpace(config.AuthParams["username"])
+	meta.Username = strings.TrimSpace(meta.Username)
 
-	if (config.AuthParams["password"] == "" && config.AuthParams["keytab"] == "") ||
-		(config.AuthParams["password"] != "" && config.AuthParams["keytab"] != "") {
+	if (meta.Password == "" && meta.Keytab == "") || (meta.Password != "" && meta.Keytab != "") {
 		return errors.New("exactly one of 'password' or 'keytab' must be provided for GSSAPI authentication")
 	}
-	if config.AuthParams["password"] != "" {
-		meta.password = strings.TrimSpace(config.AuthParams["password"])
+	if meta.Password != "" {
+		meta.Password = strings.TrimSpace(meta.Password)
 	} else {
-		path, err := saveToFile(config.AuthParams["keytab"])
+		path, err := saveToFile(meta.Keytab)
 		if err != nil {
 			return fmt.Errorf("error saving keytab to file: %w", err)
 		}
 		meta.keytabPath = path
 	}
 
-	if config.AuthParams["realm"] == "" {
-		return errors.New("no realm given")
+	if meta.Realm == "" {
+		return fmt.Errorf("%w: no realm given", ErrScalerConfigMissingField)
 	}
-	meta.realm = strings.TrimSpace(config.AuthParams["realm"])
+	meta.Realm = strings.TrimSpace(meta.Realm)
 
-	if config.AuthParams["kerberosConfig"] == "" {
-		return errors.New("no Kerberos configuration file (kerberosConfig) given")
+	if meta.KerberosConfig == "" {
+		return fmt.Errorf("%w: no Kerberos configuration file (kerberosConfig) given", ErrScalerConfigMissingField)
 	}
-	path, err := saveToFile(config.AuthParams["kerberosConfig"])
+	path, err := saveToFile(meta.KerberosConfig)
 	if err != nil {
 		return fmt.Errorf("error saving kerberosConfig to file: %w", err)
 	}
@@ -293,37 +259,25 @@ func parseKerberosParams(config *ScalerConfig, meta *kafkaMetadata, mode kafkaSa
 	return nil
 }
 
-func parseSaslParams(config *ScalerConfig, meta *kafkaMetadata, mode kafkaSaslType) error {
-	if config.AuthParams["username"] == "" {
-		return errors.New("no username given")
+func parseSaslParams(meta *kafkaMetadata, mode kafkaSaslType) error {
+	if meta.Username == "" {
+		return fmt.Errorf("%w: no username given", ErrScalerConfigMissingField)
 	}
-	meta.username = strings.TrimSpace(config.AuthParams["username"])
+	meta.Username = strings.TrimSpace(meta.Username)
 
-	if config.AuthParams["password"] == "" {
-		return errors.New("no password given")
+	if meta.Password == "" {
+		return fmt.Errorf("%w: no password given", ErrScalerConfigMissingField)
 	}
-	meta.password = strings.TrimSpace(config.AuthParams["password"])
+	meta.Password = strings.TrimSpace(meta.Password)
 	meta.saslType = mode
 
 	if mode == KafkaSASLTypeOAuthbearer {
-		meta.scopes = strings.Split(config.AuthParams["scopes"], ",")
+		meta.scopes = strings.Split(meta.Scopes, ",")
 
-		if config.AuthParams["oauthTokenEndpointUri"] == "" {
-			return errors.New("no oauth token endpoint uri given")
-		}
-		meta.oauthTokenEndpointURI = strings.TrimSpace(config.AuthParams["oauthTokenEndpointUri"])
-
-		meta.oauthExtensions = make(map[string]string)
-		oauthExtensionsRaw := config.AuthParams["oauthExtensions"]
-		if oauthExtensionsRaw != "" {
-			for _, extension := range strings.Split(oauthExtensionsRaw, ",") {
-				splittedExtension := strings.Split(extension, "=")
-				if len(splittedExtension) != 2 {
-					return errors.New("invalid OAuthBearer extension, must be of format key=value")
-				}
-				meta.oauthExtensions[splittedExtension[0]] = splittedExtension[1]
-			}
+		if meta.OAuthTokenEndpointURI == "" {
+			return fmt.Errorf("%w: no oauth token endpoint uri given", ErrScalerConfigMissingField)
 		}
+		meta.OAuthTokenEndpointURI = strings.TrimSpace(meta.OAuthTokenEndpointURI)
 	}
 	return nil
 }
@@ -357,160 +311,55 @@ func saveToFile(content string) (string, error) {
 
 func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata, error) {
 	meta := kafkaMetadata{}
-	switch {
-	case config.TriggerMetadata["bootstrapServersFromEnv"] != "":
-		meta.bootstrapServers = strings.Split(config.ResolvedEnv[config.TriggerMetadata["bootstrapServersFromEnv"]], ",")
-	case config.TriggerMetadata["bootstrapServers"] != "":
-		meta.bootstrapServers = strings.Split(config.TriggerMetadata["bootstrapServers"], ",")
-	default:
-		return meta, errors.New("no bootstrapServers given")
-	}
-
-	switch {
-	case config.TriggerMetadata["consumerGroupFromEnv"] != "":
-		meta.group = config.ResolvedEnv[config.TriggerMetadata["consumerGroupFromEnv"]]
-	case config.TriggerMetadata["consumerGroup"] != "":
-		meta.group = config.TriggerMetadata["consumerGroup"]
-	default:
-		return meta, errors.New("no consumer group given")
-	}
-
-	switch {
-	case config.TriggerMetadata["topicFromEnv"] != "":
-		meta.topic = config.ResolvedEnv[config.TriggerMetadata["topicFromEnv"]]
-	case config.TriggerMetadata["topic"] != "":
-		meta.topic = config.TriggerMetadata["topic"]
-	default:
-		meta.topic = ""
+	if err := config.TypedConfig(&meta); err != nil {
+		return meta, err
+	}
+
+	if meta.Topic == "" {
 		logger.V(1).Info(fmt.Sprintf("consumer group %q has no topic specified, "+
-			"will use all topics subscribed by the consumer group for scaling", meta.group))
+			"will use all topics subscribed by the consumer group for scaling", meta.Group))
 	}
 
-	meta.partitionLimitation = nil
-	partitionLimitationMetadata := strings.TrimSpace(config.TriggerMetadata["partitionLimitation"])
-	if partitionLimitationMetadata != "" {
-		if meta.topic == "" {
+	meta.limitedPartitions = nil
+	if meta.PartitionLimitation != "" {
+		if meta.Topic == "" {
 			logger.V(1).Info("no specific topic set, ignoring partitionLimitation setting")
 		} else {
-			pattern := config.TriggerMetadata["partitionLimitation"]
-			parsed, err := kedautil.ParseInt32List(pattern)
+			parsed, err := kedautil.ParseInt32List(meta.PartitionLimitation)
 			if err != nil {
-				return meta, fmt.Errorf("error parsing in partitionLimitation '%s': %w", pattern, err)
+				return meta, fmt.Errorf("error parsing in partitionLimitation '%s': %w", meta.PartitionLimitation, err)
 			}
-			meta.partitionLimitation = parsed
-			logger.V(0).Info(fmt.Sprintf("partition limit active '%s'", pattern))
-		}
-	}
-
-	meta.offsetResetPolicy = defaultOffsetResetPolicy
-
-	if config.TriggerMetadata["offsetResetPolicy"] != "" {
-		policy := offsetResetPolicy(config.TriggerMetadata["offsetResetPolicy"])
-		if policy != earliest && policy != latest {
-			return meta, fmt.Errorf("err offsetResetPolicy policy %q given", policy)
+			meta.limitedPartitions = parsed
+			logger.V(0).Info(fmt.Sprintf("partition limit active '%s'", meta.PartitionLimitation))
 		}
-		meta.offsetResetPolicy = policy
 	}
 
-	meta.lagThreshold = defaultKafkaLagThreshold
-
-	if val, ok := config.TriggerMetadata[lagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %q: %w", lagThresholdMetricName, err)
-		}
-		if t <= 0 {
-			return meta, fmt.Errorf("%q must be positive number", lagThresholdMetricName)
-		}
-		meta.lagThreshold = t
-	}
-
-	meta.activationLagThreshold = defaultKafkaActivationLagThreshold
-
-	if val, ok := config.TriggerMetadata[activationLagThresholdMetricName]; ok {
-		t, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing %q: %w", activationLagThresholdMetricName, err)
-		}
-		if t < 0 {
-			return meta, fmt.Errorf("%q must be positive number", activationLagThresholdMetricName)
-		}
-		meta.activationLagThreshold = t
-	}
-
-	if err := parseKafkaAuthParams(config, &meta); err != nil {
+	if err := parseKafkaAuthParams(&meta); err != nil {
 		return meta, err
 	}
 
-	meta.allowIdleConsumers = false
-	if val, ok := config.TriggerMetadata["allowIdleConsumers"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing allowIdleConsumers: %w", err)
-		}
-		meta.allowIdleConsumers = t
-	}
-
-	meta.excludePersistentLag = false
-	if val, ok := config.TriggerMetadata["excludePersistentLag"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing excludePersistentLag: %w", err)
-		}
-		meta.excludePersistentLag = t
-	}
-
-	meta.scaleToZeroOnInvalidOffset = false
-	if val, ok := config.TriggerMetadata["scaleToZeroOnInvalidOffset"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing scaleToZeroOnInvalidOffset: %w", err)
-		}
-		meta.scaleToZeroOnInvalidOffset = t
-	}
-
-	meta.limitToPartitionsWithLag = false
-	if val, ok := config.TriggerMetadata["limitToPartitionsWithLag"]; ok {
-		t, err := strconv.ParseBool(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing limitToPartitionsWithLag: %w", err)
-		}
-		meta.limitToPartitionsWithLag = t
-
-		if meta.allowIdleConsumers && meta.limitToPartitionsWithLag {
-			return meta, fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
-		}
-		if len(meta.topic) == 0 && meta.limitToPartitionsWithLag {
-			return meta, fmt.Errorf("topic must be specified when using limitToPartitionsWithLag")
-		}
-	}
-
-	meta.version = sarama.V1_0_0_0
-	if val, ok := config.TriggerMetadata["version"]; ok {
-		val = strings.TrimSpace(val)
-		version, err := sarama.ParseKafkaVersion(val)
-		if err != nil {
-			return meta, fmt.Errorf("error parsing kafka version: %w", err)
-		}
-		meta.version = version
+	version, err := sarama.ParseKafkaVersion(strings.TrimSpace(meta.Version))
+	if err != nil {
+		return meta, fmt.Errorf("error parsing kafka version: %w", err)
 	}
+	meta.saramaVersion = version
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
 
 func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
 	config := sarama.NewConfig()
-	config.Version = metadata.version
+	config.Version = metadata.saramaVersion
 
 	if metadata.saslType != KafkaSASLTypeNone && metadata.saslType != KafkaSASLTypeGSSAPI {
 		config.Net.SASL.Enable = true
-		config.Net.SASL.User = metadata.username
-		config.Net.SASL.Password = metadata.password
+		config.Net.SASL.User = metadata.Username
+		config.Net.SASL.Password = metadata.Password
 	}
 
 	if metadata.enableTLS {
 		config.Net.TLS.Enable = true
-		tlsConfig, err := kedautil.NewTLSConfigWithPassword(metadata.cert, metadata.key, metadata.keyPassword, metadata.ca, metadata.unsafeSsl)
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(metadata.Cert, metadata.Key, metadata.KeyPassword, metadata.CA, metadata.UnsafeSsl)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -533,26 +382,26 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 
 	if metadata.saslType == KafkaSASLTypeOAuthbearer {
 		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
-		config.Net.SASL.TokenProvider = OAuthBearerTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes, metadata.oauthExtensions)
+		config.Net.SASL.TokenProvider = OAuthBearerTokenProvider(metadata.Username, metadata.Password, metadata.OAuthTokenEndpointURI, metadata.scopes, metadata.OAuthExtensions)
 	}
 
 	if metadata.saslType == KafkaSASLTypeGSSAPI {
 		config.Net.SASL.Enable = true
 		config.Net.SASL.Mechanism = sarama.SASLTypeGSSAPI
 		config.Net.SASL.GSSAPI.ServiceName = "kafka"
-		config.Net.SASL.GSSAPI.Username = metadata.username
-		config.Net.SASL.GSSAPI.Realm = metadata.realm
+		config.Net.SASL.GSSAPI.Username = metadata.Username
+		config.Net.SASL.GSSAPI.Realm = metadata.Realm
 		config.Net.SASL.GSSAPI.KerberosConfigPath = metadata.kerberosConfigPath
 		if metadata.keytabPath != "" {
 			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_KEYTAB_AUTH
 			config.Net.SASL.GSSAPI.KeyTabPath = metadata.keytabPath
 		} else {
 			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_USER_AUTH
-			config.Net.SASL.GSSAPI.Password = metadata.password
+			config.Net.SASL.GSSAPI.Password = metadata.Password
 		}
 	}
 
-	client, err := sarama.NewClient(metadata.bootstrapServers, config)
+	client, err := sarama.NewClient(metadata.BootstrapServers, config)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating kafka client: %w", err)
 	}
@@ -572,8 +421,8 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 	var topicsToDescribe = make([]string, 0)
 
 	// when no topic is specified, query to cg group to fetch all subscribed topics
-	if s.metadata.topic == "" {
-		listCGOffsetResponse, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, nil)
+	if s.metadata.Topic == "" {
+		listCGOffsetResponse, err := s.admin.ListConsumerGroupOffsets(s.metadata.Group, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error listing cg offset: %w", err)
 		}
@@ -587,7 +436,7 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 			topicsToDescribe = append(topicsToDescribe, topicName)
 		}
 	} else {
-		topicsToDescribe = []string{s.metadata.topic}
+		topicsToDescribe = []string{s.metadata.Topic}
 	}
 
 	topicsMetadata, err := s.admin.DescribeTopics(topicsToDescribe)
@@ -598,7 +447,7 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 		fmt.Sprintf("with topic name %s the list of topic metadata is %v", topicsToDescribe, topicsMetadata),
 	)
 
-	if s.metadata.topic != "" && len(topicsMetadata) != 1 {
+	if s.metadata.Topic != "" && len(topicsMetadata) != 1 {
 		return nil, fmt.Errorf("expected only 1 topic metadata, got %d", len(topicsMetadata))
 	}
 
@@ -625,10 +474,10 @@ func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
 }
 
 func (s *kafkaScaler) isActivePartition(pID int32) bool {
-	if s.metadata.partitionLimitation == nil {
+	if s.metadata.limitedPartitions == nil {
 		return true
 	}
-	for _, _pID := range s.metadata.partitionLimitation {
+	for _, _pID := range s.metadata.limitedPartitions {
 		if pID == _pID {
 			return true
 		}
@@ -637,7 +486,7 @@ func (s *kafkaScaler) isActivePartition(pID int32) bool {
 }
 
 func (s *kafkaScaler) getConsumerOffsets(topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
-	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, topicPartitions)
+	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.Group, topicPartitions)
 	if err != nil {
 		return nil, fmt.Errorf("error listing consumer group offsets: %w", err)
 	}
@@ -665,14 +514,14 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 	}
 
 	consumerOffset := block.Offset
-	if consumerOffset == invalidOffset && s.metadata.offsetResetPolicy == latest {
+	if consumerOffset == invalidOffset && s.metadata.OffsetResetPolicy == latest {
 		retVal := int64(1)
-		if s.metadata.scaleToZeroOnInvalidOffset {
+		if s.metadata.ScaleToZeroOnInvalidOffset {
 			retVal = 0
 		}
 		msg := fmt.Sprintf(
 			"invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet. Returning with lag of %d",
-			topic, s.metadata.group, partitionID, retVal)
+			topic, s.metadata.Group, partitionID, retVal)
 		s.logger.V(1).Info(msg)
 		return retVal, retVal, nil
 	}
@@ -681,12 +530,12 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 		return 0, 0, fmt.Errorf("error finding partition offset for topic %s", topic)
 	}
 	latestOffset := topicPartitionOffsets[topic][partitionID]
-	if consumerOffset == invalidOffset && s.metadata.offsetResetPolicy == earliest {
+	if consumerOffset == invalidOffset && s.metadata.OffsetResetPolicy == earliest {
 		return latestOffset, latestOffset, nil
 	}
 
 	// This code block tries to prevent KEDA Kafka trigger from scaling the scale target based on erroneous events
-	if s.metadata.excludePersistentLag {
+	if s.metadata.ExcludePersistentLag {
 		switch previousOffset, found := s.previousOffsets[topic][partitionID]; {
 		case !found:
 			// No record of previous offset, so store current consumer offset
@@ -732,17 +581,17 @@ func (s *kafkaScaler) Close(context.Context) error {
 
 func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricName string
-	if s.metadata.topic != "" {
-		metricName = fmt.Sprintf("kafka-%s", s.metadata.topic)
+	if s.metadata.Topic != "" {
+		metricName = fmt.Sprintf("kafka-%s", s.metadata.Topic)
 	} else {
-		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.group)
+		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.Group)
 	}
 
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
+		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -785,64 +634,96 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 }
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
-func (s *kafkaScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	totalLag, totalLagWithPersistent, err := s.getTotalLag()
+func (s *kafkaScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	metrics, isActive, _, err := s.GetMetricsActivityAndWorkHints(ctx, metricName)
+	return metrics, isActive, err
+}
+
+// GetMetricsActivityAndWorkHints returns the metric and activity as GetMetricsAndActivity does, the work hints
+// are the partitions with lag, as topic/partition, ordered by decreasing lag
+func (s *kafkaScaler) GetMetricsActivityAndWorkHints(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, []string, error) {
+	totalLag, totalLagWithPersistent, partitionsWithLag, err := s.getTotalLag()
 	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, false, err
+		return []external_metrics.ExternalMetricValue{}, false, nil, err
 	}
 	metric := GenerateMetricInMili(metricName, float64(totalLag))
 
-	return []external_metrics.ExternalMetricValue{metric}, totalLagWithPersistent > s.metadata.activationLagThreshold, nil
+	return []external_metrics.ExternalMetricValue{metric}, totalLagWithPersistent > s.metadata.ActivationLagThreshold, getPartitionWorkHints(partitionsWithLag), nil
+}
+
+// partitionLag is the lag of a partition of a topic
+type partitionLag struct {
+	topic     string
+	partition int32
+	lag       int64
+}
+
+// getPartitionWorkHints returns the partitions as topic/partition ordered by decreasing lag
+func getPartitionWorkHints(partitions []partitionLag) []string {
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].lag != partitions[j].lag {
+			return partitions[i].lag > partitions[j].lag
+		}
+		if partitions[i].topic != partitions[j].topic {
+			return partitions[i].topic < partitions[j].topic
+		}
+		return partitions[i].partition < partitions[j].partition
+	})
+	hints := make([]string, 0, len(partitions))
+	for _, p := range partitions {
+		hints = append(hints, fmt.Sprintf("%s/%d", p.topic, p.partition))
+	}
+	return hints
 }
 
-// getTotalLag returns totalLag, totalLagWithPersistent, error
+// getTotalLag returns totalLag, totalLagWithPersistent, the partitions with lag, error
 // totalLag and totalLagWithPersistent are the summations of lag and lagWithPersistent returned by getLagForPartition function respectively.
 // totalLag maybe less than totalLagWithPersistent when excludePersistentLag is set to `true` due to some partitions deemed as having persistent lag
-func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
+func (s *kafkaScaler) getTotalLag() (int64, int64, []partitionLag, error) {
 	topicPartitions, err := s.getTopicPartitions()
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	consumerOffsets, producerOffsets, err := s.getConsumerAndProducerOffsets(topicPartitions)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	totalLag := int64(0)
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
-	partitionsWithLag := int64(0)
+	var partitionsWithLag []partitionLag
 
 	for topic, partitionsOffsets := range producerOffsets {
 		for partition := range partitionsOffsets {
 			lag, lagWithPersistent, err := s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets)
 			if err != nil {
-				return 0, 0, err
+				return 0, 0, nil, err
 			}
 			totalLag += lag
 			totalLagWithPersistent += lagWithPersistent
 
 			if lag > 0 {
-				partitionsWithLag++
+				partitionsWithLag = append(partitionsWithLag, partitionLag{topic: topic, partition: partition, lag: lag})
 			}
 		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
-	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
+	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.LagThreshold))
 
-	if !s.metadata.allowIdleConsumers || s.metadata.limitToPartitionsWithLag {
+	if !s.metadata.AllowIdleConsumers || s.metadata.LimitToPartitionsWithLag {
 		// don't scale out beyond the number of topicPartitions or partitionsWithLag depending on settings
 		upperBound := totalTopicPartitions
-		if s.metadata.limitToPartitionsWithLag {
-			upperBound = partitionsWithLag
+		if s.metadata.LimitToPartitionsWithLag {
+			upperBound = int64(len(partitionsWithLag))
 		}
 
-		if (totalLag / s.metadata.lagThreshold) > upperBound {
-			totalLag = upperBound * s.metadata.lagThreshold
+		if (totalLag / s.metadata.LagThreshold) > upperBound {
+			totalLag = upperBound * s.metadata.LagThreshold
 		}
 	}
-	return totalLag, totalLagWithPersistent, nil
+	return totalLag, totalLagWithPersistent, partitionsWithLag, nil
 }
 
 type brokerOffsetResult struct {
@@ -333,40 +333,40 @@ func getBrokerTestBase(t *testing.T, meta kafkaMetadata, testData parseKafkaMeta
 	if testData.isError && err == nil {
 		t.Error("Expected error but got success")
 	}
-	if len(meta.bootstrapServers) != testData.numBrokers {
-		t.Errorf("Expected %d bootstrap servers but got %d\n", testData.numBrokers, len(meta.bootstrapServers))
+	if len(meta.BootstrapServers) != testData.numBrokers {
+		t.Errorf("Expected %d bootstrap servers but got %d\n", testData.numBrokers, len(meta.BootstrapServers))
 	}
-	if !reflect.DeepEqual(testData.brokers, meta.bootstrapServers) {
-		t.Errorf("Expected %v but got %v\n", testData.brokers, meta.bootstrapServers)
+	if !reflect.DeepEqual(testData.brokers, meta.BootstrapServers) {
+		t.Errorf("Expected %v but got %v\n", testData.brokers, meta.BootstrapServers)
 	}
-	if meta.group != testData.group {
-		t.Errorf("Expected group %s but got %s\n", testData.group, meta.group)
+	if meta.Group != testData.group {
+		t.Errorf("Expected group %s but got %s\n", testData.group, meta.Group)
 	}
-	if meta.topic != testData.topic {
-		t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.topic)
+	if meta.Topic != testData.topic {
+		t.Errorf("Expected topic %s but got %s\n", testData.topic, meta.Topic)
 	}
-	if !reflect.DeepEqual(testData.partitionLimitation, meta.partitionLimitation) {
-		t.Errorf("Expected %v but got %v\n", testData.partitionLimitation, meta.partitionLimitation)
+	if !reflect.DeepEqual(testData.partitionLimitation, meta.limitedPartitions) {
+		t.Errorf("Expected %v but got %v\n", testData.partitionLimitation, meta.limitedPartitions)
 	}
-	if err == nil && meta.offsetResetPolicy != testData.offsetResetPolicy {
-		t.Errorf("Expected offsetResetPolicy %s but got %s\n", testData.offsetResetPolicy, meta.offsetResetPolicy)
+	if err == nil && meta.OffsetResetPolicy != testData.offsetResetPolicy {
+		t.Errorf("Expected offsetResetPolicy %s but got %s\n", testData.offsetResetPolicy, meta.OffsetResetPolicy)
 	}
-	if err == nil && meta.allowIdleConsumers != testData.allowIdleConsumers {
-		t.Errorf("Expected allowIdleConsumers %t but got %t\n", testData.allowIdleConsumers, meta.allowIdleConsumers)
+	if err == nil && meta.AllowIdleConsumers != testData.allowIdleConsumers {
+		t.Errorf("Expected allowIdleConsumers %t but got %t\n", testData.allowIdleConsumers, meta.AllowIdleConsumers)
 	}
-	if err == nil && meta.excludePersistentLag != testData.excludePersistentLag {
-		t.Errorf("Expected excludePersistentLag %t but got %t\n", testData.excludePersistentLag, meta.excludePersistentLag)
+	if err == nil && meta.ExcludePersistentLag != testData.excludePersistentLag {
+		t.Errorf("Expected excludePersistentLag %t but got %t\n", testData.excludePersistentLag, meta.ExcludePersistentLag)
 	}
-	if err == nil && meta.limitToPartitionsWithLag != testData.limitToPartitionsWithLag {
-		t.Errorf("Expected limitToPartitionsWithLag %t but got %t\n", testData.limitToPartitionsWithLag, meta.limitToPartitionsWithLag)
+	if err == nil && meta.LimitToPartitionsWithLag != testData.limitToPartitionsWithLag {
+		t.Errorf("Expected limitToPartitionsWithLag %t but got %t\n", testData.limitToPartitionsWithLag, meta.LimitToPartitionsWithLag)
 	}
 	expectedLagThreshold, er := parseExpectedLagThreshold(testData.metadata)
 	if er != nil {
 		t.Errorf("Unable to convert test data lagThreshold %s to string", testData.metadata["lagThreshold"])
 	}
 
-	if meta.lagThreshold != expectedLagThreshold && meta.lagThreshold != defaultKafkaLagThreshold {
-		t.Errorf("Expected lagThreshold to be either %v or %v got %v ", meta.lagThreshold, defaultKafkaLagThreshold, expectedLagThreshold)
+	if meta.LagThreshold != expectedLagThreshold && meta.LagThreshold != defaultKafkaLagThreshold {
+		t.Errorf("Expected lagThreshold to be either %v or %v got %v ", meta.LagThreshold, defaultKafkaLagThreshold, expectedLagThreshold)
 	}
 }
 
@@ -384,17 +384,17 @@ func TestKafkaAuthParamsInTriggerAuthentication(t *testing.T) {
 			t.Errorf("Expected enableTLS to be set to %v but got %v\n", testData.enableTLS, meta.enableTLS)
 		}
 		if meta.enableTLS {
-			if meta.ca != testData.authParams["ca"] {
+			if meta.CA != testData.authParams["ca"] {
 				t.Errorf("Expected ca to be set to %v but got %v\n", testData.authParams["ca"], meta.enableTLS)
 			}
-			if meta.cert != testData.authParams["cert"] {
-				t.Errorf("Expected cert to be set to %v but got %v\n", testData.authParams["cert"], meta.cert)
+			if meta.Cert != testData.authParams["cert"] {
+				t.Errorf("Expected cert to be set to %v but got %v\n", testData.authParams["cert"], meta.Cert)
 			}
-			if meta.key != testData.authParams["key"] {
-				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["key"], meta.key)
+			if meta.Key != testData.authParams["key"] {
+				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["key"], meta.Key)
 			}
-			if meta.keyPassword != testData.authParams["keyPassword"] {
-				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["keyPassword"], meta.key)
+			if meta.KeyPassword != testData.authParams["keyPassword"] {
+				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["keyPassword"], meta.Key)
 			}
 		}
 		if meta.saslType == KafkaSASLTypeGSSAPI && !testData.isError {
@@ -429,25 +429,25 @@ func TestKafkaAuthParamsInScaledObject(t *testing.T) {
 				t.Errorf("Test case: %v. Expected tls to be set to %v but got %v\n", id, testData.metadata["tls"], meta.enableTLS)
 			}
 			if meta.enableTLS {
-				if meta.ca != testData.authParams["ca"] {
-					t.Errorf("Test case: %v. Expected ca to be set to %v but got %v\n", id, testData.authParams["ca"], meta.ca)
+				if meta.CA != testData.authParams["ca"] {
+					t.Errorf("Test case: %v. Expected ca to be set to %v but got %v\n", id, testData.authParams["ca"], meta.CA)
 				}
-				if meta.cert != testData.authParams["cert"] {
-					t.Errorf("Test case: %v. Expected cert to be set to %v but got %v\n", id, testData.authParams["cert"], meta.cert)
+				if meta.Cert != testData.authParams["cert"] {
+					t.Errorf("Test case: %v. Expected cert to be set to %v but got %v\n", id, testData.authParams["cert"], meta.Cert)
 				}
-				if meta.key != testData.authParams["key"] {
-					t.Errorf("Test case: %v. Expected key to be set to %v but got %v\n", id, testData.authParams["key"], meta.key)
+				if meta.Key != testData.authParams["key"] {
+					t.Errorf("Test case: %v. Expected key to be set to %v but got %v\n", id, testData.authParams["key"], meta.Key)
 				}
-				if meta.keyPassword != testData.authParams["keyPassword"] {
-					t.Errorf("Test case: %v. Expected key to be set to %v but got %v\n", id, testData.authParams["keyPassword"], meta.keyPassword)
+				if meta.KeyPassword != testData.authParams["keyPassword"] {
+					t.Errorf("Test case: %v. Expected key to be set to %v but got %v\n", id, testData.authParams["keyPassword"], meta.KeyPassword)
 				}
 				if val, ok := testData.authParams["unsafeSsl"]; ok && err == nil {
 					boolVal, err := strconv.ParseBool(val)
 					if err != nil && !testData.isError {
-						t.Errorf("Expect error but got success in test case %s", meta.key)
+						t.Errorf("Expect error but got success in test case %s", meta.Key)
 					}
-					if boolVal != meta.unsafeSsl {
-						t.Errorf("Expected unsafeSsl key to be set to %v but got %v\n", boolVal, meta.unsafeSsl)
+					if boolVal != meta.UnsafeSsl {
+						t.Errorf("Expected unsafeSsl key to be set to %v but got %v\n", boolVal, meta.UnsafeSsl)
 					}
 				}
 			}
@@ -492,8 +492,8 @@ func TestKafkaOAuthbrearerAuthParams(t *testing.T) {
 			}
 		}
 		if err == nil && testData.authParams["oauthExtensions"] != "" {
-			if len(meta.oauthExtensions) != strings.Count(testData.authParams["oauthExtensions"], ",")+1 {
-				t.Errorf("Expected number of extensions to be set to %v but got %v\n", strings.Count(testData.authParams["oauthExtensions"], ",")+1, len(meta.oauthExtensions))
+			if len(meta.OAuthExtensions) != strings.Count(testData.authParams["oauthExtensions"], ",")+1 {
+				t.Errorf("Expected number of extensions to be set to %v but got %v\n", strings.Count(testData.authParams["oauthExtensions"], ",")+1, len(meta.OAuthExtensions))
 			}
 		}
 	}
@@ -548,6 +548,22 @@ func TestGetTopicPartitions(t *testing.T) {
 	}
 }
 
+func TestGetPartitionWorkHints(t *testing.T) {
+	hints := getPartitionWorkHints([]partitionLag{
+		{topic: "orders", partition: 2, lag: 5},
+		{topic: "payments", partition: 0, lag: 20},
+		{topic: "orders", partition: 1, lag: 5},
+		{topic: "orders", partition: 0, lag: 10},
+	})
+	expected := []string{"payments/0", "orders/0", "orders/1", "orders/2"}
+	if !reflect.DeepEqual(expected, hints) {
+		t.Errorf("Expected %v but got %v\n", expected, hints)
+	}
+	if hints := getPartitionWorkHints(nil); len(hints) != 0 {
+		t.Errorf("Expected no hint but got %v\n", hints)
+	}
+}
+
 type MockClusterAdmin struct {
 	partitionIds []int32
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -24,11 +23,34 @@ type postgreSQLScaler struct {
 }
 
 type postgreSQLMetadata struct {
-	targetQueryValue           float64
-	activationTargetQueryValue float64
-	connection                 string
-	query                      string
+	TargetQueryValue           *float64 `keda:"name=targetQueryValue, order=triggerMetadata, optional"`
+	ActivationTargetQueryValue float64  `keda:"name=activationTargetQueryValue, order=triggerMetadata, default=0"`
+	Connection                 string   `keda:"name=connection, order=authParams;resolvedEnv, optional"`
+	Query                      string   `keda:"name=query, order=triggerMetadata"`
 	triggerIndex               int
+
+	Host     string `keda:"name=host, order=authParams;triggerMetadata, optional"`
+	Port     string `keda:"name=port, order=authParams;triggerMetadata, optional"`
+	UserName string `keda:"name=userName, order=authParams;triggerMetadata, optional"`
+	DBName   string `keda:"name=dbName, order=authParams;triggerMetadata, optional"`
+	SslMode  string `keda:"name=sslmode, order=authParams;triggerMetadata, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+}
+
+// Validate checks that either a full connection string or all the connection parameters are given
+func (m *postgreSQLMetadata) Validate() error {
+	if m.Connection != "" {
+		return nil
+	}
+	params := []struct{ name, value string }{
+		{"host", m.Host}, {"port", m.Port}, {"userName", m.UserName}, {"dbName", m.DBName}, {"sslmode", m.SslMode},
+	}
+	for _, p := range params {
+		if p.value == "" {
+			return fmt.Errorf("%w: no %s given", ErrScalerConfigMissingField, p.name)
+		}
+	}
+	return nil
 }
 
 // NewPostgreSQLScaler creates a new postgreSQL scaler
@@ -58,91 +80,36 @@ func NewPostgreSQLScaler(config *ScalerConfig) (Scaler, error) {
 }
 
 func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error) {
-	meta := postgreSQLMetadata{}
-
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
+	meta := &postgreSQLMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
 	}
 
-	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
-		targetQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("queryValue parsing error %w", err)
-		}
-		meta.targetQueryValue = targetQueryValue
-	} else {
-		if config.AsMetricSource {
-			meta.targetQueryValue = 0
-		} else {
-			return nil, fmt.Errorf("no targetQueryValue given")
+	if meta.TargetQueryValue == nil {
+		if !config.AsMetricSource {
+			return nil, fmt.Errorf("%w: no targetQueryValue given", ErrScalerConfigMissingField)
 		}
+		// the target is ignored when the trigger is only a metric source of a composite scaler
+		meta.TargetQueryValue = new(float64)
 	}
 
-	meta.activationTargetQueryValue = 0
-	if val, ok := config.TriggerMetadata["activationTargetQueryValue"]; ok {
-		activationTargetQueryValue, err := strconv.ParseFloat(val, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationTargetQueryValue parsing error %w", err)
-		}
-		meta.activationTargetQueryValue = activationTargetQueryValue
-	}
-
-	switch {
-	case config.AuthParams["connection"] != "":
-		meta.connection = config.AuthParams["connection"]
-	case config.TriggerMetadata["connectionFromEnv"] != "":
-		meta.connection = config.ResolvedEnv[config.TriggerMetadata["connectionFromEnv"]]
-	default:
-		host, err := GetFromAuthOrMeta(config, "host")
-		if err != nil {
-			return nil, err
-		}
-
-		port, err := GetFromAuthOrMeta(config, "port")
-		if err != nil {
-			return nil, err
-		}
-
-		userName, err := GetFromAuthOrMeta(config, "userName")
-		if err != nil {
-			return nil, err
-		}
-
-		dbName, err := GetFromAuthOrMeta(config, "dbName")
-		if err != nil {
-			return nil, err
-		}
-
-		sslmode, err := GetFromAuthOrMeta(config, "sslmode")
-		if err != nil {
-			return nil, err
-		}
-
-		var password string
-		if config.AuthParams["password"] != "" {
-			password = config.AuthParams["password"]
-		} else if config.TriggerMetadata["passwordFromEnv"] != "" {
-			password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
-		}
-
+	if meta.Connection == "" {
 		// Build connection str
 		var params []string
-		params = append(params, "host="+escapePostgreConnectionParameter(host))
-		params = append(params, "port="+escapePostgreConnectionParameter(port))
-		params = append(params, "user="+escapePostgreConnectionParameter(userName))
-		params = append(params, "dbname="+escapePostgreConnectionParameter(dbName))
-		params = append(params, "sslmode="+escapePostgreConnectionParameter(sslmode))
-		params = append(params, "password="+escapePostgreConnectionParameter(password))
-		meta.connection = strings.Join(params, " ")
+		params = append(params, "host="+escapePostgreConnectionParameter(meta.Host))
+		params = append(params, "port="+escapePostgreConnectionParameter(meta.Port))
+		params = append(params, "user="+escapePostgreConnectionParameter(meta.UserName))
+		params = append(params, "dbname="+escapePostgreConnectionParameter(meta.DBName))
+		params = append(params, "sslmode="+escapePostgreConnectionParameter(meta.SslMode))
+		params = append(params, "password="+escapePostgreConnectionParameter(meta.Password))
+		meta.Connection = strings.Join(params, " ")
 	}
 	meta.triggerIndex = config.TriggerIndex
-	return &meta, nil
+	return meta, nil
 }
 
 func getConnection(meta *postgreSQLMetadata, logger logr.Logger) (*sql.DB, error) {
-	db, err := sql.Open("pgx", meta.connection)
+	db, err := sql.Open("pgx", meta.Connection)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Found error opening postgreSQL: %s", err))
 		return nil, err
@@ -167,7 +134,7 @@ func (s *postgreSQLScaler) Close(context.Context) error {
 
 func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (float64, error) {
 	var id float64
-	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&id)
+	err := s.connection.QueryRowContext(ctx, s.metadata.Query).Scan(&id)
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("could not query postgreSQL: %s", err))
 		return 0, fmt.Errorf("could not query postgreSQL: %w", err)
@@ -181,7 +148,7 @@ func (s *postgreSQLScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString("postgresql")),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueryValue),
+		Target: GetMetricTargetMili(s.metricType, *s.metadata.TargetQueryValue),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -198,7 +165,7 @@ func (s *postgreSQLScaler) GetMetricsAndActivity(ctx context.Context, metricName
 
 	metric := GenerateMetricInMili(metricName, num)
 
-	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationTargetQueryValue, nil
+	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.ActivationTargetQueryValue, nil
 }
 
 func escapePostgreConnectionParameter(str string) string {
@@ -82,17 +82,18 @@ func TestPosgresSQLConnectionStringGeneration(t *testing.T) {
 			t.Fatal("Could not parse metadata:", err)
 		}
 
-		if meta.connection != testData.connectionString {
-			t.Errorf("Error generating connectionString, expected '%s' and get '%s'", testData.connectionString, meta.connection)
+		if meta.Connection != testData.connectionString {
+			t.Errorf("Error generating connectionString, expected '%s' and get '%s'", testData.connectionString, meta.Connection)
 		}
 	}
 }
 
 type parsePostgresMetadataTestData struct {
-	metadata    map[string]string
-	authParams  map[string]string
-	resolvedEnv map[string]string
-	raisesError bool
+	metadata       map[string]string
+	authParams     map[string]string
+	resolvedEnv    map[string]string
+	asMetricSource bool
+	raisesError    bool
 }
 
 var testPostgresResolvedEnv = map[string]string{
@@ -129,11 +130,33 @@ var testPostgresMetadata = []parsePostgresMetadataTestData{
 		resolvedEnv: testPostgresResolvedEnv,
 		raisesError: false,
 	},
+	// Explicit zero targetQueryValue
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "0", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// No targetQueryValue
+	{
+		metadata:    map[string]string{"query": "query", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// No targetQueryValue for a metric source of a composite scaler
+	{
+		metadata:       map[string]string{"query": "query", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:     map[string]string{},
+		resolvedEnv:    testPostgresResolvedEnv,
+		asMetricSource: true,
+		raisesError:    false,
+	},
 }
 
 func TestParsePosgresSQLMetadata(t *testing.T) {
 	for _, testData := range testPostgresMetadata {
-		_, err := parsePostgreSQLMetadata(&ScalerConfig{ResolvedEnv: testData.resolvedEnv, TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		_, err := parsePostgreSQLMetadata(&ScalerConfig{ResolvedEnv: testData.resolvedEnv, TriggerMetadata: testData.metadata, AuthParams: testData.authParams, AsMetricSource: testData.asMetricSource})
 		if err != nil && !testData.raisesError {
 			t.Error("Expected success but got error", err)
 		}
@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"path"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -30,30 +29,26 @@ func init() {
 }
 
 const (
-	rabbitQueueLengthMetricName            = "queueLength"
-	rabbitModeTriggerConfigName            = "mode"
-	rabbitValueTriggerConfigName           = "value"
-	rabbitActivationValueTriggerConfigName = "activationValue"
-	rabbitModeQueueLength                  = "QueueLength"
-	rabbitModeMessageRate                  = "MessageRate"
-	defaultRabbitMQQueueLength             = 20
-	rabbitMetricType                       = "External"
-	rabbitRootVhostPath                    = "/%2F"
-	rmqTLSEnable                           = "enable"
+	rabbitModeTriggerConfigName  = "mode"
+	rabbitValueTriggerConfigName = "value"
+	rabbitModeQueueLength        = "QueueLength"
+	rabbitModeMessageRate        = "MessageRate"
+	defaultRabbitMQQueueLength   = 20
+	rabbitMetricType             = "External"
+	rabbitRootVhostPath          = "/%2F"
+	rmqTLSEnable                 = "enable"
 )
 
 const (
-	httpProtocol    = "http"
-	amqpProtocol    = "amqp"
-	autoProtocol    = "auto"
-	defaultProtocol = autoProtocol
+	httpProtocol = "http"
+	amqpProtocol = "amqp"
+	autoProtocol = "auto"
 )
 
 const (
-	sumOperation     = "sum"
-	avgOperation     = "avg"
-	maxOperation     = "max"
-	defaultOperation = sumOperation
+	sumOperation = "sum"
+	avgOperation = "avg"
+	maxOperation = "max"
 )
 
 type rabbitMQScaler struct {
@@ -67,31 +62,124 @@ type rabbitMQScaler struct {
 }
 
 type rabbitMQMetadata struct {
-	queueName             string
-	mode                  string        // QueueLength or MessageRate
-	value                 float64       // trigger value (queue length or publish/sec. rate)
-	activationValue       float64       // activation value
-	host                  string        // connection string for either HTTP or AMQP protocol
-	protocol              string        // either http or amqp protocol
-	vhostName             string        // override the vhost from the connection info
-	useRegex              bool          // specify if the queueName contains a rexeg
-	excludeUnacknowledged bool          // specify if the QueueLength value should exclude Unacknowledged messages (Ready messages only)
-	pageSize              int64         // specify the page size if useRegex is enabled
-	operation             string        // specify the operation to apply in case of multiples queues
-	timeout               time.Duration // custom http timeout for a specific trigger
-	triggerIndex          int           // scaler index
+	QueueName             string  `keda:"name=queueName, order=triggerMetadata"`
+	Mode                  string  `keda:"name=mode, order=triggerMetadata, optional, enum=QueueLength;MessageRate"`           // QueueLength or MessageRate
+	Value                 float64 `keda:"name=value, order=triggerMetadata, default=-1"`                                      // trigger value (queue length or publish/sec. rate)
+	QueueLength           float64 `keda:"name=queueLength, order=triggerMetadata, default=-1"`                                // deprecated, use Mode and Value instead
+	ActivationValue       float64 `keda:"name=activationValue, order=triggerMetadata, default=0"`                             // activation value
+	Host                  string  `keda:"name=host, order=authParams;triggerMetadata;resolvedEnv"`                            // connection string for either HTTP or AMQP protocol
+	Protocol              string  `keda:"name=protocol, order=triggerMetadata;authParams, default=auto, enum=auto;http;amqp"` // either http or amqp protocol
+	VhostName             string  `keda:"name=vhostName, order=triggerMetadata, optional"`                                    // override the vhost from the connection info
+	UseRegex              bool    `keda:"name=useRegex, order=triggerMetadata, optional"`                                     // specify if the queueName contains a rexeg
+	ExcludeUnacknowledged bool    `keda:"name=excludeUnacknowledged, order=triggerMetadata, optional"`                        // specify if the QueueLength value should exclude Unacknowledged messages (Ready messages only)
+	PageSize              int64   `keda:"name=pageSize, order=triggerMetadata, default=100, range=1:"`                        // specify the page size if useRegex is enabled
+	Operation             string  `keda:"name=operation, order=triggerMetadata, default=sum, enum=sum;avg;max"`               // specify the operation to apply in case of multiples queues
+	TimeoutMs             int     `keda:"name=timeout, order=triggerMetadata, optional, range=1:"`                            // custom http timeout for a specific trigger
+	timeout               time.Duration
+	triggerIndex          int // scaler index
 
 	// TLS
-	ca          string
-	cert        string
-	key         string
-	keyPassword string
+	TLS         string `keda:"name=tls, order=authParams, default=disable, enum=enable;disable"`
+	Ca          string `keda:"name=ca, order=authParams, optional"`
+	Cert        string `keda:"name=cert, order=authParams, optional"`
+	Key         string `keda:"name=key, order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
 	enableTLS   bool
-	unsafeSsl   bool
 
 	// token provider for azure AD
+	WorkloadIdentityResource string `keda:"name=workloadIdentityResource, order=authParams, optional"`
 	workloadIdentityClientID string
-	workloadIdentityResource string
+}
+
+// Validate checks the relationships between the rabbitMQ parameters, the metadata is left untouched
+func (m *rabbitMQMetadata) Validate() error {
+	if m.tlsEnabled() && (m.Cert != "") != (m.Key != "") {
+		return fmt.Errorf("both key and cert must be provided")
+	}
+
+	protocol, err := m.resolveProtocol()
+	if err != nil {
+		return err
+	}
+
+	if protocol == amqpProtocol && m.WorkloadIdentityResource != "" {
+		return fmt.Errorf("workload identity is not supported for amqp protocol currently")
+	}
+
+	if m.UseRegex && protocol != httpProtocol {
+		return fmt.Errorf("configure only useRegex with http protocol")
+	}
+
+	if m.ExcludeUnacknowledged && protocol != httpProtocol {
+		return fmt.Errorf("configure excludeUnacknowledged=true with http protocol only")
+	}
+
+	if _, _, err := m.resolveTrigger(protocol); err != nil {
+		return fmt.Errorf("unable to parse trigger: %w", err)
+	}
+
+	if m.TimeoutMs != 0 && protocol == amqpProtocol {
+		return fmt.Errorf("amqp protocol doesn't support custom timeouts")
+	}
+	return nil
+}
+
+// tlsEnabled returns whether TLS is enabled by the `tls` auth parameter
+func (m *rabbitMQMetadata) tlsEnabled() bool {
+	return strings.TrimSpace(m.TLS) == rmqTLSEnable
+}
+
+// resolveProtocol returns the protocol, checking the host scheme if the protocol is auto
+func (m *rabbitMQMetadata) resolveProtocol() (string, error) {
+	if m.Protocol != autoProtocol {
+		return m.Protocol, nil
+	}
+	parsedURL, err := url.Parse(m.Host)
+	if err != nil {
+		return "", fmt.Errorf("can't parse host to find protocol: %w", err)
+	}
+	switch parsedURL.Scheme {
+	case "amqp", "amqps":
+		return amqpProtocol, nil
+	case "http", "https":
+		return httpProtocol, nil
+	default:
+		return "", fmt.Errorf("unknown host URL scheme `%s`", parsedURL.Scheme)
+	}
+}
+
+// resolveTrigger returns the trigger mode and value, taking the deprecated `queueLength` into account
+func (m *rabbitMQMetadata) resolveTrigger(protocol string) (string, float64, error) {
+	deprecatedQueueLengthPresent := m.QueueLength != -1
+	modePresent := m.Mode != ""
+	valuePresent := m.Value != -1
+
+	// If nothing is specified for the trigger then use the default
+	if !deprecatedQueueLengthPresent && !modePresent && !valuePresent {
+		return rabbitModeQueueLength, defaultRabbitMQQueueLength, nil
+	}
+
+	// Only allow one of `queueLength` or `mode`/`value`
+	if deprecatedQueueLengthPresent && (modePresent || valuePresent) {
+		return "", 0, fmt.Errorf("queueLength is deprecated; configure only %s and %s", rabbitModeTriggerConfigName, rabbitValueTriggerConfigName)
+	}
+
+	if deprecatedQueueLengthPresent {
+		return rabbitModeQueueLength, m.QueueLength, nil
+	}
+
+	if !modePresent {
+		return "", 0, fmt.Errorf("%s must be specified", rabbitModeTriggerConfigName)
+	}
+	if !valuePresent {
+		return "", 0, fmt.Errorf("%s must be specified", rabbitValueTriggerConfigName)
+	}
+
+	if m.Mode == rabbitModeMessageRate && protocol != httpProtocol {
+		return "", 0, fmt.Errorf("protocol %s not supported; must be http to use mode %s", protocol, rabbitModeMessageRate)
+	}
+	return m.Mode, m.Value, nil
 }
 
 type queueInfo struct {
@@ -132,17 +220,17 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing rabbitmq metadata: %w", err)
 	}
 	s.metadata = meta
-	s.httpClient = kedautil.CreateHTTPClient(meta.timeout, meta.unsafeSsl)
+	s.httpClient = kedautil.CreateHTTPClient(meta.timeout, meta.UnsafeSsl)
 
-	if meta.protocol == amqpProtocol {
+	if meta.Protocol == amqpProtocol {
 		// Override vhost if requested.
-		host := meta.host
-		if meta.vhostName != "" {
+		host := meta.Host
+		if meta.VhostName != "" {
 			hostURI, err := amqp.ParseURI(host)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing rabbitmq connection string: %w", err)
 			}
-			hostURI.Vhost = meta.vhostName
+			hostURI.Vhost = meta.VhostName
 			host = hostURI.String()
 		}
 
@@ -157,277 +245,39 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 	return s, nil
 }
 
-func resolveProtocol(config *ScalerConfig, meta *rabbitMQMetadata) error {
-	meta.protocol = defaultProtocol
-	if val, ok := config.AuthParams["protocol"]; ok {
-		meta.protocol = val
-	}
-	if val, ok := config.TriggerMetadata["protocol"]; ok {
-		meta.protocol = val
-	}
-	if meta.protocol != amqpProtocol && meta.protocol != httpProtocol && meta.protocol != autoProtocol {
-		return fmt.Errorf("the protocol has to be either `%s`, `%s`, or `%s` but is `%s`", amqpProtocol, httpProtocol, autoProtocol, meta.protocol)
-	}
-	return nil
-}
-
-func resolveHostValue(config *ScalerConfig, meta *rabbitMQMetadata) error {
-	switch {
-	case config.AuthParams["host"] != "":
-		meta.host = config.AuthParams["host"]
-	case config.TriggerMetadata["host"] != "":
-		meta.host = config.TriggerMetadata["host"]
-	case config.TriggerMetadata["hostFromEnv"] != "":
-		meta.host = config.ResolvedEnv[config.TriggerMetadata["hostFromEnv"]]
-	default:
-		return fmt.Errorf("no host setting given")
-	}
-	return nil
-}
-
-func resolveTimeout(config *ScalerConfig, meta *rabbitMQMetadata) error {
-	if val, ok := config.TriggerMetadata["timeout"]; ok {
-		timeoutMS, err := strconv.Atoi(val)
-		if err != nil {
-			return fmt.Errorf("unable to parse timeout: %w", err)
-		}
-		if meta.protocol == amqpProtocol {
-			return fmt.Errorf("amqp protocol doesn't support custom timeouts: %w", err)
-		}
-		if timeoutMS <= 0 {
-			return fmt.Errorf("timeout must be greater than 0: %w", err)
-		}
-		meta.timeout = time.Duration(timeoutMS) * time.Millisecond
-	} else {
-		meta.timeout = config.GlobalHTTPTimeout
-	}
-	return nil
-}
-
-func resolveTLSAuthParams(config *ScalerConfig, meta *rabbitMQMetadata) error {
-	meta.enableTLS = false
-	if val, ok := config.AuthParams["tls"]; ok {
-		val = strings.TrimSpace(val)
-		if val == rmqTLSEnable {
-			meta.ca = config.AuthParams["ca"]
-			meta.cert = config.AuthParams["cert"]
-			meta.key = config.AuthParams["key"]
-			meta.enableTLS = true
-		} else if val != "disable" {
-			return fmt.Errorf("err incorrect value for TLS given: %s", val)
-		}
-	}
-	return nil
-}
-
 func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
-	meta := rabbitMQMetadata{}
-
-	// Resolve protocol type
-	if err := resolveProtocol(config, &meta); err != nil {
-		return nil, err
-	}
-
-	// Resolve host value
-	if err := resolveHostValue(config, &meta); err != nil {
+	meta := &rabbitMQMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
 		return nil, err
 	}
 
-	// Resolve TLS authentication parameters
-	if err := resolveTLSAuthParams(config, &meta); err != nil {
-		return nil, err
-	}
-
-	meta.keyPassword = config.AuthParams["keyPassword"]
-
-	if config.PodIdentity.Provider == v1alpha1.PodIdentityProviderAzureWorkload {
-		if config.AuthParams["workloadIdentityResource"] != "" {
-			meta.workloadIdentityClientID = config.PodIdentity.GetIdentityID()
-			meta.workloadIdentityResource = config.AuthParams["workloadIdentityResource"]
-		}
-	}
-
-	certGiven := meta.cert != ""
-	keyGiven := meta.key != ""
-	if certGiven != keyGiven {
-		return nil, fmt.Errorf("both key and cert must be provided")
-	}
-
-	meta.unsafeSsl = false
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		boolVal, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse unsafeSsl value. Must be either true or false")
-		}
-		meta.unsafeSsl = boolVal
-	}
-
-	// If the protocol is auto, check the host scheme.
-	if meta.protocol == autoProtocol {
-		parsedURL, err := url.Parse(meta.host)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse host to find protocol: %w", err)
-		}
-		switch parsedURL.Scheme {
-		case "amqp", "amqps":
-			meta.protocol = amqpProtocol
-		case "http", "https":
-			meta.protocol = httpProtocol
-		default:
-			return nil, fmt.Errorf("unknown host URL scheme `%s`", parsedURL.Scheme)
-		}
-	}
-
-	if meta.protocol == amqpProtocol && config.AuthParams["workloadIdentityResource"] != "" {
-		return nil, fmt.Errorf("workload identity is not supported for amqp protocol currently")
-	}
-
-	// Resolve queueName
-	if val, ok := config.TriggerMetadata["queueName"]; ok {
-		meta.queueName = val
-	} else {
-		return nil, fmt.Errorf("no queue name given")
-	}
-
-	// Resolve vhostName
-	if val, ok := config.TriggerMetadata["vhostName"]; ok {
-		meta.vhostName = val
-	}
-
-	err := parseRabbitMQHttpProtocolMetadata(config, &meta)
+	// the parameters are checked by Validate, resolve the values it has checked
+	protocol, err := meta.resolveProtocol()
 	if err != nil {
 		return nil, err
 	}
-
-	if meta.useRegex && meta.protocol != httpProtocol {
-		return nil, fmt.Errorf("configure only useRegex with http protocol")
-	}
-
-	if meta.excludeUnacknowledged && meta.protocol != httpProtocol {
-		return nil, fmt.Errorf("configure excludeUnacknowledged=true with http protocol only")
-	}
-
-	_, err = parseTrigger(&meta, config)
-	if err != nil {
+	meta.Protocol = protocol
+	if meta.Mode, meta.Value, err = meta.resolveTrigger(protocol); err != nil {
 		return nil, fmt.Errorf("unable to parse trigger: %w", err)
 	}
-	// Resolve timeout
-	if err := resolveTimeout(config, &meta); err != nil {
-		return nil, err
-	}
-	meta.triggerIndex = config.TriggerIndex
-
-	return &meta, nil
-}
 
-func parseRabbitMQHttpProtocolMetadata(config *ScalerConfig, meta *rabbitMQMetadata) error {
-	// Resolve useRegex
-	if val, ok := config.TriggerMetadata["useRegex"]; ok {
-		useRegex, err := strconv.ParseBool(val)
-		if err != nil {
-			return fmt.Errorf("useRegex has invalid value")
-		}
-		meta.useRegex = useRegex
-	}
-
-	// Resolve excludeUnacknowledged
-	if val, ok := config.TriggerMetadata["excludeUnacknowledged"]; ok {
-		excludeUnacknowledged, err := strconv.ParseBool(val)
-		if err != nil {
-			return fmt.Errorf("excludeUnacknowledged has invalid value")
-		}
-		meta.excludeUnacknowledged = excludeUnacknowledged
+	meta.enableTLS = meta.tlsEnabled()
+	if !meta.enableTLS {
+		meta.Ca, meta.Cert, meta.Key = "", "", ""
 	}
 
-	// Resolve pageSize
-	if val, ok := config.TriggerMetadata["pageSize"]; ok {
-		pageSize, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return fmt.Errorf("pageSize has invalid value")
-		}
-		meta.pageSize = pageSize
-		if meta.pageSize < 1 {
-			return fmt.Errorf("pageSize should be 1 or greater than 1")
-		}
+	// the workload identity resource is only used with the azure workload identity
+	if config.PodIdentity.Provider == v1alpha1.PodIdentityProviderAzureWorkload && meta.WorkloadIdentityResource != "" {
+		meta.workloadIdentityClientID = config.PodIdentity.GetIdentityID()
 	} else {
-		meta.pageSize = 100
-	}
-
-	// Resolve operation
-	meta.operation = defaultOperation
-	if val, ok := config.TriggerMetadata["operation"]; ok {
-		meta.operation = val
-	}
-
-	return nil
-}
-
-func parseTrigger(meta *rabbitMQMetadata, config *ScalerConfig) (*rabbitMQMetadata, error) {
-	deprecatedQueueLengthValue, deprecatedQueueLengthPresent := config.TriggerMetadata[rabbitQueueLengthMetricName]
-	mode, modePresent := config.TriggerMetadata[rabbitModeTriggerConfigName]
-	value, valuePresent := config.TriggerMetadata[rabbitValueTriggerConfigName]
-	activationValue, activationValuePresent := config.TriggerMetadata[rabbitActivationValueTriggerConfigName]
-
-	// Initialize to default trigger settings
-	meta.mode = rabbitModeQueueLength
-	meta.value = defaultRabbitMQQueueLength
-
-	// If nothing is specified for the trigger then return the default
-	if !deprecatedQueueLengthPresent && !modePresent && !valuePresent {
-		return meta, nil
-	}
-
-	// Only allow one of `queueLength` or `mode`/`value`
-	if deprecatedQueueLengthPresent && (modePresent || valuePresent) {
-		return nil, fmt.Errorf("queueLength is deprecated; configure only %s and %s", rabbitModeTriggerConfigName, rabbitValueTriggerConfigName)
-	}
-
-	// Parse activation value
-	if activationValuePresent {
-		activation, err := strconv.ParseFloat(activationValue, 64)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse %s: %w", rabbitActivationValueTriggerConfigName, err)
-		}
-		meta.activationValue = activation
-	}
-
-	// Parse deprecated `queueLength` value
-	if deprecatedQueueLengthPresent {
-		queueLength, err := strconv.ParseFloat(deprecatedQueueLengthValue, 64)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse %s: %w", rabbitQueueLengthMetricName, err)
-		}
-		meta.mode = rabbitModeQueueLength
-		meta.value = queueLength
-
-		return meta, nil
+		meta.WorkloadIdentityResource = ""
 	}
 
-	if !modePresent {
-		return nil, fmt.Errorf("%s must be specified", rabbitModeTriggerConfigName)
-	}
-	if !valuePresent {
-		return nil, fmt.Errorf("%s must be specified", rabbitValueTriggerConfigName)
-	}
-
-	// Resolve trigger mode
-	switch mode {
-	case rabbitModeQueueLength:
-		meta.mode = rabbitModeQueueLength
-	case rabbitModeMessageRate:
-		meta.mode = rabbitModeMessageRate
-	default:
-		return nil, fmt.Errorf("trigger mode %s must be one of %s, %s", mode, rabbitModeQueueLength, rabbitModeMessageRate)
-	}
-	triggerValue, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return nil, fmt.Errorf("can't parse %s: %w", rabbitValueTriggerConfigName, err)
-	}
-	meta.value = triggerValue
-
-	if meta.mode == rabbitModeMessageRate && meta.protocol != httpProtocol {
-		return nil, fmt.Errorf("protocol %s not supported; must be http to use mode %s", meta.protocol, rabbitModeMessageRate)
+	meta.timeout = config.GlobalHTTPTimeout
+	if meta.TimeoutMs != 0 {
+		meta.timeout = time.Duration(meta.TimeoutMs) * time.Millisecond
 	}
+	meta.triggerIndex = config.TriggerIndex
 
 	return meta, nil
 }
@@ -441,7 +291,7 @@ func getConnectionAndChannel(host string, meta *rabbitMQMetadata) (*amqp.Connect
 	var conn *amqp.Connection
 	var err error
 	if meta.enableTLS {
-		tlsConfig, configErr := kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca, meta.unsafeSsl)
+		tlsConfig, configErr := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.Ca, meta.UnsafeSsl)
 		if configErr == nil {
 			conn, err = amqp.DialTLS(host, tlsConfig)
 		}
@@ -476,13 +326,13 @@ func (s *rabbitMQScaler) Close(context.Context) error {
 }
 
 func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, error) {
-	if s.metadata.protocol == httpProtocol {
+	if s.metadata.Protocol == httpProtocol {
 		info, err := s.getQueueInfoViaHTTP(ctx)
 		if err != nil {
 			return -1, -1, err
 		}
 
-		if s.metadata.excludeUnacknowledged {
+		if s.metadata.ExcludeUnacknowledged {
 			// messages count includes only ready
 			return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, nil
 		}
@@ -491,7 +341,7 @@ func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, er
 	}
 
 	// QueueDeclarePassive assumes that the queue exists and fails if it doesn't
-	items, err := s.channel.QueueDeclarePassive(s.metadata.queueName, false, false, false, false, amqp.Table{})
+	items, err := s.channel.QueueDeclarePassive(s.metadata.QueueName, false, false, false, false, amqp.Table{})
 	if err != nil {
 		return -1, -1, err
 	}
@@ -507,9 +357,9 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 		return result, err
 	}
 
-	if s.metadata.workloadIdentityResource != "" {
+	if s.metadata.WorkloadIdentityResource != "" {
 		if s.azureOAuth == nil {
-			s.azureOAuth = azure.NewAzureADWorkloadIdentityTokenProvider(ctx, s.metadata.workloadIdentityClientID, s.metadata.workloadIdentityResource)
+			s.azureOAuth = azure.NewAzureADWorkloadIdentityTokenProvider(ctx, s.metadata.workloadIdentityClientID, s.metadata.WorkloadIdentityResource)
 		}
 
 		err = s.azureOAuth.Refresh()
@@ -528,7 +378,7 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 	defer r.Body.Close()
 
 	if r.StatusCode == 200 {
-		if s.metadata.useRegex {
+		if s.metadata.UseRegex {
 			var queues regexQueueInfo
 			err = json.NewDecoder(r.Body).Decode(&queues)
 			if err != nil {
@@ -568,20 +418,20 @@ func getVhostAndPathFromURL(rawPath, vhostName string) (resolvedVhostPath, resol
 }
 
 func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, error) {
-	parsedURL, err := url.Parse(s.metadata.host)
+	parsedURL, err := url.Parse(s.metadata.Host)
 
 	if err != nil {
 		return nil, err
 	}
 
-	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, s.metadata.vhostName)
+	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, s.metadata.VhostName)
 	parsedURL.Path = subpaths
 
 	var getQueueInfoManagementURI string
-	if s.metadata.useRegex {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
+	if s.metadata.UseRegex {
+		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName), s.metadata.PageSize)
 	} else {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName))
+		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName))
 	}
 
 	var info queueInfo
@@ -598,9 +448,9 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, e
 func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("rabbitmq-%s", url.QueryEscape(s.metadata.queueName)))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("rabbitmq-%s", url.QueryEscape(s.metadata.QueueName)))),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: rabbitMetricType,
@@ -618,12 +468,12 @@ func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 
 	var metric external_metrics.ExternalMetricValue
 	var isActive bool
-	if s.metadata.mode == rabbitModeQueueLength {
+	if s.metadata.Mode == rabbitModeQueueLength {
 		metric = GenerateMetricInMili(metricName, float64(messages))
-		isActive = float64(messages) > s.metadata.activationValue
+		isActive = float64(messages) > s.metadata.ActivationValue
 	} else {
 		metric = GenerateMetricInMili(metricName, publishRate)
-		isActive = publishRate > s.metadata.activationValue || float64(messages) > s.metadata.activationValue
+		isActive = publishRate > s.metadata.ActivationValue || float64(messages) > s.metadata.ActivationValue
 	}
 
 	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
@@ -634,7 +484,7 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	queue.Name = "composed-queue"
 	queue.MessagesUnacknowledged = 0
 	if len(q) > 0 {
-		switch s.metadata.operation {
+		switch s.metadata.Operation {
 		case sumOperation:
 			sumMessages, sumReady, sumRate := getSum(q)
 			queue.Messages = sumMessages
@@ -651,7 +501,7 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 			queue.MessagesReady = maxReady
 			queue.MessageStat.PublishDetail.Rate = maxRate
 		default:
-			return queue, fmt.Errorf("operation mode %s must be one of %s, %s, %s", s.metadata.operation, sumOperation, avgOperation, maxOperation)
+			return queue, fmt.Errorf("operation mode %s must be one of %s, %s, %s", s.metadata.Operation, sumOperation, avgOperation, maxOperation)
 		}
 	} else {
 		queue.Messages = 0
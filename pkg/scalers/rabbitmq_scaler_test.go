@@ -174,8 +174,8 @@ func TestRabbitMQParseMetadata(t *testing.T) {
 			if err != nil && !testData.isError {
 				t.Errorf("Expect error but got success in test case %d", idx)
 			}
-			if boolVal != meta.unsafeSsl {
-				t.Errorf("Expect %t but got %t in test case %d", boolVal, meta.unsafeSsl, idx)
+			if boolVal != meta.UnsafeSsl {
+				t.Errorf("Expect %t but got %t in test case %d", boolVal, meta.UnsafeSsl, idx)
 			}
 		}
 	}
@@ -194,21 +194,21 @@ func TestRabbitMQParseAuthParamData(t *testing.T) {
 			t.Errorf("Expected enableTLS to be set to %v but got %v\n", testData.enableTLS, metadata.enableTLS)
 		}
 		if metadata != nil && metadata.enableTLS {
-			if metadata.ca != testData.authParams["ca"] {
+			if metadata.Ca != testData.authParams["ca"] {
 				t.Errorf("Expected ca to be set to %v but got %v\n", testData.authParams["ca"], metadata.enableTLS)
 			}
-			if metadata.cert != testData.authParams["cert"] {
-				t.Errorf("Expected cert to be set to %v but got %v\n", testData.authParams["cert"], metadata.cert)
+			if metadata.Cert != testData.authParams["cert"] {
+				t.Errorf("Expected cert to be set to %v but got %v\n", testData.authParams["cert"], metadata.Cert)
 			}
-			if metadata.key != testData.authParams["key"] {
-				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["key"], metadata.key)
+			if metadata.Key != testData.authParams["key"] {
+				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["key"], metadata.Key)
 			}
-			if metadata.keyPassword != testData.authParams["keyPassword"] {
-				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["keyPassword"], metadata.key)
+			if metadata.KeyPassword != testData.authParams["keyPassword"] {
+				t.Errorf("Expected key to be set to %v but got %v\n", testData.authParams["keyPassword"], metadata.Key)
 			}
 		}
 		if metadata != nil && metadata.workloadIdentityClientID != "" && !testData.workloadIdentity {
-			t.Errorf("Expected workloadIdentity to be disabled but got %v as client ID and %v as resource\n", metadata.workloadIdentityClientID, metadata.workloadIdentityResource)
+			t.Errorf("Expected workloadIdentity to be disabled but got %v as client ID and %v as resource\n", metadata.workloadIdentityClientID, metadata.WorkloadIdentityResource)
 		}
 		if metadata != nil && metadata.workloadIdentityClientID == "" && testData.workloadIdentity {
 			t.Error("Expected workloadIdentity to be enabled but was not\n")
@@ -216,6 +216,33 @@ func TestRabbitMQParseAuthParamData(t *testing.T) {
 	}
 }
 
+func TestRabbitMQWorkloadIdentityResourceWithoutWorkloadIdentity(t *testing.T) {
+	podIdentities := []v1alpha1.AuthPodIdentity{
+		{},
+		{Provider: v1alpha1.PodIdentityProviderAwsEKS},
+	}
+	for _, podIdentity := range podIdentities {
+		meta, err := parseRabbitMQMetadata(&ScalerConfig{
+			ResolvedEnv:     sampleRabbitMqResolvedEnv,
+			TriggerMetadata: map[string]string{"queueName": "sample", "hostFromEnv": host, "protocol": "http"},
+			AuthParams:      map[string]string{"workloadIdentityResource": "rabbitmq-resource-id"},
+			PodIdentity:     podIdentity,
+		})
+		assert.NoError(t, err, podIdentity.Provider)
+		// the token flow of the azure workload identity isn't started for the other providers
+		assert.Empty(t, meta.WorkloadIdentityResource, podIdentity.Provider)
+		assert.Empty(t, meta.workloadIdentityClientID, podIdentity.Provider)
+	}
+}
+
+func TestRabbitMQValidateDoesNotResolveMetadata(t *testing.T) {
+	meta := &rabbitMQMetadata{Host: "amqp://localhost", Protocol: autoProtocol, Mode: "", Value: -1, QueueLength: -1, TLS: rmqTLSEnable}
+	assert.NoError(t, meta.Validate())
+	assert.Equal(t, autoProtocol, meta.Protocol)
+	assert.Empty(t, meta.Mode)
+	assert.False(t, meta.enableTLS)
+}
+
 var testDefaultQueueLength = []parseRabbitMQMetadataTestData{
 	// use default queueLength
 	{map[string]string{"queueName": "sample", "hostFromEnv": host}, false, map[string]string{}},
@@ -231,8 +258,8 @@ func TestParseDefaultQueueLength(t *testing.T) {
 			t.Error("Expected success but got error", err)
 		case testData.isError && err == nil:
 			t.Error("Expected error but got success")
-		case metadata.value != defaultRabbitMQQueueLength:
-			t.Error("Expected default queueLength =", defaultRabbitMQQueueLength, "but got", metadata.value)
+		case metadata.Value != defaultRabbitMQQueueLength:
+			t.Error("Expected default queueLength =", defaultRabbitMQQueueLength, "but got", metadata.Value)
 		}
 	}
 }
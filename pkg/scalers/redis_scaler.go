@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -17,15 +16,12 @@ import (
 )
 
 const (
-	defaultListLength           = 5
-	defaultActivationListLength = 0
-	defaultDBIdx                = 0
-	defaultEnableTLS            = false
+	defaultEnableTLS = false
 )
 
 var (
 	// ErrRedisNoListName is returned when "listName" is missing from the config.
-	ErrRedisNoListName = errors.New("no list name given")
+	ErrRedisNoListName = fmt.Errorf("%w: no list name given", ErrScalerConfigMissingField)
 
 	// ErrRedisNoAddresses is returned when the "addresses" in the connection info is empty.
 	ErrRedisNoAddresses = errors.New("no addresses or hosts given. address should be a comma separated list of host:port or set the host/port values")
@@ -34,7 +30,7 @@ var (
 	ErrRedisUnequalHostsAndPorts = errors.New("not enough hosts or ports given. number of hosts should be equal to the number of ports")
 )
 
-type redisAddressParser func(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error)
+type redisAddressParser func(config *ScalerConfig) (redisConnectionInfo, error)
 
 type redisScaler struct {
 	metricType      v2.MetricTargetType
@@ -62,14 +58,74 @@ type redisConnectionInfo struct {
 }
 
 type redisMetadata struct {
-	listLength           int64
-	activationListLength int64
-	listName             string
-	databaseIndex        int
+	ListLength           int64  `keda:"name=listLength, order=triggerMetadata, default=5"`
+	ActivationListLength int64  `keda:"name=activationListLength, order=triggerMetadata, default=0"`
+	ListName             string `keda:"name=listName, order=triggerMetadata"`
+	DatabaseIndex        int    `keda:"name=databaseIndex, order=triggerMetadata, default=0"`
 	connectionInfo       redisConnectionInfo
 	triggerIndex         int
 }
 
+// redisCredentialsMetadata holds the credentials of all the redis modes
+type redisCredentialsMetadata struct {
+	Username string `keda:"name=username, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+}
+
+// redisTLSMetadata holds the TLS parameters of all the redis modes
+type redisTLSMetadata struct {
+	EnableTLS   bool   `keda:"name=enableTLS, order=triggerMetadata, default=false"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+	TLS         string `keda:"name=tls, order=authParams, enum=enable;disable, optional"`
+	Cert        string `keda:"name=cert, order=authParams, optional"`
+	Key         string `keda:"name=key, order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	CA          string `keda:"name=ca, order=authParams, optional"`
+}
+
+// redisConnectionMetadata holds the connection parameters of a standalone redis
+type redisConnectionMetadata struct {
+	Address string `keda:"name=address, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	Host    string `keda:"name=host, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	Port    string `keda:"name=port, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	redisCredentialsMetadata
+	redisTLSMetadata
+}
+
+// redisClusterConnectionMetadata holds the connection parameters of a redis cluster
+type redisClusterConnectionMetadata struct {
+	Addresses []string `keda:"name=addresses, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	Hosts     []string `keda:"name=hosts, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	Ports     []string `keda:"name=ports, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	redisCredentialsMetadata
+	redisTLSMetadata
+}
+
+// redisSentinelConnectionMetadata holds the connection parameters of a redis sentinel
+type redisSentinelConnectionMetadata struct {
+	redisClusterConnectionMetadata
+	SentinelUsername string `keda:"name=sentinelUsername, order=authParams;triggerMetadata;resolvedEnv, optional"`
+	SentinelPassword string `keda:"name=sentinelPassword, order=authParams;resolvedEnv, optional"`
+	SentinelMaster   string `keda:"name=sentinelMaster, order=authParams;triggerMetadata;resolvedEnv, optional"`
+}
+
+// redisTriggerMetadata, redisClusterTriggerMetadata and redisSentinelTriggerMetadata declare
+// all the parameters of the redis, redis-cluster and redis-sentinel triggers in the scaler registry
+type (
+	redisTriggerMetadata struct {
+		redisMetadata
+		redisConnectionMetadata
+	}
+	redisClusterTriggerMetadata struct {
+		redisMetadata
+		redisClusterConnectionMetadata
+	}
+	redisSentinelTriggerMetadata struct {
+		redisMetadata
+		redisSentinelConnectionMetadata
+	}
+)
+
 // NewRedisScaler creates a new redisScaler
 func NewRedisScaler(ctx context.Context, isClustered, isSentinel bool, config *ScalerConfig) (Scaler, error) {
 	luaScript := `
@@ -130,7 +186,7 @@ func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script
 	}
 
 	listLengthFn := func(ctx context.Context) (int64, error) {
-		cmd := client.Eval(ctx, script, []string{meta.listName})
+		cmd := client.Eval(ctx, script, []string{meta.ListName})
 		if cmd.Err() != nil {
 			return -1, cmd.Err()
 		}
@@ -148,7 +204,7 @@ func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script
 }
 
 func createSentinelRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
-	client, err := getRedisSentinelClient(ctx, meta.connectionInfo, meta.databaseIndex)
+	client, err := getRedisSentinelClient(ctx, meta.connectionInfo, meta.DatabaseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis sentinel failed: %w", err)
 	}
@@ -157,7 +213,7 @@ func createSentinelRedisScaler(ctx context.Context, meta *redisMetadata, script
 }
 
 func createRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
-	client, err := getRedisClient(ctx, meta.connectionInfo, meta.databaseIndex)
+	client, err := getRedisClient(ctx, meta.connectionInfo, meta.DatabaseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis failed: %w", err)
 	}
@@ -175,7 +231,7 @@ func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, scri
 	}
 
 	listLengthFn := func(ctx context.Context) (int64, error) {
-		cmd := client.Eval(ctx, script, []string{meta.listName})
+		cmd := client.Eval(ctx, script, []string{meta.ListName})
 		if cmd.Err() != nil {
 			return -1, cmd.Err()
 		}
@@ -192,64 +248,35 @@ func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, scri
 	}
 }
 
-func parseTLSConfigIntoConnectionInfo(config *ScalerConfig, connInfo *redisConnectionInfo) error {
-	enableTLS := defaultEnableTLS
-	if val, ok := config.TriggerMetadata["enableTLS"]; ok {
-		tls, err := strconv.ParseBool(val)
-		if err != nil {
-			return fmt.Errorf("enableTLS parsing error %w", err)
-		}
-		enableTLS = tls
-	}
-
-	connInfo.unsafeSsl = false
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		parsedVal, err := strconv.ParseBool(val)
-		if err != nil {
-			return fmt.Errorf("error parsing unsafeSsl: %w", err)
-		}
-		connInfo.unsafeSsl = parsedVal
-	}
-
-	// parse tls config defined in auth params
-	if val, ok := config.AuthParams["tls"]; ok {
-		val = strings.TrimSpace(val)
+// connectionInfo sets the TLS parameters of the connection, TLS can be enabled either in the trigger
+// metadata or in the TriggerAuthentication but not in both
+func (m redisTLSMetadata) connectionInfo(info *redisConnectionInfo) error {
+	enableTLS := m.EnableTLS
+	if m.TLS != "" {
 		if enableTLS {
 			return errors.New("unable to set `tls` in both ScaledObject and TriggerAuthentication together")
 		}
-		switch val {
-		case stringEnable:
-			enableTLS = true
-		case stringDisable:
-			enableTLS = false
-		default:
-			return fmt.Errorf("error incorrect TLS value given, got %s", val)
-		}
+		enableTLS = strings.TrimSpace(m.TLS) == stringEnable
 	}
+	info.unsafeSsl = m.UnsafeSsl
 	if enableTLS {
-		certGiven := config.AuthParams["cert"] != ""
-		keyGiven := config.AuthParams["key"] != ""
-		if certGiven && !keyGiven {
+		if m.Cert != "" && m.Key == "" {
 			return errors.New("key must be provided with cert")
 		}
-		if keyGiven && !certGiven {
+		if m.Key != "" && m.Cert == "" {
 			return errors.New("cert must be provided with key")
 		}
-		connInfo.ca = config.AuthParams["ca"]
-		connInfo.cert = config.AuthParams["cert"]
-		connInfo.key = config.AuthParams["key"]
-		if value, found := config.AuthParams["keyPassword"]; found {
-			connInfo.keyPassword = value
-		} else {
-			connInfo.keyPassword = ""
-		}
+		info.ca = m.CA
+		info.cert = m.Cert
+		info.key = m.Key
+		info.keyPassword = m.KeyPassword
 	}
-	connInfo.enableTLS = enableTLS
+	info.enableTLS = enableTLS
 	return nil
 }
 
 func parseRedisMetadata(config *ScalerConfig, parserFn redisAddressParser) (*redisMetadata, error) {
-	connInfo, err := parserFn(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
+	connInfo, err := parserFn(config)
 	if err != nil {
 		return nil, err
 	}
@@ -257,43 +284,13 @@ func parseRedisMetadata(config *ScalerConfig, parserFn redisAddressParser) (*red
 		connectionInfo: connInfo,
 	}
 
-	err = parseTLSConfigIntoConnectionInfo(config, &meta.connectionInfo)
-	if err != nil {
-		return nil, err
-	}
-
-	meta.listLength = defaultListLength
-	if val, ok := config.TriggerMetadata["listLength"]; ok {
-		listLength, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("list length parsing error: %w", err)
-		}
-		meta.listLength = listLength
-	}
-
-	meta.activationListLength = defaultActivationListLength
-	if val, ok := config.TriggerMetadata["activationListLength"]; ok {
-		activationListLength, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("activationListLength parsing error %w", err)
-		}
-		meta.activationListLength = activationListLength
-	}
-
-	if val, ok := config.TriggerMetadata["listName"]; ok {
-		meta.listName = val
-	} else {
+	if _, found := config.getParamValue("listName", []ParsingOrder{TriggerMetadata}); !found {
 		return nil, ErrRedisNoListName
 	}
-
-	meta.databaseIndex = defaultDBIdx
-	if val, ok := config.TriggerMetadata["databaseIndex"]; ok {
-		dbIndex, err := strconv.ParseInt(val, 10, 32)
-		if err != nil {
-			return nil, fmt.Errorf("databaseIndex: parsing error %w", err)
-		}
-		meta.databaseIndex = int(dbIndex)
+	if err := config.TypedConfig(&meta); err != nil {
+		return nil, err
 	}
+
 	meta.triggerIndex = config.TriggerIndex
 	return &meta, nil
 }
@@ -304,12 +301,12 @@ func (s *redisScaler) Close(context.Context) error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := util.NormalizeString(fmt.Sprintf("redis-%s", s.metadata.listName))
+	metricName := util.NormalizeString(fmt.Sprintf("redis-%s", s.metadata.ListName))
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.listLength),
+		Target: GetMetricTarget(s.metricType, s.metadata.ListLength),
 	}
 	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -328,178 +325,78 @@ func (s *redisScaler) GetMetricsAndActivity(ctx context.Context, metricName stri
 
 	metric := GenerateMetricInMili(metricName, float64(listLen))
 
-	return []external_metrics.ExternalMetricValue{metric}, listLen > s.metadata.activationListLength, nil
+	return []external_metrics.ExternalMetricValue{metric}, listLen > s.metadata.ActivationListLength, nil
 }
 
-func parseRedisAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
-	info := redisConnectionInfo{}
-	switch {
-	case authParams["address"] != "":
-		info.addresses = append(info.addresses, authParams["address"])
-	case metadata["address"] != "":
-		info.addresses = append(info.addresses, metadata["address"])
-	case metadata["addressFromEnv"] != "":
-		info.addresses = append(info.addresses, resolvedEnv[metadata["addressFromEnv"]])
-	default:
-		switch {
-		case authParams["host"] != "":
-			info.hosts = append(info.hosts, authParams["host"])
-		case metadata["host"] != "":
-			info.hosts = append(info.hosts, metadata["host"])
-		case metadata["hostFromEnv"] != "":
-			info.hosts = append(info.hosts, resolvedEnv[metadata["hostFromEnv"]])
-		}
-
-		switch {
-		case authParams["port"] != "":
-			info.ports = append(info.ports, authParams["port"])
-		case metadata["port"] != "":
-			info.ports = append(info.ports, metadata["port"])
-		case metadata["portFromEnv"] != "":
-			info.ports = append(info.ports, resolvedEnv[metadata["portFromEnv"]])
-		}
-
-		if len(info.hosts) != 0 && len(info.ports) != 0 {
-			info.addresses = append(info.addresses, net.JoinHostPort(info.hosts[0], info.ports[0]))
-		}
+func parseRedisAddress(config *ScalerConfig) (redisConnectionInfo, error) {
+	meta := redisConnectionMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
+		return redisConnectionInfo{}, err
 	}
 
-	if len(info.addresses) == 0 || len(info.addresses[0]) == 0 {
-		return info, fmt.Errorf("no address or host given. address should be in the format of host:port or you should set the host/port values")
+	info := redisConnectionInfo{
+		username: meta.Username,
+		password: meta.Password,
 	}
-
 	switch {
-	case authParams["username"] != "":
-		info.username = authParams["username"]
-	case metadata["username"] != "":
-		info.username = metadata["username"]
-	case metadata["usernameFromEnv"] != "":
-		info.username = resolvedEnv[metadata["usernameFromEnv"]]
-	}
-
-	if authParams["password"] != "" {
-		info.password = authParams["password"]
-	} else if metadata["passwordFromEnv"] != "" {
-		info.password = resolvedEnv[metadata["passwordFromEnv"]]
+	case meta.Address != "":
+		info.addresses = append(info.addresses, meta.Address)
+	case meta.Host != "" && meta.Port != "":
+		info.hosts = append(info.hosts, meta.Host)
+		info.ports = append(info.ports, meta.Port)
+		info.addresses = append(info.addresses, net.JoinHostPort(meta.Host, meta.Port))
+	default:
+		return info, fmt.Errorf("no address or host given. address should be in the format of host:port or you should set the host/port values")
 	}
 
-	return info, nil
+	return info, meta.redisTLSMetadata.connectionInfo(&info)
 }
 
-func parseRedisMultipleAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
-	info := redisConnectionInfo{}
+func parseRedisMultipleAddress(meta redisClusterConnectionMetadata) (redisConnectionInfo, error) {
+	info := redisConnectionInfo{
+		username: meta.Username,
+		password: meta.Password,
+	}
 	switch {
-	case authParams["addresses"] != "":
-		info.addresses = splitAndTrim(authParams["addresses"])
-	case metadata["addresses"] != "":
-		info.addresses = splitAndTrim(metadata["addresses"])
-	case metadata["addressesFromEnv"] != "":
-		info.addresses = splitAndTrim(resolvedEnv[metadata["addressesFromEnv"]])
-	default:
-		switch {
-		case authParams["hosts"] != "":
-			info.hosts = splitAndTrim(authParams["hosts"])
-		case metadata["hosts"] != "":
-			info.hosts = splitAndTrim(metadata["hosts"])
-		case metadata["hostsFromEnv"] != "":
-			info.hosts = splitAndTrim(resolvedEnv[metadata["hostsFromEnv"]])
+	case len(meta.Addresses) != 0:
+		info.addresses = meta.Addresses
+	case len(meta.Hosts) != 0 && len(meta.Ports) != 0:
+		if len(meta.Hosts) != len(meta.Ports) {
+			return info, ErrRedisUnequalHostsAndPorts
 		}
-
-		switch {
-		case authParams["ports"] != "":
-			info.ports = splitAndTrim(authParams["ports"])
-		case metadata["ports"] != "":
-			info.ports = splitAndTrim(metadata["ports"])
-		case metadata["portsFromEnv"] != "":
-			info.ports = splitAndTrim(resolvedEnv[metadata["portsFromEnv"]])
-		}
-
-		if len(info.hosts) != 0 && len(info.ports) != 0 {
-			if len(info.hosts) != len(info.ports) {
-				return info, ErrRedisUnequalHostsAndPorts
-			}
-			for i := range info.hosts {
-				info.addresses = append(info.addresses, net.JoinHostPort(info.hosts[i], info.ports[i]))
-			}
+		info.hosts = meta.Hosts
+		info.ports = meta.Ports
+		for i := range meta.Hosts {
+			info.addresses = append(info.addresses, net.JoinHostPort(meta.Hosts[i], meta.Ports[i]))
 		}
-	}
-
-	if len(info.addresses) == 0 {
+	default:
 		return info, ErrRedisNoAddresses
 	}
 
-	return info, nil
+	return info, meta.redisTLSMetadata.connectionInfo(&info)
 }
 
-func parseRedisClusterAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
-	info, err := parseRedisMultipleAddress(metadata, resolvedEnv, authParams)
-	if err != nil {
+func parseRedisClusterAddress(config *ScalerConfig) (redisConnectionInfo, error) {
+	meta := redisClusterConnectionMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
 		return redisConnectionInfo{}, err
 	}
-
-	switch {
-	case authParams["username"] != "":
-		info.username = authParams["username"]
-	case metadata["username"] != "":
-		info.username = metadata["username"]
-	case metadata["usernameFromEnv"] != "":
-		info.username = resolvedEnv[metadata["usernameFromEnv"]]
-	}
-
-	if authParams["password"] != "" {
-		info.password = authParams["password"]
-	} else if metadata["passwordFromEnv"] != "" {
-		info.password = resolvedEnv[metadata["passwordFromEnv"]]
-	}
-
-	return info, nil
+	return parseRedisMultipleAddress(meta)
 }
 
-func parseRedisSentinelAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
-	info, err := parseRedisMultipleAddress(metadata, resolvedEnv, authParams)
-	if err != nil {
+func parseRedisSentinelAddress(config *ScalerConfig) (redisConnectionInfo, error) {
+	meta := redisSentinelConnectionMetadata{}
+	if err := config.TypedConfig(&meta); err != nil {
 		return redisConnectionInfo{}, err
 	}
-
-	switch {
-	case authParams["username"] != "":
-		info.username = authParams["username"]
-	case metadata["username"] != "":
-		info.username = metadata["username"]
-	case metadata["usernameFromEnv"] != "":
-		info.username = resolvedEnv[metadata["usernameFromEnv"]]
-	}
-
-	if authParams["password"] != "" {
-		info.password = authParams["password"]
-	} else if metadata["passwordFromEnv"] != "" {
-		info.password = resolvedEnv[metadata["passwordFromEnv"]]
-	}
-
-	switch {
-	case authParams["sentinelUsername"] != "":
-		info.sentinelUsername = authParams["sentinelUsername"]
-	case metadata["sentinelUsername"] != "":
-		info.sentinelUsername = metadata["sentinelUsername"]
-	case metadata["sentinelUsernameFromEnv"] != "":
-		info.sentinelUsername = resolvedEnv[metadata["sentinelUsernameFromEnv"]]
-	}
-
-	if authParams["sentinelPassword"] != "" {
-		info.sentinelPassword = authParams["sentinelPassword"]
-	} else if metadata["sentinelPasswordFromEnv"] != "" {
-		info.sentinelPassword = resolvedEnv[metadata["sentinelPasswordFromEnv"]]
-	}
-
-	switch {
-	case authParams["sentinelMaster"] != "":
-		info.sentinelMaster = authParams["sentinelMaster"]
-	case metadata["sentinelMaster"] != "":
-		info.sentinelMaster = metadata["sentinelMaster"]
-	case metadata["sentinelMasterFromEnv"] != "":
-		info.sentinelMaster = resolvedEnv[metadata["sentinelMasterFromEnv"]]
+	info, err := parseRedisMultipleAddress(meta.redisClusterConnectionMetadata)
+	if err != nil {
+		return redisConnectionInfo{}, err
 	}
 
+	info.sentinelUsername = meta.SentinelUsername
+	info.sentinelPassword = meta.SentinelPassword
+	info.sentinelMaster = meta.SentinelMaster
 	return info, nil
 }
 
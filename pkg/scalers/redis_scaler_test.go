@@ -72,6 +72,13 @@ var testRedisMetadata = []parseRedisMetadataTestData{
 	// host only is defined in the authParams
 	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}, false}}
 
+func TestRedisParseMetadataNoListName(t *testing.T) {
+	config := &ScalerConfig{TriggerMetadata: map[string]string{"address": "localhost:6379"}}
+	_, err := parseRedisMetadata(config, parseRedisAddress)
+	assert.ErrorIs(t, err, ErrRedisNoListName)
+	assert.ErrorIs(t, err, ErrScalerConfigMissingField)
+}
+
 var redisMetricIdentifiers = []redisMetricIdentifier{
 	{&testRedisMetadata[1], 0, "s0-redis-mylist"},
 	{&testRedisMetadata[1], 1, "s1-redis-mylist"},
@@ -188,8 +195,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{":7001", ":7002"},
 				},
@@ -206,8 +213,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"ports": "1, 2, 3",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -227,8 +234,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"username": "username",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -248,8 +255,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -270,8 +277,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -292,8 +299,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"password": "password",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -314,8 +321,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -335,8 +342,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{":7001", ":7002"},
 					enableTLS: true,
@@ -356,8 +363,8 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{":7001", ":7002"},
 					enableTLS: true,
@@ -440,8 +447,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{":7001", ":7002"},
 				},
@@ -458,8 +465,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"ports": "1, 2, 3",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -478,8 +485,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"ports": "1, 2, 3",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -499,8 +506,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"username": "username",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -520,8 +527,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -542,8 +549,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -564,8 +571,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"password": "password",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -586,8 +593,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{"a:1", "b:2", "c:3"},
 					hosts:     []string{"a", "b", "c"},
@@ -608,8 +615,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"sentinelUsername": "sentinelUsername",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:        []string{"a:1", "b:2", "c:3"},
 					hosts:            []string{"a", "b", "c"},
@@ -629,8 +636,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:        []string{"a:1", "b:2", "c:3"},
 					hosts:            []string{"a", "b", "c"},
@@ -651,8 +658,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:        []string{"a:1", "b:2", "c:3"},
 					hosts:            []string{"a", "b", "c"},
@@ -673,8 +680,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"sentinelPassword": "sentinelPassword",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:        []string{"a:1", "b:2", "c:3"},
 					hosts:            []string{"a", "b", "c"},
@@ -695,8 +702,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:        []string{"a:1", "b:2", "c:3"},
 					hosts:            []string{"a", "b", "c"},
@@ -717,8 +724,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"sentinelMaster": "sentinelMaster",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:      []string{"a:1", "b:2", "c:3"},
 					hosts:          []string{"a", "b", "c"},
@@ -738,8 +745,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:      []string{"a:1", "b:2", "c:3"},
 					hosts:          []string{"a", "b", "c"},
@@ -760,8 +767,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses:      []string{"a:1", "b:2", "c:3"},
 					hosts:          []string{"a", "b", "c"},
@@ -781,8 +788,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{":7001", ":7002"},
 					enableTLS: true,
@@ -802,8 +809,8 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				listLength: 5,
-				listName:   "mylist",
+				ListLength: 5,
+				ListName:   "mylist",
 				connectionInfo: redisConnectionInfo{
 					addresses: []string{":7001", ":7002"},
 					enableTLS: true,
@@ -64,6 +64,43 @@ type redisStreamsMetadata struct {
 	activationLagCount        int64
 }
 
+// redisStreamsParameters are the trigger parameters of the redis streams scalers,
+// they are resolved into the redisStreamsMetadata of the scale factor in use
+type redisStreamsParameters struct {
+	StreamName          string `keda:"name=stream, order=triggerMetadata"`
+	ConsumerGroupName   string `keda:"name=consumerGroup, order=triggerMetadata, optional"`
+	PendingEntriesCount int64  `keda:"name=pendingEntriesCount, order=triggerMetadata, default=5"`
+	StreamLength        int64  `keda:"name=streamLength, order=triggerMetadata, default=5"`
+	LagCount            *int64 `keda:"name=lagCount, order=triggerMetadata, optional"`
+	ActivationLagCount  *int64 `keda:"name=activationLagCount, order=triggerMetadata, optional"`
+	DatabaseIndex       int    `keda:"name=databaseIndex, order=triggerMetadata, default=0"`
+}
+
+// Validate checks that the activation lag is given with the lag of a consumer group
+func (p *redisStreamsParameters) Validate() error {
+	if p.ConsumerGroupName != "" && p.LagCount != nil && p.ActivationLagCount == nil {
+		return fmt.Errorf("%w: activationLagCount required for Redis lag", ErrScalerConfigMissingField)
+	}
+	return nil
+}
+
+// redisStreamsTriggerMetadata, redisClusterStreamsTriggerMetadata and redisSentinelStreamsTriggerMetadata declare
+// all the parameters of the redis-streams, redis-cluster-streams and redis-sentinel-streams triggers in the scaler registry
+type (
+	redisStreamsTriggerMetadata struct {
+		redisStreamsParameters
+		redisConnectionMetadata
+	}
+	redisClusterStreamsTriggerMetadata struct {
+		redisStreamsParameters
+		redisClusterConnectionMetadata
+	}
+	redisSentinelStreamsTriggerMetadata struct {
+		redisStreamsParameters
+		redisSentinelConnectionMetadata
+	}
+)
+
 // NewRedisStreamsScaler creates a new redisStreamsScaler
 func NewRedisStreamsScaler(ctx context.Context, isClustered, isSentinel bool, config *ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -253,11 +290,11 @@ func createEntriesCountFn(client redis.Cmdable, meta *redisStreamsMetadata) (ent
 
 var (
 	// ErrRedisMissingStreamName is returned when "stream" is missing.
-	ErrRedisMissingStreamName = errors.New("missing redis stream name")
+	ErrRedisMissingStreamName = fmt.Errorf("%w: missing redis stream name", ErrScalerConfigMissingField)
 )
 
 func parseRedisStreamsMetadata(config *ScalerConfig, parseFn redisAddressParser) (*redisStreamsMetadata, error) {
-	connInfo, err := parseFn(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
+	connInfo, err := parseFn(config)
 	if err != nil {
 		return nil, err
 	}
@@ -265,69 +302,29 @@ func parseRedisStreamsMetadata(config *ScalerConfig, parseFn redisAddressParser)
 		connectionInfo: connInfo,
 	}
 
-	err = parseTLSConfigIntoConnectionInfo(config, &meta.connectionInfo)
-	if err != nil {
-		return nil, err
-	}
-
-	if val, ok := config.TriggerMetadata[streamNameMetadata]; ok {
-		meta.streamName = val
-	} else {
+	if _, found := config.getParamValue(streamNameMetadata, []ParsingOrder{TriggerMetadata}); !found {
 		return nil, ErrRedisMissingStreamName
 	}
-
+	params := redisStreamsParameters{}
+	if err := config.TypedConfig(&params); err != nil {
+		return nil, err
+	}
+	meta.streamName = params.StreamName
+	meta.consumerGroupName = params.ConsumerGroupName
+	meta.databaseIndex = params.DatabaseIndex
 	meta.activationLagCount = defaultActivationLagCount
 
-	if val, ok := config.TriggerMetadata[consumerGroupNameMetadata]; ok {
-		meta.consumerGroupName = val
-		if val, ok := config.TriggerMetadata[lagMetadata]; ok {
-			meta.scaleFactor = lagFactor
-			lag, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing lag: %w", err)
-			}
-			meta.targetLag = lag
-
-			if val, ok := config.TriggerMetadata[activationValueTriggerConfigName]; ok {
-				activationVal, err := strconv.ParseInt(val, 10, 64)
-				if err != nil {
-					return nil, errors.New("error while parsing activation lag value")
-				}
-				meta.activationLagCount = activationVal
-			} else {
-				err := errors.New("activationLagCount required for Redis lag")
-				return nil, err
-			}
-		} else {
-			meta.scaleFactor = xPendingFactor
-			meta.targetPendingEntriesCount = defaultTargetEntries
-			if val, ok := config.TriggerMetadata[pendingEntriesCountMetadata]; ok {
-				pendingEntriesCount, err := strconv.ParseInt(val, 10, 64)
-				if err != nil {
-					return nil, fmt.Errorf("error parsing pending entries count: %w", err)
-				}
-				meta.targetPendingEntriesCount = pendingEntriesCount
-			}
-		}
-	} else {
+	switch {
+	case params.ConsumerGroupName != "" && params.LagCount != nil:
+		meta.scaleFactor = lagFactor
+		meta.targetLag = *params.LagCount
+		meta.activationLagCount = *params.ActivationLagCount
+	case params.ConsumerGroupName != "":
+		meta.scaleFactor = xPendingFactor
+		meta.targetPendingEntriesCount = params.PendingEntriesCount
+	default:
 		meta.scaleFactor = xLengthFactor
-		meta.targetStreamLength = defaultTargetEntries
-		if val, ok := config.TriggerMetadata[streamLengthMetadata]; ok {
-			streamLength, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing stream length: %w", err)
-			}
-			meta.targetStreamLength = streamLength
-		}
-	}
-
-	meta.databaseIndex = defaultDBIndex
-	if val, ok := config.TriggerMetadata[databaseIndexMetadata]; ok {
-		dbIndex, err := strconv.ParseInt(val, 10, 32)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing redis database index %w", err)
-		}
-		meta.databaseIndex = int(dbIndex)
+		meta.targetStreamLength = params.StreamLength
 	}
 
 	meta.triggerIndex = config.TriggerIndex
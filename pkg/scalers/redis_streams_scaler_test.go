@@ -2053,3 +2053,14 @@ func TestActivityCount(t *testing.T) {
 		assert.Equal(t, isActive, true, "redis scaler should be active when lag is greater than activation")
 	})
 }
+
+func TestParseRedisStreamsMetadataMissingField(t *testing.T) {
+	config := &ScalerConfig{TriggerMetadata: map[string]string{"address": "localhost:6379"}, ResolvedEnv: map[string]string{}, AuthParams: map[string]string{}}
+	_, err := parseRedisStreamsMetadata(config, parseRedisAddress)
+	assert.ErrorIs(t, err, ErrRedisMissingStreamName)
+	assert.ErrorIs(t, err, ErrScalerConfigMissingField)
+
+	config.TriggerMetadata = map[string]string{"address": "localhost:6379", "stream": "my-stream", "consumerGroup": "consumer1", "lagCount": "5"}
+	_, err = parseRedisStreamsMetadata(config, parseRedisAddress)
+	assert.ErrorIs(t, err, ErrScalerConfigMissingField)
+}
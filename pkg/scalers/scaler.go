@@ -63,6 +63,15 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// WorkHintScaler interface is implemented by the scalers able to tell which work the jobs of a ScaledJob should pick up
+type WorkHintScaler interface {
+	Scaler
+
+	// GetMetricsActivityAndWorkHints returns the metric values and activity for a metric Name as GetMetricsAndActivity does,
+	// along with hints of the pending work, eg. the partitions with lag, ordered by priority
+	GetMetricsActivityAndWorkHints(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, []string, error)
+}
+
 // ScalerConfig contains config fields common for all scalers
 type ScalerConfig struct {
 	// ScalableObjectName specifies name of the ScaledObject/ScaledJob that owns this scaler
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScalerBuilderFunc builds a scaler from the trigger config
+type ScalerBuilderFunc func(ctx context.Context, client client.Client, config *ScalerConfig) (Scaler, error)
+
+// ScalerDefinition describes a trigger type supported by KEDA
+type ScalerDefinition struct {
+	// Name is the trigger type, as used in `spec.triggers[].type`
+	Name string
+	// Builder constructs the scaler
+	Builder ScalerBuilderFunc
+	// Metadata is the metadata struct decoded with TypedConfig, nil if the scaler parses its metadata by hand
+	Metadata any
+	// MetricTypes are the supported `spec.triggers[].metricType` values, defaults to AverageValue and Value
+	MetricTypes []v2.MetricTargetType
+	// IsPush is set when the scaler implements PushScaler
+	IsPush bool
+}
+
+var defaultMetricTypes = []v2.MetricTargetType{v2.AverageValueMetricType, v2.ValueMetricType}
+
+var resourceMetricTypes = []v2.MetricTargetType{v2.UtilizationMetricType, v2.AverageValueMetricType}
+
+var (
+	scalerDefinitions     = map[string]ScalerDefinition{}
+	scalerDefinitionsLock sync.RWMutex
+)
+
+func init() {
+	// TRIGGERS-START
+	for _, def := range []ScalerDefinition{
+		{Name: "activemq", Builder: withConfig(NewActiveMQScaler)},
+		{Name: "apache-kafka", Builder: withContext(NewApacheKafkaScaler)},
+		{Name: "arangodb", Builder: withConfig(NewArangoDBScaler)},
+		{Name: "artemis-queue", Builder: withConfig(NewArtemisQueueScaler)},
+		{Name: "aws-cloudwatch", Builder: withContext(NewAwsCloudwatchScaler)},
+		{Name: "aws-dynamodb", Builder: withContext(NewAwsDynamoDBScaler)},
+		{Name: "aws-dynamodb-streams", Builder: withContext(NewAwsDynamoDBStreamsScaler)},
+		{Name: "aws-kinesis-stream", Builder: withContext(NewAwsKinesisStreamScaler)},
+		{Name: "aws-sqs-queue", Builder: withContext(NewAwsSqsQueueScaler)},
+		{Name: "azure-app-insights", Builder: withConfig(NewAzureAppInsightsScaler)},
+		{Name: "azure-blob", Builder: withConfig(NewAzureBlobScaler)},
+		{Name: "azure-data-explorer", Builder: withConfig(NewAzureDataExplorerScaler)},
+		{Name: "azure-eventhub", Builder: withContext(NewAzureEventHubScaler)},
+		{Name: "azure-log-analytics", Builder: withConfig(NewAzureLogAnalyticsScaler)},
+		{Name: "azure-monitor", Builder: withConfig(NewAzureMonitorScaler)},
+		{Name: "azure-pipelines", Builder: withContext(NewAzurePipelinesScaler)},
+		{Name: "azure-queue", Builder: withConfig(NewAzureQueueScaler)},
+		{Name: "azure-servicebus", Builder: withContext(NewAzureServiceBusScaler)},
+		{Name: "cassandra", Builder: withConfig(NewCassandraScaler)},
+		{Name: "couchdb", Builder: withContext(NewCouchDBScaler)},
+		{Name: "cpu", Builder: withResource(corev1.ResourceCPU), MetricTypes: resourceMetricTypes},
+		{Name: "cron", Builder: withConfig(NewCronScaler)},
+		{Name: "datadog", Builder: withContext(NewDatadogScaler)},
+		{Name: "elasticsearch", Builder: withConfig(NewElasticsearchScaler)},
+		{Name: "etcd", Builder: withConfig(NewEtcdScaler), IsPush: true},
+		{Name: "external", Builder: withConfig(NewExternalScaler)},
+		// TODO: use other way for test.
+		{Name: "external-mock", Builder: withConfig(NewExternalMockScaler)},
+		{Name: "external-push", Builder: withConfig(NewExternalPushScaler), IsPush: true},
+		{Name: "gcp-cloudtasks", Builder: withConfig(NewGcpCloudTasksScaler)},
+		{Name: "gcp-pubsub", Builder: withConfig(NewPubSubScaler)},
+		{Name: "gcp-stackdriver", Builder: withContext(NewStackdriverScaler)},
+		{Name: "gcp-storage", Builder: withConfig(NewGcsScaler)},
+		{Name: "github-runner", Builder: withConfig(NewGitHubRunnerScaler)},
+		{Name: "graphite", Builder: withConfig(NewGraphiteScaler)},
+		{Name: "huawei-cloudeye", Builder: withConfig(NewHuaweiCloudeyeScaler)},
+		{Name: "ibmmq", Builder: withConfig(NewIBMMQScaler)},
+		{Name: "influxdb", Builder: withConfig(NewInfluxDBScaler)},
+		{Name: "kafka", Builder: withConfig(NewKafkaScaler), Metadata: kafkaMetadata{}},
+		{Name: "kubernetes-workload", Builder: withClient(NewKubernetesWorkloadScaler)},
+		{Name: "liiklus", Builder: withConfig(NewLiiklusScaler)},
+		{Name: "loki", Builder: withConfig(NewLokiScaler)},
+		{Name: "memory", Builder: withResource(corev1.ResourceMemory), MetricTypes: resourceMetricTypes},
+		{Name: "metrics-api", Builder: withConfig(NewMetricsAPIScaler)},
+		{Name: "mongodb", Builder: withContext(NewMongoDBScaler)},
+		{Name: "mssql", Builder: withConfig(NewMSSQLScaler)},
+		{Name: "mysql", Builder: withConfig(NewMySQLScaler)},
+		{Name: "nats-jetstream", Builder: withConfig(NewNATSJetStreamScaler)},
+		{Name: "new-relic", Builder: withConfig(NewNewRelicScaler)},
+		{Name: "openstack-metric", Builder: withContext(NewOpenstackMetricScaler)},
+		{Name: "openstack-swift", Builder: withConfig(NewOpenstackSwiftScaler)},
+		{Name: "postgresql", Builder: withConfig(NewPostgreSQLScaler), Metadata: postgreSQLMetadata{}},
+		{Name: "predictkube", Builder: withContext(NewPredictKubeScaler)},
+		{Name: "prometheus", Builder: withConfig(NewPrometheusScaler)},
+		{Name: "pulsar", Builder: withConfig(NewPulsarScaler)},
+		{Name: "rabbitmq", Builder: withConfig(NewRabbitMQScaler), Metadata: rabbitMQMetadata{}},
+		{Name: "redis", Builder: withRedis(false, false), Metadata: redisTriggerMetadata{}},
+		{Name: "redis-cluster", Builder: withRedis(true, false), Metadata: redisClusterTriggerMetadata{}},
+		{Name: "redis-cluster-streams", Builder: withRedisStreams(true, false), Metadata: redisClusterStreamsTriggerMetadata{}},
+		{Name: "redis-sentinel", Builder: withRedis(false, true), Metadata: redisSentinelTriggerMetadata{}},
+		{Name: "redis-sentinel-streams", Builder: withRedisStreams(false, true), Metadata: redisSentinelStreamsTriggerMetadata{}},
+		{Name: "redis-streams", Builder: withRedisStreams(false, false), Metadata: redisStreamsTriggerMetadata{}},
+		{Name: "selenium-grid", Builder: withConfig(NewSeleniumGridScaler)},
+		{Name: "solace-event-queue", Builder: withConfig(NewSolaceScaler)},
+		{Name: "solr", Builder: withConfig(NewSolrScaler)},
+		{Name: "stan", Builder: withConfig(NewStanScaler)},
+	} {
+		RegisterScaler(def)
+	}
+	// TRIGGERS-END
+}
+
+// RegisterScaler adds a trigger type to the registry, it panics if the definition is
+// invalid or the trigger type is already registered
+func RegisterScaler(def ScalerDefinition) {
+	if def.Name == "" || def.Builder == nil {
+		panic("scaler definition requires a name and a builder")
+	}
+	if def.Metadata != nil {
+		if _, err := TypedConfigParameters(def.Metadata); err != nil {
+			panic(fmt.Sprintf("invalid metadata for scaler %q: %s", def.Name, err))
+		}
+	}
+
+	scalerDefinitionsLock.Lock()
+	defer scalerDefinitionsLock.Unlock()
+	if _, exists := scalerDefinitions[def.Name]; exists {
+		panic(fmt.Sprintf("scaler %q is already registered", def.Name))
+	}
+	scalerDefinitions[def.Name] = def
+}
+
+// GetScalerDefinition returns the definition of the given trigger type
+func GetScalerDefinition(triggerType string) (ScalerDefinition, bool) {
+	scalerDefinitionsLock.RLock()
+	defer scalerDefinitionsLock.RUnlock()
+	def, ok := scalerDefinitions[triggerType]
+	return def, ok
+}
+
+// ListScalerDefinitions returns all the registered trigger types sorted by name
+func ListScalerDefinitions() []ScalerDefinition {
+	scalerDefinitionsLock.RLock()
+	defer scalerDefinitionsLock.RUnlock()
+	defs := make([]ScalerDefinition, 0, len(scalerDefinitions))
+	for _, def := range scalerDefinitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+	return defs
+}
+
+// BuildScaler builds a scaler for the given trigger type
+func BuildScaler(ctx context.Context, client client.Client, triggerType string, config *ScalerConfig) (Scaler, error) {
+	def, ok := GetScalerDefinition(triggerType)
+	if !ok {
+		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
+	}
+	return def.Builder(ctx, client, config)
+}
+
+// ValidatedTriggerTypes returns the sorted trigger types whose metadata is checked by ValidateTriggerMetadata
+func ValidatedTriggerTypes() []string {
+	var types []string
+	for _, def := range ListScalerDefinitions() {
+		if def.Metadata != nil {
+			types = append(types, def.Name)
+		}
+	}
+	return types
+}
+
+// ValidateTriggerMetadata checks a trigger against its scaler definition without building the scaler, so no
+// connection is made to the scaler source. Unknown parameters are rejected and values are decoded only for the
+// scalers which declare their metadata, listed by ValidatedTriggerTypes: kafka, postgresql, rabbitmq and the
+// redis and redis streams triggers. The metadata of the other triggers isn't checked, only their trigger type
+// and metricType are, and a warning tells so. It returns warnings for the deprecated parameters in use.
+func ValidateTriggerMetadata(triggerType string, metricType v2.MetricTargetType, metadata map[string]string) ([]string, error) {
+	def, ok := GetScalerDefinition(triggerType)
+	if !ok {
+		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
+	}
+	if metricType != "" && !slices.Contains(def.SupportedMetricTypes(), metricType) {
+		return nil, fmt.Errorf("metricType %q is not supported by %q scaler, allowed values are %v", metricType, triggerType, def.SupportedMetricTypes())
+	}
+	if def.Metadata == nil {
+		return []string{fmt.Sprintf("the metadata of the %q scaler isn't validated, unknown parameters and invalid values are only reported when the scaler is built", triggerType)}, nil
+	}
+
+	if unknown := def.unknownParameters(metadata); len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown metadata parameters %q for %q scaler", unknown, triggerType)
+	}
+
+	t := reflect.TypeOf(def.Metadata)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	config := &ScalerConfig{
+		TriggerMetadata: metadata,
+		ResolvedEnv:     map[string]string{},
+		AuthParams:      map[string]string{},
+		MetricType:      metricType,
+	}
+	return config.DryRunTypedConfig(reflect.New(t).Interface())
+}
+
+// unknownParameters returns the sorted metadata keys which aren't declared by the scaler
+func (d ScalerDefinition) unknownParameters(metadata map[string]string) []string {
+	known := map[string]bool{}
+	for _, p := range d.Parameters() {
+		if p.HasOrder(TriggerMetadata) {
+			known[p.Name] = true
+			for _, alias := range p.Deprecated {
+				known[alias] = true
+			}
+		}
+		if p.HasOrder(ResolvedEnv) {
+			known[p.Name+resolvedEnvKeySuffix] = true
+		}
+	}
+
+	var unknown []string
+	for key := range metadata {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Parameters returns the metadata parameters declared by the scaler, nil if the scaler doesn't use TypedConfig
+func (d ScalerDefinition) Parameters() []MetadataParameter {
+	if d.Metadata == nil {
+		return nil
+	}
+	// tags were validated when the scaler was registered
+	params, _ := TypedConfigParameters(d.Metadata)
+	return params
+}
+
+// AuthParams returns the names of the parameters the scaler reads from a TriggerAuthentication
+func (d ScalerDefinition) AuthParams() []string {
+	var authParams []string
+	for _, p := range d.Parameters() {
+		if p.HasOrder(AuthParams) {
+			authParams = append(authParams, p.Name)
+		}
+	}
+	return authParams
+}
+
+// SupportedMetricTypes returns the `metricType` values accepted by the scaler
+func (d ScalerDefinition) SupportedMetricTypes() []v2.MetricTargetType {
+	if len(d.MetricTypes) == 0 {
+		return defaultMetricTypes
+	}
+	return d.MetricTypes
+}
+
+func withConfig[T Scaler](fn func(*ScalerConfig) (T, error)) ScalerBuilderFunc {
+	return func(_ context.Context, _ client.Client, config *ScalerConfig) (Scaler, error) {
+		return asScaler(fn(config))
+	}
+}
+
+func withContext[T Scaler](fn func(context.Context, *ScalerConfig) (T, error)) ScalerBuilderFunc {
+	return func(ctx context.Context, _ client.Client, config *ScalerConfig) (Scaler, error) {
+		return asScaler(fn(ctx, config))
+	}
+}
+
+func withClient[T Scaler](fn func(client.Client, *ScalerConfig) (T, error)) ScalerBuilderFunc {
+	return func(_ context.Context, client client.Client, config *ScalerConfig) (Scaler, error) {
+		return asScaler(fn(client, config))
+	}
+}
+
+func withResource(resourceName corev1.ResourceName) ScalerBuilderFunc {
+	return func(_ context.Context, _ client.Client, config *ScalerConfig) (Scaler, error) {
+		return NewCPUMemoryScaler(resourceName, config)
+	}
+}
+
+func withRedis(isClustered, isSentinel bool) ScalerBuilderFunc {
+	return func(ctx context.Context, _ client.Client, config *ScalerConfig) (Scaler, error) {
+		return NewRedisScaler(ctx, isClustered, isSentinel, config)
+	}
+}
+
+func withRedisStreams(isClustered, isSentinel bool) ScalerBuilderFunc {
+	return func(ctx context.Context, _ client.Client, config *ScalerConfig) (Scaler, error) {
+		return NewRedisStreamsScaler(ctx, isClustered, isSentinel, config)
+	}
+}
+
+// asScaler avoids returning a non-nil Scaler wrapping a nil pointer when the constructor failed
+func asScaler[T Scaler](scaler T, err error) (Scaler, error) {
+	if err != nil {
+		return nil, err
+	}
+	return scaler, nil
+}
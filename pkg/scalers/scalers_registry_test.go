@@ -0,0 +1,209 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestScalerDefinitionsAreSorted(t *testing.T) {
+	defs := ListScalerDefinitions()
+	assert.NotEmpty(t, defs)
+	for i := 1; i < len(defs); i++ {
+		assert.Less(t, defs[i-1].Name, defs[i].Name)
+	}
+}
+
+func TestScalerDefinitionParameters(t *testing.T) {
+	for _, def := range ListScalerDefinitions() {
+		if def.Metadata == nil {
+			assert.Nil(t, def.Parameters(), def.Name)
+			continue
+		}
+		_, err := TypedConfigParameters(def.Metadata)
+		assert.NoError(t, err, def.Name)
+		assert.NotEmpty(t, def.Parameters(), def.Name)
+	}
+
+	def, ok := GetScalerDefinition("postgresql")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"connection", "host", "port", "userName", "dbName", "sslmode", "password"}, def.AuthParams())
+	assert.Equal(t, []v2.MetricTargetType{v2.AverageValueMetricType, v2.ValueMetricType}, def.SupportedMetricTypes())
+
+	def, ok = GetScalerDefinition("cpu")
+	assert.True(t, ok)
+	assert.Contains(t, def.SupportedMetricTypes(), v2.UtilizationMetricType)
+}
+
+func TestRegisterScalerDuplicate(t *testing.T) {
+	def, _ := GetScalerDefinition("cron")
+	assert.Panics(t, func() { RegisterScaler(def) })
+	assert.Panics(t, func() { RegisterScaler(ScalerDefinition{Name: "no-builder"}) })
+}
+
+func TestBuildScalerUnknownType(t *testing.T) {
+	_, err := BuildScaler(context.Background(), nil, "unknown", &ScalerConfig{})
+	assert.EqualError(t, err, "no scaler found for type: unknown")
+}
+
+func TestScalerMetadataJSONSchema(t *testing.T) {
+	def, _ := GetScalerDefinition("postgresql")
+	schema := ScalerMetadataJSONSchema(def)
+	assert.Equal(t, false, schema["additionalProperties"])
+	assert.Equal(t, true, schema[validatedSchemaKeyword])
+	assert.Equal(t, []any{map[string]any{"required": []string{"query"}}}, schema["allOf"])
+	properties := schema["properties"].(map[string]any)
+	assert.Contains(t, properties, "connectionFromEnv")
+	assert.Contains(t, properties, "activationTargetQueryValue")
+
+	def, _ = GetScalerDefinition("redis-sentinel")
+	schema = ScalerMetadataJSONSchema(def)
+	assert.Equal(t, false, schema["additionalProperties"])
+	properties = schema["properties"].(map[string]any)
+	assert.Contains(t, properties, "sentinelMasterFromEnv")
+	assert.Contains(t, properties, "addresses")
+	assert.NotContains(t, properties, "address")
+
+	def, _ = GetScalerDefinition("cron")
+	schema = ScalerMetadataJSONSchema(def)
+	assert.NotContains(t, schema, "properties")
+	assert.Equal(t, false, schema[validatedSchemaKeyword])
+	assert.Equal(t, "The cron scaler doesn't declare its metadata, its parameters are not validated", schema["description"])
+}
+
+func TestTriggersJSONSchema(t *testing.T) {
+	schema := TriggersJSONSchema()
+	defs := schema["$defs"].(map[string]any)
+	assert.Len(t, defs, len(ListScalerDefinitions()))
+	assert.Len(t, schema["allOf"], len(ListScalerDefinitions()))
+
+	for _, def := range ListScalerDefinitions() {
+		metadata := defs[def.Name].(map[string]any)
+		assert.Equal(t, def.Metadata != nil, metadata[validatedSchemaKeyword], def.Name)
+	}
+}
+
+func TestValidatedTriggerTypes(t *testing.T) {
+	// the admission webhook only checks the metadata of these triggers, keep ValidateTriggerMetadata doc in sync
+	assert.Equal(t, []string{
+		"kafka", "postgresql", "rabbitmq",
+		"redis", "redis-cluster", "redis-cluster-streams", "redis-sentinel", "redis-sentinel-streams", "redis-streams",
+	}, ValidatedTriggerTypes())
+}
+
+func TestValidateTriggerMetadata(t *testing.T) {
+	cases := []struct {
+		name         string
+		triggerType  string
+		metricType   v2.MetricTargetType
+		metadata     map[string]string
+		wantWarnings []string
+		wantErr      string
+		wantErrIs    error
+	}{
+		{
+			name:        "unknown trigger type",
+			triggerType: "unknown",
+			wantErr:     "no scaler found for type: unknown",
+		},
+		{
+			name:        "unsupported metricType",
+			triggerType: "cron",
+			metricType:  v2.UtilizationMetricType,
+			wantErr:     `metricType "Utilization" is not supported by "cron" scaler`,
+		},
+		{
+			name:         "scaler without typed metadata",
+			triggerType:  "cron",
+			metadata:     map[string]string{"timezone": "UTC", "strat": "0 * * * *"},
+			wantWarnings: []string{`the metadata of the "cron" scaler isn't validated, unknown parameters and invalid values are only reported when the scaler is built`},
+		},
+		{
+			name:         "scaler without typed metadata isn't decoded",
+			triggerType:  "cpu",
+			metadata:     map[string]string{"value": "not a number"},
+			wantWarnings: []string{`the metadata of the "cpu" scaler isn't validated, unknown parameters and invalid values are only reported when the scaler is built`},
+		},
+		{
+			name:        "unknown parameter",
+			triggerType: "rabbitmq",
+			metadata:    map[string]string{"queueName": "q", "host": "amqp://host", "queueLenght": "5"},
+			wantErr:     `unknown metadata parameters ["queueLenght"] for "rabbitmq" scaler`,
+		},
+		{
+			name:        "unparsable value",
+			triggerType: "postgresql",
+			metadata:    map[string]string{"query": "SELECT 1", "activationTargetQueryValue": "one"},
+			wantErr:     `unable to set parameter "activationTargetQueryValue" value "one"`,
+		},
+		{
+			name:        "missing required parameter",
+			triggerType: "postgresql",
+			metadata:    map[string]string{"targetQueryValue": "1"},
+			wantErrIs:   ErrScalerConfigMissingField,
+		},
+		{
+			name:        "parameters from the TriggerAuthentication or env are left for runtime",
+			triggerType: "postgresql",
+			metadata:    map[string]string{"query": "SELECT 1", "targetQueryValue": "1", "connectionFromEnv": "CONNECTION"},
+		},
+		{
+			name:        "kafka authentication parameters",
+			triggerType: "kafka",
+			metadata:    map[string]string{"bootstrapServers": "localhost:9092", "consumerGroup": "group", "sasl": "plaintext", "tls": "enable"},
+		},
+		{
+			name:        "kafka unknown parameter",
+			triggerType: "kafka",
+			metadata:    map[string]string{"bootstrapServers": "localhost:9092", "consumerGroup": "group", "partitionLimitaton": "1-4"},
+			wantErr:     `unknown metadata parameters ["partitionLimitaton"] for "kafka" scaler`,
+		},
+		{
+			name:        "redis connection parameters",
+			triggerType: "redis",
+			metadata:    map[string]string{"listName": "list", "hostFromEnv": "HOST", "port": "6379", "enableTLS": "true"},
+		},
+		{
+			name:        "redis parameter of another mode",
+			triggerType: "redis",
+			metadata:    map[string]string{"listName": "list", "addresses": "localhost:6379"},
+			wantErr:     `unknown metadata parameters ["addresses"] for "redis" scaler`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			warnings, err := ValidateTriggerMetadata(c.triggerType, c.metricType, c.metadata)
+			switch {
+			case c.wantErr != "":
+				assert.ErrorContains(t, err, c.wantErr)
+			case c.wantErrIs != nil:
+				assert.ErrorIs(t, err, c.wantErrIs)
+			default:
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.wantWarnings, warnings)
+		})
+	}
+}
+
+func TestDryRunTypedConfig(t *testing.T) {
+	sc := &ScalerConfig{TriggerMetadata: map[string]string{"min": "5", "max": "1"}}
+	_, err := sc.DryRunTypedConfig(&typedConfigValidatedMetadata{})
+	assert.EqualError(t, err, "min must be lower than max")
+
+	type runtimeMetadata struct {
+		Password string `keda:"name=password, order=authParams"`
+		Host     string `keda:"name=host, order=triggerMetadata;resolvedEnv"`
+	}
+	sc = &ScalerConfig{TriggerMetadata: map[string]string{"hostFromEnv": "HOST"}}
+	_, err = sc.DryRunTypedConfig(&runtimeMetadata{})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, sc.TypedConfig(&runtimeMetadata{}), ErrScalerConfigMissingField)
+
+	sc = &ScalerConfig{TriggerMetadata: map[string]string{}}
+	_, err = sc.DryRunTypedConfig(&runtimeMetadata{})
+	assert.ErrorIs(t, err, ErrScalerConfigMissingField)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// validatedSchemaKeyword tells if the metadata schema of a scaler lists its parameters,
+// it is false for the scalers which don't declare their metadata yet
+const validatedSchemaKeyword = "x-keda-validated"
+
+// patterns used to describe the values accepted by TypedConfig, metadata values are always strings
+const (
+	intValuePattern   = `^\s*[-+]?[0-9]+\s*$`
+	uintValuePattern  = `^\s*\+?[0-9]+\s*$`
+	floatValuePattern = `^\s*[-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?\s*$`
+	boolValuePattern  = `^\s*(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)\s*$`
+)
+
+// TriggersJSONSchema returns a JSON Schema describing `spec.triggers[]` for all the registered scalers.
+// Triggers are matched on their `type` and their `metadata` is validated against the scaler parameters,
+// scalers which don't declare their metadata accept any string values and are marked as unvalidated.
+func TriggersJSONSchema() map[string]any {
+	defs := ListScalerDefinitions()
+
+	types := make([]string, 0, len(defs))
+	metadataDefs := make(map[string]any, len(defs))
+	conditions := make([]any, 0, len(defs))
+	for _, def := range defs {
+		types = append(types, def.Name)
+		metadataDefs[def.Name] = ScalerMetadataJSONSchema(def)
+
+		metricTypes := []string{}
+		for _, mt := range def.SupportedMetricTypes() {
+			metricTypes = append(metricTypes, string(mt))
+		}
+		conditions = append(conditions, map[string]any{
+			"if": map[string]any{
+				"properties": map[string]any{"type": map[string]any{"const": def.Name}},
+				"required":   []string{"type"},
+			},
+			"then": map[string]any{
+				"properties": map[string]any{
+					"metadata":   map[string]any{"$ref": "#/$defs/" + def.Name},
+					"metricType": map[string]any{"enum": metricTypes},
+				},
+			},
+		})
+	}
+
+	return map[string]any{
+		"$schema":  jsonSchemaDraft,
+		"title":    "KEDA trigger",
+		"type":     "object",
+		"required": []string{"type", "metadata"},
+		"properties": map[string]any{
+			"type":             map[string]any{"type": "string", "enum": types},
+			"name":             map[string]any{"type": "string"},
+			"useCachedMetrics": map[string]any{"type": "boolean"},
+			"metadata":         map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"metricType":       map[string]any{"type": "string"},
+			"authenticationRef": map[string]any{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+					"kind": map[string]any{"type": "string", "enum": []string{"TriggerAuthentication", "ClusterTriggerAuthentication"}},
+				},
+			},
+		},
+		"allOf": conditions,
+		"$defs": metadataDefs,
+	}
+}
+
+// ScalerMetadataJSONSchema returns a JSON Schema describing `spec.triggers[].metadata` for the scaler.
+// The metadata of scalers which don't declare it is reported as unvalidated instead of being left empty.
+func ScalerMetadataJSONSchema(def ScalerDefinition) map[string]any {
+	if def.Metadata == nil {
+		return map[string]any{
+			"type":                 "object",
+			"description":          fmt.Sprintf("The %s scaler doesn't declare its metadata, its parameters are not validated", def.Name),
+			"additionalProperties": map[string]any{"type": "string"},
+			validatedSchemaKeyword: false,
+		}
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		validatedSchemaKeyword: true,
+	}
+
+	properties := map[string]any{}
+	var required []any
+	for _, p := range def.Parameters() {
+		if p.HasOrder(TriggerMetadata) {
+			properties[p.Name] = parameterJSONSchema(p)
+			for _, alias := range p.Deprecated {
+				aliasSchema := parameterJSONSchema(p)
+				aliasSchema["deprecated"] = true
+				properties[alias] = aliasSchema
+			}
+		}
+		if p.HasOrder(ResolvedEnv) {
+			properties[p.Name+resolvedEnvKeySuffix] = map[string]any{"type": "string"}
+		}
+
+		// parameters which can be supplied by a TriggerAuthentication can't be required in the metadata
+		if !p.IsRequired() || p.HasOrder(AuthParams) {
+			continue
+		}
+		var keys []string
+		if p.HasOrder(TriggerMetadata) {
+			keys = append(keys, p.Name)
+			keys = append(keys, p.Deprecated...)
+		}
+		if p.HasOrder(ResolvedEnv) {
+			keys = append(keys, p.Name+resolvedEnvKeySuffix)
+		}
+		if len(keys) == 1 {
+			required = append(required, map[string]any{"required": keys})
+			continue
+		}
+		anyOf := make([]any, 0, len(keys))
+		for _, key := range keys {
+			anyOf = append(anyOf, map[string]any{"required": []string{key}})
+		}
+		required = append(required, map[string]any{"anyOf": anyOf})
+	}
+
+	schema["properties"] = properties
+	if len(required) > 0 {
+		schema["allOf"] = required
+	}
+	return schema
+}
+
+func parameterJSONSchema(p MetadataParameter) map[string]any {
+	schema := map[string]any{"type": "string"}
+	if p.HasDefault {
+		schema["default"] = p.Default
+	}
+
+	t := indirectType(p.Type)
+	switch {
+	case t == durationType, t.Kind() == reflect.Slice, t.Kind() == reflect.Map:
+		// durations and comma separated values are only checked when decoded
+	case len(p.Enum) > 0:
+		schema["enum"] = p.Enum
+	default:
+		if pattern := valuePattern(t.Kind()); pattern != "" {
+			schema["pattern"] = pattern
+		}
+	}
+	return schema
+}
+
+func valuePattern(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return boolValuePattern
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intValuePattern
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uintValuePattern
+	case reflect.Float32, reflect.Float64:
+		return floatValuePattern
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,558 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsingOrder is a source from which a scaler metadata parameter can be read
+type ParsingOrder string
+
+const (
+	// TriggerMetadata reads the parameter from `spec.triggers[].metadata`
+	TriggerMetadata ParsingOrder = "triggerMetadata"
+	// ResolvedEnv reads the parameter from the scale target env, named by the `<name>FromEnv` metadata key
+	ResolvedEnv ParsingOrder = "resolvedEnv"
+	// AuthParams reads the parameter from the resolved TriggerAuthentication
+	AuthParams ParsingOrder = "authParams"
+)
+
+// allowedParsingOrders is the set of sources accepted by the `order` tag property
+var allowedParsingOrders = map[ParsingOrder]bool{
+	TriggerMetadata: true,
+	ResolvedEnv:     true,
+	AuthParams:      true,
+}
+
+// default parsing order when the `order` tag property is omitted
+var defaultParsingOrder = []ParsingOrder{TriggerMetadata}
+
+const (
+	// metadataTag is the struct tag used by TypedConfig
+	metadataTag = "keda"
+
+	// tag property names
+	nameTagProp       = "name"
+	orderTagProp      = "order"
+	defaultTagProp    = "default"
+	optionalTagProp   = "optional"
+	enumTagProp       = "enum"
+	rangeTagProp      = "range"
+	deprecatedTagProp = "deprecated"
+
+	// separators used within the tag
+	tagPropSeparator  = ","
+	tagKeyValueSep    = "="
+	tagListSeparator  = ";"
+	tagRangeSeparator = ":"
+
+	// separators used within the parameter values
+	valueListSeparator   = ","
+	valueKeyValueSep     = "="
+	resolvedEnvKeySuffix = "FromEnv"
+)
+
+// CustomValidator is an optional interface a typed config can implement to validate
+// relationships between its fields once all of them have been decoded
+type CustomValidator interface {
+	Validate() error
+}
+
+// MetadataParameter holds the parsed content of a `keda` struct tag
+type MetadataParameter struct {
+	FieldName string
+	// Type is the Go type of the tagged field
+	Type reflect.Type
+
+	Name       string
+	Order      []ParsingOrder
+	Default    string
+	HasDefault bool
+	Optional   bool
+	Enum       []string
+	Deprecated []string
+
+	RangeMin *float64
+	RangeMax *float64
+}
+
+// TypedConfig decodes TriggerMetadata, ResolvedEnv and AuthParams into the struct pointed to by typedConfig.
+// Every exported field tagged with `keda:"..."` is read from the sources listed in its `order` property, and
+// the tag properties declare defaults, optionality, allowed values, numeric ranges and deprecated aliases:
+//
+//	ListLength int64 `keda:"name=listLength, order=triggerMetadata, default=5, range=1:"`
+//	Password string  `keda:"name=password, order=authParams;resolvedEnv, optional"`
+//	Mode string      `keda:"name=mode, order=triggerMetadata, enum=QueueLength;MessageRate, deprecated=queueMode"`
+//
+// A missing required parameter is reported with ErrScalerConfigMissingField, so all scalers using
+// TypedConfig surface the same error. Optional pointer fields are left nil when the parameter isn't found,
+// to tell a missing parameter from an explicit zero value. All problems found are joined and returned together.
+func (c *ScalerConfig) TypedConfig(typedConfig any) error {
+	warnings, err := c.parseTypedConfig(typedConfig)
+	if len(warnings) > 0 {
+		logger := InitializeLogger(c, "typed_config")
+		for _, warning := range warnings {
+			logger.Info(warning)
+		}
+	}
+	return err
+}
+
+// parseTypedConfig does the actual decoding for TypedConfig and returns the deprecation warnings separately
+func (c *ScalerConfig) parseTypedConfig(typedConfig any) ([]string, error) {
+	p := &typedConfigParser{config: c}
+	err := p.parse(typedConfig)
+	return p.warnings, err
+}
+
+// DryRunTypedConfig decodes the trigger metadata into typedConfig like TypedConfig does, but without
+// the TriggerAuthentication and the scale target env, which are only known at runtime. Required
+// parameters which can come from those sources aren't reported as missing, and the Validate method
+// is skipped when any parameter which can come from them is left unset. It returns the deprecation warnings.
+func (c *ScalerConfig) DryRunTypedConfig(typedConfig any) ([]string, error) {
+	p := &typedConfigParser{config: c, dryRun: true}
+	err := p.parse(typedConfig)
+	return p.warnings, err
+}
+
+// typedConfigParser holds the state of a single TypedConfig decoding
+type typedConfigParser struct {
+	config *ScalerConfig
+	dryRun bool
+
+	warnings []string
+	// deferred is set when a parameter has been left for runtime during a dry run
+	deferred bool
+}
+
+func (p *typedConfigParser) parse(typedConfig any) error {
+	v := reflect.ValueOf(typedConfig)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("typedConfig must be a non-nil pointer to a struct, got %T", typedConfig)
+	}
+
+	if err := p.parseStruct(v.Elem()); err != nil {
+		return err
+	}
+
+	if validator, ok := typedConfig.(CustomValidator); ok && !p.deferred {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *typedConfigParser) parseStruct(v reflect.Value) error {
+	var errs []error
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup(metadataTag)
+		if !hasTag {
+			// untagged embedded structs are decoded in place, the exported fields of unexported ones are settable too
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := p.parseStruct(v.Field(i)); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			errs = append(errs, fmt.Errorf("field %q with %q tag must be exported", field.Name, metadataTag))
+			continue
+		}
+
+		params, err := parseParamTag(field.Name, tag)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		params.Type = field.Type
+
+		if err := p.setParam(v.Field(i), params); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TypedConfigParameters returns the parameters declared by the `keda` tags of typedConfig,
+// which is either a struct or a pointer to one, in field order
+func TypedConfigParameters(typedConfig any) ([]MetadataParameter, error) {
+	t := reflect.TypeOf(typedConfig)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typedConfig must be a struct or a pointer to a struct, got %T", typedConfig)
+	}
+	return typedConfigStructParameters(t)
+}
+
+func typedConfigStructParameters(t reflect.Type) ([]MetadataParameter, error) {
+	var params []MetadataParameter
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup(metadataTag)
+		if !hasTag {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				p, err := typedConfigStructParameters(field.Type)
+				params = append(params, p...)
+				if err != nil {
+					errs = append(errs, err)
+				}
+			}
+			continue
+		}
+		p, err := parseParamTag(field.Name, tag)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.Type = field.Type
+		params = append(params, p)
+	}
+	return params, errors.Join(errs...)
+}
+
+// IsRequired returns true when the parameter has neither a default nor is optional
+func (p MetadataParameter) IsRequired() bool {
+	return !p.HasDefault && !p.Optional
+}
+
+// HasOrder returns true when the parameter is read from the given source
+func (p MetadataParameter) HasOrder(order ParsingOrder) bool {
+	for _, o := range p.Order {
+		if o == order {
+			return true
+		}
+	}
+	return false
+}
+
+// setParam looks the parameter up in the configured sources and sets it on the field
+func (p *typedConfigParser) setParam(field reflect.Value, params MetadataParameter) error {
+	c := p.config
+	value, found := c.getParamValue(params.Name, params.Order)
+	if !found {
+		for _, alias := range params.Deprecated {
+			if value, found = c.getParamValue(alias, params.Order); found {
+				p.warnings = append(p.warnings, fmt.Sprintf("parameter %q is deprecated, use %q instead", alias, params.Name))
+				break
+			}
+		}
+	}
+
+	if !found {
+		// during a dry run the value may still be supplied at runtime, so Validate can't be trusted
+		runtimeParam := p.dryRun && p.isRuntimeParam(params)
+		if runtimeParam {
+			p.deferred = true
+		}
+		switch {
+		case params.HasDefault:
+			value = params.Default
+		case params.Optional, runtimeParam:
+			return nil
+		default:
+			return fmt.Errorf("%w: %q in %s", ErrScalerConfigMissingField, params.Name, params.orderString())
+		}
+	}
+
+	if len(params.Enum) > 0 {
+		if err := checkEnum(field, value, params); err != nil {
+			return err
+		}
+	}
+
+	if err := setFieldValue(field, value); err != nil {
+		return fmt.Errorf("unable to set parameter %q value %q: %w", params.Name, value, err)
+	}
+
+	if params.RangeMin != nil || params.RangeMax != nil {
+		if err := checkRange(field, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRuntimeParam returns true when the parameter can be supplied by a source which is only known at runtime
+func (p *typedConfigParser) isRuntimeParam(params MetadataParameter) bool {
+	if params.HasOrder(AuthParams) {
+		return true
+	}
+	return params.HasOrder(ResolvedEnv) && p.config.TriggerMetadata[params.Name+resolvedEnvKeySuffix] != ""
+}
+
+// getParamValue returns the first non empty value found for key following the given order
+func (c *ScalerConfig) getParamValue(key string, order []ParsingOrder) (string, bool) {
+	for _, source := range order {
+		var value string
+		switch source {
+		case TriggerMetadata:
+			value = c.TriggerMetadata[key]
+		case AuthParams:
+			value = c.AuthParams[key]
+		case ResolvedEnv:
+			if envKey := c.TriggerMetadata[key+resolvedEnvKeySuffix]; envKey != "" {
+				value = c.ResolvedEnv[envKey]
+			}
+		}
+		if strings.TrimSpace(value) != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (p MetadataParameter) orderString() string {
+	order := make([]string, 0, len(p.Order))
+	for _, o := range p.Order {
+		order = append(order, string(o))
+	}
+	return "[" + strings.Join(order, ", ") + "]"
+}
+
+func checkEnum(field reflect.Value, value string, params MetadataParameter) error {
+	elems := []string{strings.TrimSpace(value)}
+	if indirectType(field.Type()).Kind() == reflect.Slice {
+		elems = splitAndTrimBySep(value, valueListSeparator)
+	}
+	for _, elem := range elems {
+		allowed := false
+		for _, e := range params.Enum {
+			if elem == e {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("parameter %q value %q must be one of %v", params.Name, elem, params.Enum)
+		}
+	}
+	return nil
+}
+
+func checkRange(field reflect.Value, params MetadataParameter) error {
+	field = reflect.Indirect(field)
+	values := []reflect.Value{field}
+	if field.Kind() == reflect.Slice {
+		values = values[:0]
+		for i := 0; i < field.Len(); i++ {
+			values = append(values, field.Index(i))
+		}
+	}
+	for _, v := range values {
+		var num float64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			num = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			num = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			num = v.Float()
+		default:
+			return fmt.Errorf("parameter %q: range is only supported on numeric fields", params.Name)
+		}
+		if (params.RangeMin != nil && num < *params.RangeMin) || (params.RangeMax != nil && num > *params.RangeMax) {
+			return fmt.Errorf("parameter %q value %v must be in range %s", params.Name, num, params.rangeString())
+		}
+	}
+	return nil
+}
+
+func (p MetadataParameter) rangeString() string {
+	var lower, upper string
+	if p.RangeMin != nil {
+		lower = strconv.FormatFloat(*p.RangeMin, 'f', -1, 64)
+	}
+	if p.RangeMax != nil {
+		upper = strconv.FormatFloat(*p.RangeMax, 'f', -1, 64)
+	}
+	return "[" + lower + tagRangeSeparator + upper + "]"
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// indirectType returns the type pointed to by t if t is a pointer
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// setFieldValue converts value into the field's type and sets it
+func setFieldValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := setFieldValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(strings.TrimSpace(value), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		elems := splitAndTrimBySep(value, valueListSeparator)
+		slice := reflect.MakeSlice(field.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := setFieldValue(slice.Index(i), elem); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		field.Set(slice)
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %v, only map[string]string is supported", field.Type())
+		}
+		m := reflect.MakeMap(field.Type())
+		for _, pair := range splitAndTrimBySep(value, valueListSeparator) {
+			key, val, ok := strings.Cut(pair, valueKeyValueSep)
+			if !ok {
+				return fmt.Errorf("invalid map entry %q, must be of format key=value", pair)
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)).Convert(field.Type().Key()), reflect.ValueOf(strings.TrimSpace(val)).Convert(field.Type().Elem()))
+		}
+		field.Set(m)
+	default:
+		return fmt.Errorf("unsupported field type %v", field.Type())
+	}
+	return nil
+}
+
+// parseParamTag parses the content of a `keda` struct tag
+func parseParamTag(fieldName, tag string) (MetadataParameter, error) {
+	params := MetadataParameter{FieldName: fieldName}
+	for _, prop := range strings.Split(tag, tagPropSeparator) {
+		prop = strings.TrimSpace(prop)
+		if prop == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(prop, tagKeyValueSep)
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case nameTagProp:
+			params.Name = value
+		case orderTagProp:
+			for _, o := range strings.Split(value, tagListSeparator) {
+				order := ParsingOrder(strings.TrimSpace(o))
+				if !allowedParsingOrders[order] {
+					return params, fmt.Errorf("field %q: unknown parsing order %q", fieldName, order)
+				}
+				params.Order = append(params.Order, order)
+			}
+		case defaultTagProp:
+			params.Default = value
+			params.HasDefault = true
+		case optionalTagProp:
+			params.Optional = value == "" || value == "true"
+		case enumTagProp:
+			params.Enum = splitTagList(value)
+		case deprecatedTagProp:
+			params.Deprecated = splitTagList(value)
+		case rangeTagProp:
+			lower, upper, ok := strings.Cut(value, tagRangeSeparator)
+			if !ok {
+				return params, fmt.Errorf("field %q: range %q must be of format min:max", fieldName, value)
+			}
+			var err error
+			if params.RangeMin, err = parseRangeBound(lower); err != nil {
+				return params, fmt.Errorf("field %q: %w", fieldName, err)
+			}
+			if params.RangeMax, err = parseRangeBound(upper); err != nil {
+				return params, fmt.Errorf("field %q: %w", fieldName, err)
+			}
+		default:
+			return params, fmt.Errorf("field %q: unknown tag property %q", fieldName, key)
+		}
+	}
+	if params.Name == "" {
+		return params, fmt.Errorf("field %q: missing %q tag property", fieldName, nameTagProp)
+	}
+	if len(params.Order) == 0 {
+		params.Order = defaultParsingOrder
+	}
+	return params, nil
+}
+
+func parseRangeBound(bound string) (*float64, error) {
+	bound = strings.TrimSpace(bound)
+	if bound == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range bound %q: %w", bound, err)
+	}
+	return &f, nil
+}
+
+func splitTagList(value string) []string {
+	var list []string
+	for _, elem := range strings.Split(value, tagListSeparator) {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			list = append(list, elem)
+		}
+	}
+	return list
+}
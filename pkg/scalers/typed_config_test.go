@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedConfigTestMetadata struct {
+	StringVal   string            `keda:"name=stringVal, order=triggerMetadata"`
+	IntVal      int64             `keda:"name=intVal, order=triggerMetadata, default=5, range=1:10"`
+	FloatVal    float64           `keda:"name=floatVal, order=triggerMetadata, optional"`
+	BoolVal     bool              `keda:"name=boolVal, order=triggerMetadata, optional"`
+	DurationVal time.Duration     `keda:"name=durationVal, order=triggerMetadata, default=30s"`
+	EnumVal     string            `keda:"name=enumVal, order=triggerMetadata, default=a, enum=a;b"`
+	SliceVal    []int             `keda:"name=sliceVal, order=triggerMetadata, optional, range=0:"`
+	MapVal      map[string]string `keda:"name=mapVal, order=triggerMetadata, optional"`
+	AliasVal    string            `keda:"name=aliasVal, order=triggerMetadata, optional, deprecated=oldAliasVal"`
+	SecretVal   string            `keda:"name=secretVal, order=authParams;resolvedEnv;triggerMetadata, optional"`
+}
+
+type typedConfigValidatedMetadata struct {
+	Min int `keda:"name=min, order=triggerMetadata"`
+	Max int `keda:"name=max, order=triggerMetadata"`
+}
+
+func (m *typedConfigValidatedMetadata) Validate() error {
+	if m.Min > m.Max {
+		return errors.New("min must be lower than max")
+	}
+	return nil
+}
+
+func TestTypedConfig(t *testing.T) {
+	sc := &ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"stringVal":        "value",
+			"intVal":           "7",
+			"floatVal":         "1.5",
+			"boolVal":          "true",
+			"sliceVal":         "1, 2,3",
+			"mapVal":           "a=1,b=2",
+			"oldAliasVal":      "alias",
+			"secretValFromEnv": "SECRET",
+			"secretVal":        "fromMetadata",
+		},
+		ResolvedEnv: map[string]string{"SECRET": "fromEnv"},
+		AuthParams:  map[string]string{},
+	}
+
+	meta := typedConfigTestMetadata{}
+	warnings, err := sc.parseTypedConfig(&meta)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`parameter "oldAliasVal" is deprecated, use "aliasVal" instead`}, warnings)
+	assert.Equal(t, typedConfigTestMetadata{
+		StringVal:   "value",
+		IntVal:      7,
+		FloatVal:    1.5,
+		BoolVal:     true,
+		DurationVal: 30 * time.Second,
+		EnumVal:     "a",
+		SliceVal:    []int{1, 2, 3},
+		MapVal:      map[string]string{"a": "1", "b": "2"},
+		AliasVal:    "alias",
+		SecretVal:   "fromEnv",
+	}, meta)
+
+	sc.AuthParams["secretVal"] = "fromAuth"
+	meta = typedConfigTestMetadata{}
+	assert.NoError(t, sc.TypedConfig(&meta))
+	assert.Equal(t, "fromAuth", meta.SecretVal)
+}
+
+func TestTypedConfigErrors(t *testing.T) {
+	cases := []struct {
+		name      string
+		metadata  map[string]string
+		wantErrIs error
+		wantErr   string
+	}{
+		{
+			name:      "missing required field",
+			metadata:  map[string]string{},
+			wantErrIs: ErrScalerConfigMissingField,
+			wantErr:   `missing required field in scaler config: "stringVal" in [triggerMetadata]`,
+		},
+		{
+			name:      "invalid number",
+			metadata:  map[string]string{"stringVal": "value", "intVal": "AA"},
+			wantErrIs: strconv.ErrSyntax,
+			wantErr:   `unable to set parameter "intVal" value "AA"`,
+		},
+		{
+			name:     "out of range",
+			metadata: map[string]string{"stringVal": "value", "intVal": "11"},
+			wantErr:  `parameter "intVal" value 11 must be in range [1:10]`,
+		},
+		{
+			name:     "slice element out of range",
+			metadata: map[string]string{"stringVal": "value", "sliceVal": "1,-1"},
+			wantErr:  `parameter "sliceVal" value -1 must be in range [0:]`,
+		},
+		{
+			name:     "not in enum",
+			metadata: map[string]string{"stringVal": "value", "enumVal": "c"},
+			wantErr:  `parameter "enumVal" value "c" must be one of [a b]`,
+		},
+		{
+			name:     "invalid map",
+			metadata: map[string]string{"stringVal": "value", "mapVal": "a"},
+			wantErr:  `invalid map entry "a"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sc := &ScalerConfig{TriggerMetadata: c.metadata}
+			err := sc.TypedConfig(&typedConfigTestMetadata{})
+			assert.ErrorContains(t, err, c.wantErr)
+			if c.wantErrIs != nil {
+				assert.ErrorIs(t, err, c.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestTypedConfigJoinsErrors(t *testing.T) {
+	sc := &ScalerConfig{TriggerMetadata: map[string]string{"intVal": "0", "enumVal": "c"}}
+	err := sc.TypedConfig(&typedConfigTestMetadata{})
+	assert.ErrorIs(t, err, ErrScalerConfigMissingField)
+	assert.ErrorContains(t, err, `"intVal"`)
+	assert.ErrorContains(t, err, `"enumVal"`)
+}
+
+func TestTypedConfigValidate(t *testing.T) {
+	sc := &ScalerConfig{TriggerMetadata: map[string]string{"min": "5", "max": "1"}}
+	err := sc.TypedConfig(&typedConfigValidatedMetadata{})
+	assert.EqualError(t, err, "min must be lower than max")
+
+	sc.TriggerMetadata["max"] = "10"
+	assert.NoError(t, sc.TypedConfig(&typedConfigValidatedMetadata{}))
+}
+
+func TestTypedConfigInvalidTag(t *testing.T) {
+	type invalidMetadata struct {
+		Value string `keda:"name=value, order=somewhere"`
+	}
+	sc := &ScalerConfig{TriggerMetadata: map[string]string{"value": "a"}}
+	assert.ErrorContains(t, sc.TypedConfig(&invalidMetadata{}), `unknown parsing order "somewhere"`)
+	assert.Error(t, sc.TypedConfig(invalidMetadata{}))
+}
+
+type typedConfigCommonMetadata struct {
+	Common string `keda:"name=common, order=triggerMetadata"`
+}
+
+type typedConfigEmbeddingMetadata struct {
+	typedConfigCommonMetadata
+	Target *float64 `keda:"name=target, order=triggerMetadata, optional, range=0:"`
+}
+
+func TestTypedConfigEmbeddedStructAndPointer(t *testing.T) {
+	sc := &ScalerConfig{TriggerMetadata: map[string]string{"common": "value", "target": "0"}}
+	meta := typedConfigEmbeddingMetadata{}
+	assert.NoError(t, sc.TypedConfig(&meta))
+	assert.Equal(t, "value", meta.Common)
+	if assert.NotNil(t, meta.Target, "an explicit zero is set") {
+		assert.Equal(t, 0.0, *meta.Target)
+	}
+
+	sc.TriggerMetadata = map[string]string{"common": "value"}
+	meta = typedConfigEmbeddingMetadata{}
+	assert.NoError(t, sc.TypedConfig(&meta))
+	assert.Nil(t, meta.Target, "a missing optional parameter is left nil")
+
+	sc.TriggerMetadata = map[string]string{"target": "-1"}
+	err := sc.TypedConfig(&typedConfigEmbeddingMetadata{})
+	assert.ErrorIs(t, err, ErrScalerConfigMissingField)
+	assert.ErrorContains(t, err, `parameter "target" value -1 must be in range [0:]`)
+
+	params, err := TypedConfigParameters(typedConfigEmbeddingMetadata{})
+	assert.NoError(t, err)
+	assert.Len(t, params, 2)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"sort"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// triggerActivity is the activity of a single trigger evaluated by the activation policy
+type triggerActivity struct {
+	name     string
+	key      string
+	isVeto   bool
+	isActive bool
+}
+
+// evaluateActivationPolicy combines the activity of the triggers according to the activation policy,
+// it returns whether the ScaledObject is active and the names of the triggers which decided it:
+// the active veto triggers if any of them forces the ScaledObject inactive, the active triggers otherwise
+func evaluateActivationPolicy(policy *kedav1alpha1.ActivationPolicy, triggers []triggerActivity) (bool, []string) {
+	var vetoes, active []string
+	counted := 0
+	for _, trigger := range triggers {
+		switch {
+		case trigger.isVeto:
+			if trigger.isActive {
+				vetoes = append(vetoes, trigger.name)
+			}
+		case trigger.isActive:
+			active = append(active, trigger.name)
+			counted++
+		default:
+			counted++
+		}
+	}
+	if len(vetoes) > 0 {
+		sort.Strings(vetoes)
+		return false, vetoes
+	}
+	sort.Strings(active)
+
+	switch policy.GetMode() {
+	case kedav1alpha1.ActivationPolicyAll:
+		return len(active) > 0 && len(active) == counted, active
+	case kedav1alpha1.ActivationPolicyQuorum:
+		quorum := 1
+		if policy.Quorum != nil {
+			quorum = int(*policy.Quorum)
+		}
+		return len(active) >= quorum, active
+	default:
+		return len(active) > 0, active
+	}
+}
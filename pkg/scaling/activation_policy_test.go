@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestEvaluateActivationPolicy(t *testing.T) {
+	queueAndDatabase := []triggerActivity{
+		{name: "queue", isActive: true},
+		{name: "database", isActive: false},
+		{name: "cache", isActive: true},
+	}
+
+	tests := []struct {
+		name               string
+		policy             *kedav1alpha1.ActivationPolicy
+		triggers           []triggerActivity
+		expectedActive     bool
+		expectedActivation []string
+	}{
+		{
+			name:               "any by default",
+			policy:             &kedav1alpha1.ActivationPolicy{},
+			triggers:           queueAndDatabase,
+			expectedActive:     true,
+			expectedActivation: []string{"cache", "queue"},
+		},
+		{
+			name:           "any without active trigger",
+			policy:         &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyAny},
+			triggers:       []triggerActivity{{name: "queue"}},
+			expectedActive: false,
+		},
+		{
+			name:               "all with an inactive trigger",
+			policy:             &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyAll},
+			triggers:           queueAndDatabase,
+			expectedActive:     false,
+			expectedActivation: []string{"cache", "queue"},
+		},
+		{
+			name:               "all triggers active",
+			policy:             &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyAll},
+			triggers:           []triggerActivity{{name: "queue", isActive: true}, {name: "database", isActive: true}},
+			expectedActive:     true,
+			expectedActivation: []string{"database", "queue"},
+		},
+		{
+			name:               "quorum reached",
+			policy:             &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyQuorum, Quorum: ptr.To[int32](2)},
+			triggers:           queueAndDatabase,
+			expectedActive:     true,
+			expectedActivation: []string{"cache", "queue"},
+		},
+		{
+			name:               "quorum not reached",
+			policy:             &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyQuorum, Quorum: ptr.To[int32](3)},
+			triggers:           queueAndDatabase,
+			expectedActive:     false,
+			expectedActivation: []string{"cache", "queue"},
+		},
+		{
+			name:               "active veto trigger forces inactive",
+			policy:             &kedav1alpha1.ActivationPolicy{VetoTriggers: []string{"maintenance"}},
+			triggers:           append([]triggerActivity{{name: "maintenance", isVeto: true, isActive: true}}, queueAndDatabase...),
+			expectedActive:     false,
+			expectedActivation: []string{"maintenance"},
+		},
+		{
+			name:               "inactive veto trigger is not counted",
+			policy:             &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyAll, VetoTriggers: []string{"maintenance"}},
+			triggers:           []triggerActivity{{name: "maintenance", isVeto: true}, {name: "queue", isActive: true}},
+			expectedActive:     true,
+			expectedActivation: []string{"queue"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			isActive, activationTriggers := evaluateActivationPolicy(test.policy, test.triggers)
+			assert.Equal(t, test.expectedActive, isActive)
+			assert.Equal(t, test.expectedActivation, activationTriggers)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// maxMetricsHistorySamples bounds the number of values kept per trigger
+const maxMetricsHistorySamples = 1024
+
+// MetricSample is a trigger value observed at the given time
+type MetricSample struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// MetricsHistory keeps the recent values of the ScaledObject triggers, used by
+// the scalingModifiers window functions. Values older than
+// kedav1alpha1.ScalingModifiersMaxWindow are dropped.
+type MetricsHistory struct {
+	samples map[string][]MetricSample
+	lock    *sync.RWMutex
+}
+
+func NewMetricsHistory() *MetricsHistory {
+	return &MetricsHistory{
+		samples: map[string][]MetricSample{},
+		lock:    &sync.RWMutex{},
+	}
+}
+
+// Record stores the trigger value observed at the given time
+func (h *MetricsHistory) Record(trigger string, value float64, timestamp time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	samples := append(h.samples[trigger], MetricSample{Timestamp: timestamp, Value: value})
+
+	// drop samples which are too old or over the limit
+	oldest := timestamp.Add(-kedav1alpha1.ScalingModifiersMaxWindow)
+	drop := 0
+	for drop < len(samples) && (samples[drop].Timestamp.Before(oldest) || len(samples)-drop > maxMetricsHistorySamples) {
+		drop++
+	}
+	h.samples[trigger] = samples[drop:]
+}
+
+// Window returns the trigger values observed in the window ending at the given time,
+// ordered from the oldest one
+func (h *MetricsHistory) Window(trigger string, window time.Duration, end time.Time) []MetricSample {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	start := end.Add(-window)
+	var result []MetricSample
+	for _, sample := range h.samples[trigger] {
+		if !sample.Timestamp.Before(start) && !sample.Timestamp.After(end) {
+			result = append(result, sample)
+		}
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestMetricsHistoryWindow(t *testing.T) {
+	history := NewMetricsHistory()
+	now := time.Now()
+
+	history.Record("lag", 1, now.Add(-10*time.Minute))
+	history.Record("lag", 2, now.Add(-4*time.Minute))
+	history.Record("lag", 3, now.Add(-1*time.Minute))
+	history.Record("requests", 10, now)
+
+	assert.Equal(t, []MetricSample{
+		{Timestamp: now.Add(-4 * time.Minute), Value: 2},
+		{Timestamp: now.Add(-1 * time.Minute), Value: 3},
+	}, history.Window("lag", 5*time.Minute, now))
+	assert.Len(t, history.Window("lag", time.Hour, now), 3)
+	assert.Len(t, history.Window("requests", time.Minute, now), 1)
+	assert.Empty(t, history.Window("unknown", time.Minute, now))
+}
+
+func TestMetricsHistoryIsBounded(t *testing.T) {
+	history := NewMetricsHistory()
+	now := time.Now()
+
+	history.Record("lag", 1, now.Add(-kedav1alpha1.ScalingModifiersMaxWindow-time.Second))
+	history.Record("lag", 2, now)
+	assert.Equal(t, []MetricSample{{Timestamp: now, Value: 2}}, history.Window("lag", kedav1alpha1.ScalingModifiersMaxWindow, now))
+
+	for i := 0; i < 2*maxMetricsHistorySamples; i++ {
+		history.Record("requests", float64(i), now.Add(time.Duration(i)*time.Millisecond))
+	}
+	samples := history.Window("requests", kedav1alpha1.ScalingModifiersMaxWindow, now.Add(time.Minute))
+	assert.Len(t, samples, maxMetricsHistorySamples)
+	assert.Equal(t, float64(maxMetricsHistorySamples), samples[0].Value)
+}
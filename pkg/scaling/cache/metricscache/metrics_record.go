@@ -2,6 +2,7 @@ package metricscache
 
 import (
 	"sync"
+	"time"
 
 	"k8s.io/metrics/pkg/apis/external_metrics"
 )
@@ -10,6 +11,8 @@ type MetricsRecord struct {
 	IsActive    bool
 	Metric      []external_metrics.ExternalMetricValue
 	ScalerError error
+	// StoredAt is when the record was stored, it is set by the cache
+	StoredAt time.Time
 }
 
 type MetricsCache struct {
@@ -32,10 +35,31 @@ func (mc *MetricsCache) ReadRecord(scaledObjectIdentifier, metricName string) (M
 	return record, ok
 }
 
+// StoreRecords replaces the records of the scaledObject with a copy of metricsRecords, the caller's map isn't modified
 func (mc *MetricsCache) StoreRecords(scaledObjectIdentifier string, metricsRecords map[string]MetricsRecord) {
+	now := time.Now()
+	records := make(map[string]MetricsRecord, len(metricsRecords))
+	for metricName, metricsRecord := range metricsRecords {
+		if metricsRecord.StoredAt.IsZero() {
+			metricsRecord.StoredAt = now
+		}
+		records[metricName] = metricsRecord
+	}
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+	mc.metricRecords[scaledObjectIdentifier] = records
+}
+
+func (mc *MetricsCache) StoreRecord(scaledObjectIdentifier, metricName string, metricsRecord MetricsRecord) {
+	if metricsRecord.StoredAt.IsZero() {
+		metricsRecord.StoredAt = time.Now()
+	}
 	mc.lock.Lock()
 	defer mc.lock.Unlock()
-	mc.metricRecords[scaledObjectIdentifier] = metricsRecords
+	if _, ok := mc.metricRecords[scaledObjectIdentifier]; !ok {
+		mc.metricRecords[scaledObjectIdentifier] = map[string]MetricsRecord{}
+	}
+	mc.metricRecords[scaledObjectIdentifier][metricName] = metricsRecord
 }
 
 func (mc *MetricsCache) Delete(scaledObjectIdentifier string) {
@@ -43,3 +67,17 @@ func (mc *MetricsCache) Delete(scaledObjectIdentifier string) {
 	defer mc.lock.Unlock()
 	delete(mc.metricRecords, scaledObjectIdentifier)
 }
+
+// Records returns a copy of the records of the cache by identifier and metric name
+func (mc *MetricsCache) Records() map[string]map[string]MetricsRecord {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+	records := make(map[string]map[string]MetricsRecord, len(mc.metricRecords))
+	for identifier, metricsRecords := range mc.metricRecords {
+		records[identifier] = make(map[string]MetricsRecord, len(metricsRecords))
+		for metricName, metricsRecord := range metricsRecords {
+			records[identifier][metricName] = metricsRecord
+		}
+	}
+	return records
+}
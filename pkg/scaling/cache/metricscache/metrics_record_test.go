@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreRecordsCopiesRecords(t *testing.T) {
+	storedAt := time.Now().Add(-time.Minute)
+	metricsRecords := map[string]MetricsRecord{
+		"queue":   {IsActive: true},
+		"backlog": {IsActive: false, StoredAt: storedAt},
+	}
+
+	cache := NewMetricsCache()
+	cache.StoreRecords("scaledobject.keda.test", metricsRecords)
+
+	// the caller's records aren't stamped
+	assert.True(t, metricsRecords["queue"].StoredAt.IsZero())
+	assert.Equal(t, storedAt, metricsRecords["backlog"].StoredAt)
+
+	record, ok := cache.ReadRecord("scaledobject.keda.test", "queue")
+	assert.True(t, ok)
+	assert.False(t, record.StoredAt.IsZero())
+	record, ok = cache.ReadRecord("scaledobject.keda.test", "backlog")
+	assert.True(t, ok)
+	assert.Equal(t, storedAt, record.StoredAt)
+
+	// changing the caller's map doesn't change the cache
+	delete(metricsRecords, "queue")
+	_, ok = cache.ReadRecord("scaledobject.keda.test", "queue")
+	assert.True(t, ok)
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups="",namespace=keda,resources=configmaps;secrets,verbs=get;create;update
+
+const (
+	// PersistenceConfigMap persists the metrics caches in a ConfigMap
+	PersistenceConfigMap = "configmap"
+	// PersistenceSecret persists the metrics caches in a Secret
+	PersistenceSecret = "secret"
+
+	// snapshotKey is the key of the snapshot in the ConfigMap or Secret
+	snapshotKey = "metrics-cache.json"
+	// maxSnapshotSize is the size of the largest snapshot which can be persisted, the limit of a ConfigMap or Secret is 1MiB
+	maxSnapshotSize = 1000 * 1024
+)
+
+var log = logf.Log.WithName("metrics_cache_persister")
+
+// PersisterOptions configures where and how often the metrics caches are persisted
+type PersisterOptions struct {
+	// Backend is PersistenceConfigMap or PersistenceSecret
+	Backend   string
+	Namespace string
+	Name      string
+	// Interval is the interval the caches are saved at, they are saved on shutdown too
+	Interval time.Duration
+	// MaxStaleness is the age above which the persisted records are not restored
+	MaxStaleness time.Duration
+}
+
+// Persister saves the metrics caches of the scale handler to a ConfigMap or Secret and restores them when
+// the operator starts or becomes the leader, so that the cached metrics and the last known metrics of the
+// fallback survive the restarts. The records older than the maximum staleness are not restored.
+type Persister struct {
+	client  client.Client
+	reader  client.Reader
+	options PersisterOptions
+	now     func() time.Time
+
+	lock   sync.Mutex
+	caches map[string]MetricsCache
+	// restoredAt holds the time the restored records of each ScaledObject or ScaledJob were stored at,
+	// until it is read by the scale loop
+	restoredAt map[string]time.Time
+	restored   chan struct{}
+}
+
+// snapshot is the content persisted in the ConfigMap or Secret
+type snapshot struct {
+	SavedAt metav1.Time                  `json:"savedAt"`
+	Caches  map[string][]persistedRecord `json:"caches"`
+}
+
+type persistedRecord struct {
+	Identifier string                                 `json:"identifier"`
+	MetricName string                                 `json:"metricName"`
+	IsActive   bool                                   `json:"isActive"`
+	Metric     []external_metrics.ExternalMetricValue `json:"metric"`
+	StoredAt   metav1.Time                            `json:"storedAt"`
+}
+
+// NewPersister returns a Persister, the client is used to save the snapshot and the reader, which shouldn't be cached, to read it
+func NewPersister(client client.Client, reader client.Reader, options PersisterOptions) (*Persister, error) {
+	if options.Backend != PersistenceConfigMap && options.Backend != PersistenceSecret {
+		return nil, fmt.Errorf("unsupported metrics cache persistence %q, expected %s or %s", options.Backend, PersistenceConfigMap, PersistenceSecret)
+	}
+	if options.Namespace == "" || options.Name == "" {
+		return nil, fmt.Errorf("the namespace and the name of the metrics cache %s are required", options.Backend)
+	}
+	if options.Interval <= 0 || options.MaxStaleness <= 0 {
+		return nil, fmt.Errorf("the interval and the max staleness of the metrics cache persistence must be positive")
+	}
+	return &Persister{
+		client:     client,
+		reader:     reader,
+		options:    options,
+		now:        time.Now,
+		caches:     map[string]MetricsCache{},
+		restoredAt: map[string]time.Time{},
+		restored:   make(chan struct{}),
+	}, nil
+}
+
+// Register adds the cache to the persisted ones under the name, it must be called before the Persister is started
+func (p *Persister) Register(name string, cache MetricsCache) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.caches[name] = cache
+}
+
+// WaitForRestore blocks until the caches are restored or the context is done, it returns right away if the Persister is nil
+func (p *Persister) WaitForRestore(ctx context.Context) {
+	if p == nil {
+		return
+	}
+	select {
+	case <-p.restored:
+	case <-ctx.Done():
+	}
+}
+
+// RestoredAt returns when the newest restored record of the ScaledObject or ScaledJob was stored,
+// it is returned once, so only the first scale loop following the restore can rely on it
+func (p *Persister) RestoredAt(identifier string) (time.Time, bool) {
+	if p == nil {
+		return time.Time{}, false
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	storedAt, ok := p.restoredAt[identifier]
+	delete(p.restoredAt, identifier)
+	return storedAt, ok
+}
+
+// Start restores the caches then saves them at the interval until the context is done, when they are saved
+// a last time. It implements the Runnable interface of controller-runtime Manager.
+func (p *Persister) Start(ctx context.Context) error {
+	if err := p.restore(ctx); err != nil {
+		log.Error(err, "error restoring the metrics cache, starting with an empty cache", "backend", p.options.Backend, "name", p.options.Name)
+	}
+	close(p.restored)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.save(ctx); err != nil {
+			log.Error(err, "error saving the metrics cache", "backend", p.options.Backend, "name", p.options.Name)
+		}
+	}, p.options.Interval)
+
+	saveCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.save(saveCtx); err != nil {
+		log.Error(err, "error saving the metrics cache on shutdown", "backend", p.options.Backend, "name", p.options.Name)
+	}
+	return nil
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime. The caches are restored by the new leader.
+func (p *Persister) NeedLeaderElection() bool {
+	return true
+}
+
+// restore fills the caches with the persisted records which aren't older than the max staleness,
+// the records already stored by the scale loops are kept
+func (p *Persister) restore(ctx context.Context) error {
+	data, found, err := p.read(ctx)
+	if err != nil || !found {
+		return err
+	}
+	persisted := snapshot{}
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return fmt.Errorf("error decoding the metrics cache: %w", err)
+	}
+
+	now := p.now()
+	restored, stale := 0, 0
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for name, records := range persisted.Caches {
+		cache, ok := p.caches[name]
+		if !ok {
+			continue
+		}
+		for _, record := range records {
+			if now.Sub(record.StoredAt.Time) > p.options.MaxStaleness {
+				stale++
+				continue
+			}
+			if _, ok := cache.ReadRecord(record.Identifier, record.MetricName); ok {
+				continue
+			}
+			cache.StoreRecord(record.Identifier, record.MetricName, MetricsRecord{
+				IsActive: record.IsActive,
+				Metric:   record.Metric,
+				StoredAt: record.StoredAt.Time,
+			})
+			if record.StoredAt.After(p.restoredAt[record.Identifier]) {
+				p.restoredAt[record.Identifier] = record.StoredAt.Time
+			}
+			restored++
+		}
+	}
+	log.Info("Restored the metrics cache", "restored", restored, "stale", stale, "savedAt", persisted.SavedAt)
+	return nil
+}
+
+// save persists the records of the caches, the records of the scalers which failed aren't persisted
+func (p *Persister) save(ctx context.Context) error {
+	persisted := snapshot{
+		SavedAt: metav1.NewTime(p.now()),
+		Caches:  map[string][]persistedRecord{},
+	}
+	p.lock.Lock()
+	for name, cache := range p.caches {
+		records := []persistedRecord{}
+		for identifier, metricsRecords := range cache.Records() {
+			for metricName, metricsRecord := range metricsRecords {
+				if metricsRecord.ScalerError != nil {
+					continue
+				}
+				records = append(records, persistedRecord{
+					Identifier: identifier,
+					MetricName: metricName,
+					IsActive:   metricsRecord.IsActive,
+					Metric:     metricsRecord.Metric,
+					StoredAt:   metav1.NewTime(metricsRecord.StoredAt),
+				})
+			}
+		}
+		persisted.Caches[name] = records
+	}
+	p.lock.Unlock()
+
+	data, err := json.Marshal(persisted)
+	if err != nil {
+		return fmt.Errorf("error encoding the metrics cache: %w", err)
+	}
+	if len(data) > maxSnapshotSize {
+		return fmt.Errorf("the metrics cache is too large to be persisted, %d bytes", len(data))
+	}
+	return p.write(ctx, data)
+}
+
+func (p *Persister) read(ctx context.Context) ([]byte, bool, error) {
+	key := types.NamespacedName{Namespace: p.options.Namespace, Name: p.options.Name}
+	var object client.Object = &corev1.ConfigMap{}
+	if p.options.Backend == PersistenceSecret {
+		object = &corev1.Secret{}
+	}
+	err := p.reader.Get(ctx, key, object)
+	if errors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	switch obj := object.(type) {
+	case *corev1.ConfigMap:
+		data, found := obj.Data[snapshotKey]
+		return []byte(data), found, nil
+	case *corev1.Secret:
+		data, found := obj.Data[snapshotKey]
+		return data, found, nil
+	}
+	return nil, false, nil
+}
+
+func (p *Persister) write(ctx context.Context, data []byte) error {
+	key := types.NamespacedName{Namespace: p.options.Namespace, Name: p.options.Name}
+	meta := metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}
+
+	if p.options.Backend == PersistenceSecret {
+		secret := &corev1.Secret{}
+		err := p.reader.Get(ctx, key, secret)
+		if errors.IsNotFound(err) {
+			secret = &corev1.Secret{ObjectMeta: meta, Data: map[string][]byte{snapshotKey: data}}
+			return p.client.Create(ctx, secret)
+		}
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[snapshotKey] = data
+		return p.client.Update(ctx, secret)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := p.reader.Get(ctx, key, configMap)
+	if errors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{ObjectMeta: meta, Data: map[string]string{snapshotKey: string(data)}}
+		return p.client.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[snapshotKey] = string(data)
+	return p.client.Update(ctx, configMap)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestPersister(t *testing.T, backend string, c *fake.ClientBuilder, now time.Time) (*Persister, MetricsCache) {
+	fakeClient := c.Build()
+	persister, err := NewPersister(fakeClient, fakeClient, PersisterOptions{
+		Backend:      backend,
+		Namespace:    "keda",
+		Name:         "keda-operator-metrics-cache",
+		Interval:     time.Minute,
+		MaxStaleness: 5 * time.Minute,
+	})
+	require.NoError(t, err)
+	persister.now = func() time.Time { return now }
+	cache := NewMetricsCache()
+	persister.Register("scaledObjectsMetrics", cache)
+	return persister, cache
+}
+
+func TestPersisterSaveAndRestore(t *testing.T) {
+	for _, backend := range []string{PersistenceConfigMap, PersistenceSecret} {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now().Truncate(time.Second)
+			metric := []external_metrics.ExternalMetricValue{{MetricName: "s0-queue", Value: *resource.NewQuantity(42, resource.DecimalSI), Timestamp: metav1.NewTime(now)}}
+
+			builder := fake.NewClientBuilder()
+			persister, cache := newTestPersister(t, backend, builder, now)
+			cache.StoreRecord("scaledobject.default.fresh", "s0-queue", MetricsRecord{IsActive: true, Metric: metric, StoredAt: now.Add(-time.Minute)})
+			cache.StoreRecord("scaledobject.default.stale", "s0-queue", MetricsRecord{IsActive: true, Metric: metric, StoredAt: now.Add(-10 * time.Minute)})
+			cache.StoreRecord("scaledobject.default.failing", "s0-queue", MetricsRecord{ScalerError: fmt.Errorf("unreachable")})
+			require.NoError(t, persister.save(ctx))
+			// the snapshot is updated when it exists
+			require.NoError(t, persister.save(ctx))
+
+			// a new leader restores the records which aren't stale
+			restarted, err := NewPersister(persister.client, persister.reader, persister.options)
+			require.NoError(t, err)
+			restarted.now = persister.now
+			restoredCache := NewMetricsCache()
+			restarted.Register("scaledObjectsMetrics", restoredCache)
+			require.NoError(t, restarted.restore(ctx))
+
+			record, found := restoredCache.ReadRecord("scaledobject.default.fresh", "s0-queue")
+			require.True(t, found)
+			assert.True(t, record.IsActive)
+			assert.WithinDuration(t, now.Add(-time.Minute), record.StoredAt, 0)
+			require.Len(t, record.Metric, 1)
+			assert.Equal(t, int64(42), record.Metric[0].Value.Value())
+
+			_, found = restoredCache.ReadRecord("scaledobject.default.stale", "s0-queue")
+			assert.False(t, found, "the records older than the max staleness aren't restored")
+			_, found = restoredCache.ReadRecord("scaledobject.default.failing", "s0-queue")
+			assert.False(t, found, "the records of failing scalers aren't persisted")
+
+			storedAt, restored := restarted.RestoredAt("scaledobject.default.fresh")
+			assert.True(t, restored)
+			assert.WithinDuration(t, now.Add(-time.Minute), storedAt, 0)
+			_, restored = restarted.RestoredAt("scaledobject.default.fresh")
+			assert.False(t, restored, "the restore time is returned once")
+		})
+	}
+}
+
+func TestPersisterRestoreWithoutSnapshot(t *testing.T) {
+	persister, cache := newTestPersister(t, PersistenceConfigMap, fake.NewClientBuilder(), time.Now())
+	require.NoError(t, persister.restore(context.Background()))
+	assert.Empty(t, cache.Records())
+
+	var nilPersister *Persister
+	nilPersister.WaitForRestore(context.Background())
+	_, restored := nilPersister.RestoredAt("scaledobject.default.worker")
+	assert.False(t, restored)
+}
+
+func TestNewPersisterValidation(t *testing.T) {
+	_, err := NewPersister(nil, nil, PersisterOptions{Backend: "crd", Namespace: "keda", Name: "cache", Interval: time.Minute, MaxStaleness: time.Minute})
+	assert.Error(t, err)
+	_, err = NewPersister(nil, nil, PersisterOptions{Backend: PersistenceSecret, Namespace: "keda", Interval: time.Minute, MaxStaleness: time.Minute})
+	assert.Error(t, err)
+	_, err = NewPersister(nil, nil, PersisterOptions{Backend: PersistenceSecret, Namespace: "keda", Name: "cache", Interval: time.Minute})
+	assert.Error(t, err)
+}
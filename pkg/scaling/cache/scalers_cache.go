@@ -39,6 +39,11 @@ type ScalersCache struct {
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
 	CompiledFormula          *vm.Program
+	MetricsHistory           *MetricsHistory
+	// CompiledScalingStrategy is the compiled expression of a ScaledJob using the expression scaling strategy
+	CompiledScalingStrategy *vm.Program
+	// Clock returns the time the metrics are recorded at in MetricsHistory, time.Now if not set
+	Clock func() time.Time
 }
 
 type ScalerBuilder struct {
@@ -59,6 +64,14 @@ func (c *ScalersCache) GetScalers() ([]scalers.Scaler, []scalers.ScalerConfig) {
 	return scalersList, configsList
 }
 
+// Now returns the current time given by the clock of the cache
+func (c *ScalersCache) Now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
 // GetPushScalers returns array of push scalers stored in the cache
 func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	var result []scalers.PushScaler
@@ -122,22 +135,37 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 // GetMetricsAndActivityForScaler returns metric value, activity and latency for a scaler identified by the metric name
 // and by the input index (from the list of scalers in this ScaledObject)
 func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index int, metricName string) ([]external_metrics.ExternalMetricValue, bool, int64, error) {
+	metric, activity, _, latency, err := c.GetMetricsActivityAndWorkHintsForScaler(ctx, index, metricName)
+	return metric, activity, latency, err
+}
+
+// GetMetricsActivityAndWorkHintsForScaler returns metric value, activity and latency for a scaler identified by the metric name
+// and by the input index as GetMetricsAndActivityForScaler does, along with the work hints of the scaler if it is a WorkHintScaler
+func (c *ScalersCache) GetMetricsActivityAndWorkHintsForScaler(ctx context.Context, index int, metricName string) ([]external_metrics.ExternalMetricValue, bool, []string, int64, error) {
 	if index < 0 || index >= len(c.Scalers) {
-		return nil, false, -1, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
+		return nil, false, nil, -1, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
 	}
 	startTime := time.Now()
-	metric, activity, err := c.Scalers[index].Scaler.GetMetricsAndActivity(ctx, metricName)
+	metric, activity, hints, err := getMetricsActivityAndWorkHints(ctx, c.Scalers[index].Scaler, metricName)
 	if err == nil {
-		return metric, activity, time.Since(startTime).Milliseconds(), nil
+		return metric, activity, hints, time.Since(startTime).Milliseconds(), nil
 	}
 
 	ns, err := c.refreshScaler(ctx, index)
 	if err != nil {
-		return nil, false, -1, err
+		return nil, false, nil, -1, err
 	}
 	startTime = time.Now()
-	metric, activity, err = ns.GetMetricsAndActivity(ctx, metricName)
-	return metric, activity, time.Since(startTime).Milliseconds(), err
+	metric, activity, hints, err = getMetricsActivityAndWorkHints(ctx, ns, metricName)
+	return metric, activity, hints, time.Since(startTime).Milliseconds(), err
+}
+
+func getMetricsActivityAndWorkHints(ctx context.Context, scaler scalers.Scaler, metricName string) ([]external_metrics.ExternalMetricValue, bool, []string, error) {
+	if hintScaler, ok := scaler.(scalers.WorkHintScaler); ok {
+		return hintScaler.GetMetricsActivityAndWorkHints(ctx, metricName)
+	}
+	metric, activity, err := scaler.GetMetricsAndActivity(ctx, metricName)
+	return metric, activity, nil, err
 }
 
 func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scaler, error) {
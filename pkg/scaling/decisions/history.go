@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"sync"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// History keeps the latest scaling decisions of each ScaledObject and ScaledJob in a bounded ring buffer,
+// a nil History records nothing
+type History struct {
+	size    int
+	buffers map[string]*ringBuffer
+	lock    sync.RWMutex
+}
+
+type ringBuffer struct {
+	entries []kedav1alpha1.ScalingDecision
+	next    int
+	full    bool
+}
+
+// NewHistory creates a History keeping up to size decisions per object, a size of 0 disables the history
+func NewHistory(size int) *History {
+	return &History{
+		size:    size,
+		buffers: map[string]*ringBuffer{},
+	}
+}
+
+// Record adds the decision to the history of the object identified by key, overwriting the oldest one once full
+func (h *History) Record(key string, decision kedav1alpha1.ScalingDecision) {
+	if h == nil || h.size <= 0 {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	buffer, found := h.buffers[key]
+	if !found {
+		buffer = &ringBuffer{entries: make([]kedav1alpha1.ScalingDecision, h.size)}
+		h.buffers[key] = buffer
+	}
+	buffer.entries[buffer.next] = decision
+	buffer.next = (buffer.next + 1) % h.size
+	if buffer.next == 0 {
+		buffer.full = true
+	}
+}
+
+// List returns the decisions of the object identified by key, oldest first
+func (h *History) List(key string) []kedav1alpha1.ScalingDecision {
+	if h == nil {
+		return nil
+	}
+	return h.Latest(key, h.size)
+}
+
+// Latest returns up to limit latest decisions of the object identified by key, oldest first
+func (h *History) Latest(key string, limit int) []kedav1alpha1.ScalingDecision {
+	if h == nil {
+		return nil
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	buffer, found := h.buffers[key]
+	if !found || limit <= 0 {
+		return nil
+	}
+	count := buffer.next
+	if buffer.full {
+		count = h.size
+	}
+	if limit > count {
+		limit = count
+	}
+	decisions := make([]kedav1alpha1.ScalingDecision, 0, limit)
+	for i := count - limit; i < count; i++ {
+		index := i
+		if buffer.full {
+			index = (buffer.next + i) % h.size
+		}
+		decisions = append(decisions, *buffer.entries[index].DeepCopy())
+	}
+	return decisions
+}
+
+// Delete forgets the decisions of the object identified by key
+func (h *History) Delete(key string) {
+	if h == nil {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.buffers, key)
+}
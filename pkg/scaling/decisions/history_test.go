@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func replicasAfter(decisions []kedav1alpha1.ScalingDecision) []int64 {
+	var replicas []int64
+	for _, decision := range decisions {
+		replicas = append(replicas, decision.ReplicasAfter)
+	}
+	return replicas
+}
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(3)
+	assert.Nil(t, history.List("scaledobject.namespace.name"))
+
+	history.Record("scaledobject.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 1})
+	history.Record("scaledobject.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 2})
+	assert.Equal(t, []int64{1, 2}, replicasAfter(history.List("scaledobject.namespace.name")))
+
+	// the oldest decisions are overwritten once the buffer is full
+	history.Record("scaledobject.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 3})
+	history.Record("scaledobject.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 4})
+	history.Record("scaledobject.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 5})
+	assert.Equal(t, []int64{3, 4, 5}, replicasAfter(history.List("scaledobject.namespace.name")))
+	assert.Equal(t, []int64{4, 5}, replicasAfter(history.Latest("scaledobject.namespace.name", 2)))
+	assert.Equal(t, []int64{3, 4, 5}, replicasAfter(history.Latest("scaledobject.namespace.name", 10)))
+
+	// each object has its own history
+	history.Record("scaledjob.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 7})
+	assert.Equal(t, []int64{7}, replicasAfter(history.List("scaledjob.namespace.name")))
+
+	history.Delete("scaledobject.namespace.name")
+	assert.Nil(t, history.List("scaledobject.namespace.name"))
+
+	disabled := NewHistory(0)
+	disabled.Record("scaledobject.namespace.name", kedav1alpha1.ScalingDecision{ReplicasAfter: 1})
+	assert.Nil(t, disabled.List("scaledobject.namespace.name"))
+}
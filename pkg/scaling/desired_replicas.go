@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"math"
+	"strconv"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// getDesiredReplicasForMetrics returns the replica count the HPA would reach for the metrics with an AverageValue target,
+// ie. the sum of the metric values divided by the target, it returns 0 if it can't be derived from the metrics alone
+func getDesiredReplicasForMetrics(metrics []external_metrics.ExternalMetricValue, target float64) int32 {
+	if target <= 0 {
+		return 0
+	}
+	sum := float64(0)
+	for _, metric := range metrics {
+		sum += metric.Value.AsApproximateFloat64()
+	}
+	desired := math.Ceil(sum / target)
+	if desired > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(desired)
+}
+
+// getDesiredReplicasForSpec returns the desired replica count for the metrics of an external metric spec,
+// only AverageValue targets don't depend on the current replica count
+func getDesiredReplicasForSpec(spec v2.MetricSpec, metrics []external_metrics.ExternalMetricValue) int32 {
+	if spec.External == nil || spec.External.Target.Type != v2.AverageValueMetricType || spec.External.Target.AverageValue == nil {
+		return 0
+	}
+	return getDesiredReplicasForMetrics(metrics, spec.External.Target.AverageValue.AsApproximateFloat64())
+}
+
+// getDesiredReplicasForModifiers returns the desired replica count for the composite metric of the scaling modifiers
+func getDesiredReplicasForModifiers(scaledObject *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue) int32 {
+	scalingModifiers := scaledObject.Spec.Advanced.ScalingModifiers
+	if scalingModifiers.MetricType != "" && scalingModifiers.MetricType != v2.AverageValueMetricType {
+		return 0
+	}
+	target, err := strconv.ParseFloat(scalingModifiers.Target, 64)
+	if err != nil {
+		return 0
+	}
+	return getDesiredReplicasForMetrics(metrics, target)
+}
+
+// getUsageRatioForMetrics returns the ratio of the sum of the metric values to the target, it returns nil if the target is invalid
+func getUsageRatioForMetrics(metrics []external_metrics.ExternalMetricValue, target float64) *float64 {
+	if target <= 0 {
+		return nil
+	}
+	sum := float64(0)
+	for _, metric := range metrics {
+		sum += metric.Value.AsApproximateFloat64()
+	}
+	ratio := sum / target
+	return &ratio
+}
+
+// getUsageRatioForSpec returns the usage ratio for the metrics of an external metric spec with a Value target,
+// the HPA scales the current replica count by it, it returns nil for other targets
+func getUsageRatioForSpec(spec v2.MetricSpec, metrics []external_metrics.ExternalMetricValue) *float64 {
+	if spec.External == nil || spec.External.Target.Type != v2.ValueMetricType || spec.External.Target.Value == nil {
+		return nil
+	}
+	return getUsageRatioForMetrics(metrics, spec.External.Target.Value.AsApproximateFloat64())
+}
+
+// getUsageRatioForModifiers returns the usage ratio for the composite metric of the scaling modifiers with a Value metric type
+func getUsageRatioForModifiers(scaledObject *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue) *float64 {
+	scalingModifiers := scaledObject.Spec.Advanced.ScalingModifiers
+	if scalingModifiers.MetricType != v2.ValueMetricType {
+		return nil
+	}
+	target, err := strconv.ParseFloat(scalingModifiers.Target, 64)
+	if err != nil {
+		return nil
+	}
+	return getUsageRatioForMetrics(metrics, target)
+}
+
+// maxUsageRatio returns the largest of the usage ratios, the HPA follows the metric proposing the most replicas
+func maxUsageRatio(x, y *float64) *float64 {
+	if x == nil || (y != nil && *y > *x) {
+		return y
+	}
+	return x
+}
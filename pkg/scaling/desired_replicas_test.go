@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetDesiredReplicas(t *testing.T) {
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "queue", Value: *resource.NewQuantity(25, resource.DecimalSI)},
+		{MetricName: "queue", Value: *resource.NewQuantity(16, resource.DecimalSI)},
+	}
+
+	averageValue := v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{
+		Type:         v2.AverageValueMetricType,
+		AverageValue: resource.NewQuantity(10, resource.DecimalSI),
+	}}}
+	assert.Equal(t, int32(5), getDesiredReplicasForSpec(averageValue, metrics))
+
+	// the desired replicas of a Value target depend on the current replica count
+	value := v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{
+		Type:  v2.ValueMetricType,
+		Value: resource.NewQuantity(10, resource.DecimalSI),
+	}}}
+	assert.Equal(t, int32(0), getDesiredReplicasForSpec(value, metrics))
+
+	so := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{Advanced: &kedav1alpha1.AdvancedConfig{
+		ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: "queue", Target: "20"},
+	}}}
+	assert.Equal(t, int32(3), getDesiredReplicasForModifiers(so, metrics))
+	so.Spec.Advanced.ScalingModifiers.MetricType = v2.ValueMetricType
+	assert.Equal(t, int32(0), getDesiredReplicasForModifiers(so, metrics))
+}
+
+func TestGetUsageRatio(t *testing.T) {
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "queue", Value: *resource.NewQuantity(25, resource.DecimalSI)},
+		{MetricName: "queue", Value: *resource.NewQuantity(5, resource.DecimalSI)},
+	}
+
+	value := v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{
+		Type:  v2.ValueMetricType,
+		Value: resource.NewQuantity(20, resource.DecimalSI),
+	}}}
+	assert.Equal(t, 1.5, *getUsageRatioForSpec(value, metrics))
+
+	averageValue := v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{
+		Type:         v2.AverageValueMetricType,
+		AverageValue: resource.NewQuantity(10, resource.DecimalSI),
+	}}}
+	assert.Nil(t, getUsageRatioForSpec(averageValue, metrics))
+
+	so := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{Advanced: &kedav1alpha1.AdvancedConfig{
+		ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: "queue", Target: "60", MetricType: v2.ValueMetricType},
+	}}}
+	assert.Equal(t, 0.5, *getUsageRatioForModifiers(so, metrics))
+	so.Spec.Advanced.ScalingModifiers.MetricType = ""
+	assert.Nil(t, getUsageRatioForModifiers(so, metrics))
+
+	low, high := 0.5, 2.0
+	assert.Equal(t, &high, maxUsageRatio(&low, &high))
+	assert.Equal(t, &low, maxUsageRatio(&low, nil))
+	assert.Nil(t, maxUsageRatio(nil, nil))
+}
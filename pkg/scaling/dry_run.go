@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// emitDryRunReplicasChanged emits an event, sent to the CloudEventSources too, when the replica count
+// the ScaledObject in dry-run mode would scale its ScaleTarget to differs from the previous one
+func (h *scaleHandler) emitDryRunReplicasChanged(scaledObject *kedav1alpha1.ScaledObject, previous *int32) {
+	current := scaledObject.Status.DryRunReplicas
+	if current == nil || (previous != nil && *previous == *current) {
+		return
+	}
+	msg := fmt.Sprintf("ScaledObject in dry-run mode would scale %s %s/%s to %d replicas",
+		scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, *current)
+	h.eventEmitter.Emit(scaledObject, types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}, corev1.EventTypeNormal,
+		eventemitter.ScaledObjectDryRunReplicasChangedType, eventreason.KEDAScaleTargetDryRunReplicasChanged, msg)
+}
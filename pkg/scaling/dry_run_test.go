@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_eventemitter"
+)
+
+func TestEmitDryRunReplicasChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockEventEmitter := mock_eventemitter.NewMockEventHandler(ctrl)
+	sh := scaleHandler{eventEmitter: mockEventEmitter}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+		},
+	}
+
+	// nothing reported yet
+	sh.emitDryRunReplicasChanged(scaledObject, nil)
+
+	scaledObject.Status.DryRunReplicas = ptr.To[int32](3)
+	mockEventEmitter.EXPECT().Emit(scaledObject, gomock.Any(), corev1.EventTypeNormal,
+		eventemitter.ScaledObjectDryRunReplicasChangedType, eventreason.KEDAScaleTargetDryRunReplicasChanged, gomock.Any())
+	sh.emitDryRunReplicasChanged(scaledObject, ptr.To[int32](2))
+
+	// unchanged replica count
+	sh.emitDryRunReplicasChanged(scaledObject, ptr.To[int32](3))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// GetActivationReplicas returns the replica count the ScaleTarget is scaled to from zero or idle,
+// the initial replicas of the activation ramp if any, minReplicaCount or 1 otherwise
+func GetActivationReplicas(scaledObject *kedav1alpha1.ScaledObject) int32 {
+	minReplicaCount := scaledObject.GetMinReplicaCount()
+	if ramp := scaledObject.GetActivationRamp(); ramp != nil {
+		replicas := ramp.GetInitialReplicas(minReplicaCount)
+		if maxReplicas := scaledObject.GetHPAMaxReplicas(); replicas > maxReplicas {
+			return maxReplicas
+		}
+		return replicas
+	}
+	if minReplicaCount != nil && *minReplicaCount > 0 {
+		return *minReplicaCount
+	}
+	return 1
+}
+
+// getActivationRampTarget returns the replica count the activation ramp scales up to,
+// the desired replica count capped at maxReplicaCount, or the target in status if the desired replica count is unknown
+func getActivationRampTarget(scaledObject *kedav1alpha1.ScaledObject, desiredReplicas int32) int32 {
+	if desiredReplicas == 0 {
+		if scaledObject.Status.ActivationRamp == nil {
+			return 0
+		}
+		return scaledObject.Status.ActivationRamp.TargetReplicas
+	}
+	if maxReplicas := scaledObject.GetHPAMaxReplicas(); desiredReplicas > maxReplicas {
+		return maxReplicas
+	}
+	return desiredReplicas
+}
+
+// StartActivationRamp returns the activation ramp reported in status once the ScaleTarget was activated at the given time
+// with fewer replicas than desired, nil if the ScaledObject has no activation ramp or the ScaleTarget doesn't need one
+func StartActivationRamp(scaledObject *kedav1alpha1.ScaledObject, replicas int32, desiredReplicas int32, now time.Time) *kedav1alpha1.ActivationRampStatus {
+	if scaledObject.GetActivationRamp() == nil {
+		return nil
+	}
+	target := getActivationRampTarget(scaledObject, desiredReplicas)
+	if target <= replicas {
+		return nil
+	}
+	return &kedav1alpha1.ActivationRampStatus{
+		TargetReplicas: target,
+		LastStepTime:   metav1.NewTime(now),
+	}
+}
+
+// StepActivationRamp returns the replica count the activation ramp in progress scales the ScaleTarget to at the given time,
+// the current one until the step interval elapsed, and the ramp reported in status afterwards. The ramp ends, nil is returned,
+// when the ScaleTarget reaches its target, either by the steps or by the HPA catching up.
+func StepActivationRamp(scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, desiredReplicas int32, now time.Time) (int32, *kedav1alpha1.ActivationRampStatus) {
+	ramp := scaledObject.GetActivationRamp()
+	if ramp == nil || scaledObject.Status.ActivationRamp == nil {
+		return currentReplicas, nil
+	}
+
+	target := getActivationRampTarget(scaledObject, desiredReplicas)
+	if currentReplicas >= target {
+		return currentReplicas, nil
+	}
+	if !ramp.IsStepDue(scaledObject.Status.ActivationRamp, now) {
+		return currentReplicas, &kedav1alpha1.ActivationRampStatus{
+			TargetReplicas: target,
+			LastStepTime:   scaledObject.Status.ActivationRamp.LastStepTime,
+		}
+	}
+
+	replicas := ramp.NextStep(currentReplicas, target)
+	if replicas >= target {
+		return replicas, nil
+	}
+	return replicas, &kedav1alpha1.ActivationRampStatus{
+		TargetReplicas: target,
+		LastStepTime:   metav1.NewTime(now),
+	}
+}
+
+// startActivationRamp reports the ramp in status once the ScaleTarget was activated with fewer replicas than desired
+func (e *scaleExecutor) startActivationRamp(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, replicas int32, desiredReplicas int32) {
+	ramp := StartActivationRamp(scaledObject, replicas, desiredReplicas, time.Now())
+	if ramp == nil {
+		e.resetActivationRamp(ctx, logger, scaledObject)
+		return
+	}
+	logger.Info("Starting activation ramp", "Initial Replicas Count", replicas, "Target Replicas Count", ramp.TargetReplicas)
+	e.updateActivationRampStatus(ctx, logger, scaledObject, ramp)
+}
+
+// stepActivationRamp scales the ScaleTarget up by one step of the ramp in progress once the step interval elapsed
+func (e *scaleExecutor) stepActivationRamp(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, currentReplicas int32, desiredReplicas int32) {
+	if scaledObject.Status.ActivationRamp == nil {
+		return
+	}
+
+	replicas, ramp := StepActivationRamp(scaledObject, currentReplicas, desiredReplicas, time.Now())
+	if replicas != currentReplicas {
+		_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
+		if err != nil {
+			logger.Error(err, "Error scaling ScaleTarget for the activation ramp", "Replicas Count", replicas)
+			return
+		}
+		logger.Info("Successfully scaled ScaleTarget for the activation ramp",
+			"Original Replicas Count", currentReplicas,
+			"New Replicas Count", replicas,
+			"Target Replicas Count", getActivationRampTarget(scaledObject, desiredReplicas))
+	} else if ramp == nil && scaledObject.GetActivationRamp() != nil {
+		logger.Info("Activation ramp finished", "Replicas Count", currentReplicas)
+	}
+
+	if ramp == nil {
+		e.resetActivationRamp(ctx, logger, scaledObject)
+		return
+	}
+	if !equality.Semantic.DeepEqual(ramp, scaledObject.Status.ActivationRamp) {
+		e.updateActivationRampStatus(ctx, logger, scaledObject, ramp)
+	}
+}
+
+// resetActivationRamp removes the ramp from status, eg. when the ScaledObject is deactivated, paused or falls back
+func (e *scaleExecutor) resetActivationRamp(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) {
+	if scaledObject.Status.ActivationRamp == nil {
+		return
+	}
+	e.updateActivationRampStatus(ctx, logger, scaledObject, nil)
+}
+
+func (e *scaleExecutor) updateActivationRampStatus(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, ramp *kedav1alpha1.ActivationRampStatus) {
+	status := scaledObject.Status.DeepCopy()
+	status.ActivationRamp = ramp
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "Error updating the activation ramp status")
+	}
+}
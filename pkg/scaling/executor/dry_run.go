@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// requestDryRunScale reports the replica count the ScaleTarget would be scaled to in status.dryRunReplicas
+// and in the metrics instead of scaling it, LastActiveTime is still updated so the cooldown period applies
+func (e *scaleExecutor) requestDryRunScale(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32,
+	isActive, isError bool, pausedCount *int32, options *ScaleExecutorOptions) {
+	if isActive {
+		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
+			logger.Error(err, "Error updating last active time")
+			return
+		}
+	}
+
+	replicas := GetDryRunReplicas(scaledObject, currentReplicas, isActive, isError, pausedCount, options, time.Now())
+	metricscollector.RecordScaledObjectDryRunReplicas(scaledObject.Namespace, scaledObject.Name, replicas)
+	if scaledObject.Status.DryRunReplicas != nil && *scaledObject.Status.DryRunReplicas == replicas {
+		return
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	status.DryRunReplicas = &replicas
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, e.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating status dry-run replica count")
+		return
+	}
+	logger.Info("ScaleTarget would be scaled in dry-run mode", "Current Replicas Count", currentReplicas, "Dry-Run Replicas Count", replicas)
+}
+
+// GetDryRunReplicas returns the replica count the ScaleTarget would be scaled to, by KEDA when the ScaledObject
+// is paused, inactive or scaled to zero by schedule, and by the HPA with the current metrics otherwise
+func GetDryRunReplicas(scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, isActive, isError bool, pausedCount *int32, options *ScaleExecutorOptions, now time.Time) int32 {
+	switch {
+	case pausedCount != nil:
+		return *pausedCount
+	case scaledObject.IsScaledToZeroBySchedule():
+		return 0
+	}
+
+	minReplicas := int32(0)
+	if minReplicaCount := scaledObject.GetMinReplicaCount(); minReplicaCount != nil {
+		minReplicas = *minReplicaCount
+	}
+	if !isActive {
+		if isError && scaledObject.Spec.Fallback != nil && scaledObject.Spec.Fallback.Replicas != 0 {
+			return scaledObject.Spec.Fallback.Replicas
+		}
+		if idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject); idleValue || minReplicas == 0 {
+			// the ScaleTarget is scaled down once cooled down, the HPA keeps it as is in the meantime
+			if currentReplicas <= scaleToReplicas || scaledObject.Status.LastActiveTime == nil ||
+				isCooledDown(scaledObject, getCooldownPeriod(scaledObject, idleValue), now) {
+				return scaleToReplicas
+			}
+		}
+	}
+
+	replicas := options.GetHPAReplicas(currentReplicas)
+	if hpaMinReplicas := *scaledObject.GetHPAMinReplicas(); replicas < hpaMinReplicas {
+		replicas = hpaMinReplicas
+	}
+	if hpaMaxReplicas := scaledObject.GetHPAMaxReplicas(); replicas > hpaMaxReplicas {
+		replicas = hpaMaxReplicas
+	}
+	return replicas
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
+)
+
+func TestGetHPAReplicas(t *testing.T) {
+	var options *ScaleExecutorOptions
+	assert.Equal(t, int32(3), options.GetHPAReplicas(3))
+
+	options = &ScaleExecutorOptions{DesiredReplicas: 4}
+	assert.Equal(t, int32(4), options.GetHPAReplicas(3))
+
+	options.UsageRatio = ptr.To(2.0)
+	assert.Equal(t, int32(6), options.GetHPAReplicas(3))
+
+	// within the tolerance of the HPA the replica count doesn't change
+	options = &ScaleExecutorOptions{UsageRatio: ptr.To(1.05)}
+	assert.Equal(t, int32(10), options.GetHPAReplicas(10))
+
+	options = &ScaleExecutorOptions{UsageRatio: ptr.To(0.0)}
+	assert.Equal(t, int32(0), options.GetHPAReplicas(10))
+}
+
+func TestGetDryRunReplicas(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name             string
+		spec             v1alpha1.ScaledObjectSpec
+		lastActiveTime   *v1.Time
+		currentReplicas  int32
+		isActive         bool
+		isError          bool
+		pausedCount      *int32
+		options          *ScaleExecutorOptions
+		expectedReplicas int32
+	}{
+		{
+			name:             "active with desired replicas",
+			spec:             v1alpha1.ScaledObjectSpec{MaxReplicaCount: ptr.To[int32](10)},
+			currentReplicas:  2,
+			isActive:         true,
+			options:          &ScaleExecutorOptions{DesiredReplicas: 5},
+			expectedReplicas: 5,
+		},
+		{
+			name:             "active with desired replicas above max",
+			spec:             v1alpha1.ScaledObjectSpec{MaxReplicaCount: ptr.To[int32](10)},
+			currentReplicas:  2,
+			isActive:         true,
+			options:          &ScaleExecutorOptions{DesiredReplicas: 50},
+			expectedReplicas: 10,
+		},
+		{
+			name:             "active from zero without metrics",
+			currentReplicas:  0,
+			isActive:         true,
+			expectedReplicas: 1,
+		},
+		{
+			name:             "inactive and cooled down",
+			lastActiveTime:   &v1.Time{Time: now.Add(-time.Hour)},
+			currentReplicas:  3,
+			expectedReplicas: 0,
+		},
+		{
+			name:             "inactive and cooling down",
+			lastActiveTime:   &v1.Time{Time: now},
+			currentReplicas:  3,
+			expectedReplicas: 3,
+		},
+		{
+			name:             "inactive with idle replica count",
+			spec:             v1alpha1.ScaledObjectSpec{IdleReplicaCount: ptr.To[int32](1), MinReplicaCount: ptr.To[int32](2)},
+			currentReplicas:  3,
+			expectedReplicas: 1,
+		},
+		{
+			name:             "inactive with min replica count",
+			spec:             v1alpha1.ScaledObjectSpec{MinReplicaCount: ptr.To[int32](2)},
+			currentReplicas:  3,
+			options:          &ScaleExecutorOptions{UsageRatio: ptr.To(0.0)},
+			expectedReplicas: 2,
+		},
+		{
+			name:             "inactive with error and fallback",
+			spec:             v1alpha1.ScaledObjectSpec{Fallback: &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 4}},
+			currentReplicas:  1,
+			isError:          true,
+			expectedReplicas: 4,
+		},
+		{
+			name:             "paused",
+			currentReplicas:  3,
+			isActive:         true,
+			pausedCount:      ptr.To[int32](7),
+			expectedReplicas: 7,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			so := &v1alpha1.ScaledObject{Spec: test.spec}
+			so.Status.LastActiveTime = test.lastActiveTime
+			replicas := GetDryRunReplicas(so, test.currentReplicas, test.isActive, test.isError, test.pausedCount, test.options, now)
+			assert.Equal(t, test.expectedReplicas, replicas)
+		})
+	}
+}
+
+func TestRequestScaleInDryRunMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "name",
+			Namespace:   "namespace",
+			Annotations: map[string]string{v1alpha1.DryRunAnnotation: "true"},
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MaxReplicaCount: ptr.To[int32](10),
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, "ScaledObjectReady", "ready")
+
+	currentReplicas := int32(2)
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &currentReplicas,
+		},
+	})
+	// the last active time and the dry-run replica count are updated, the ScaleTarget isn't scaled
+	client.EXPECT().Status().Times(2).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: 6})
+
+	assert.Equal(t, ptr.To[int32](6), scaledObject.Status.DryRunReplicas)
+	assert.NotNil(t, scaledObject.Status.LastActiveTime)
+}
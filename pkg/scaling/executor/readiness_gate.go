@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// isHeldBackByReadinessGate returns whether the activation of the scale target has to wait for the dependencies
+// in the readiness gate, the Waiting condition and events report what it is waiting for
+func (e *scaleExecutor) isHeldBackByReadinessGate(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) bool {
+	ready, msg, err := e.checkReadinessGate(ctx, scaledObject)
+	if err != nil {
+		logger.Error(err, "Error checking the readiness gate")
+		msg = fmt.Sprintf("error checking the readiness gate: %s", err)
+	}
+	if ready {
+		e.resetWaitingCondition(ctx, logger, scaledObject)
+		return false
+	}
+
+	condition := scaledObject.Status.Conditions.GetWaitingCondition()
+	if !condition.IsTrue() || condition.Message != msg {
+		logger.Info("ScaleTarget activation is waiting for the readiness gate", "reason", msg)
+		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivationWaiting,
+			"Waiting to activate %s %s/%s: %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, msg)
+		if err := e.setWaitingCondition(ctx, logger, scaledObject, metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionWaitingReason, msg); err != nil {
+			logger.Error(err, "Error setting waiting condition")
+		}
+	}
+	return true
+}
+
+// resetWaitingCondition sets the Waiting condition to false once the ScaledObject doesn't wait for its readiness gate anymore
+func (e *scaleExecutor) resetWaitingCondition(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) {
+	condition := scaledObject.Status.Conditions.GetWaitingCondition()
+	if !condition.IsTrue() {
+		return
+	}
+	if err := e.setWaitingCondition(ctx, logger, scaledObject, metav1.ConditionFalse,
+		kedav1alpha1.ScaledObjectConditionNotWaitingReason, kedav1alpha1.ScaledObjectConditionNotWaitingMessage); err != nil {
+		logger.Error(err, "Error setting waiting condition")
+	}
+}
+
+// checkReadinessGate returns whether all dependencies in the readiness gate of the ScaledObject are ready,
+// otherwise the message describes the first dependency which isn't
+func (e *scaleExecutor) checkReadinessGate(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, string, error) {
+	gate := scaledObject.Spec.ReadinessGate
+	if gate == nil {
+		return true, "", nil
+	}
+
+	for _, workload := range gate.Workloads {
+		readyReplicas, err := e.getReadyReplicas(ctx, scaledObject.Namespace, workload.GetKind(), workload.Name)
+		if err != nil {
+			return false, "", err
+		}
+		if readyReplicas == 0 {
+			return false, fmt.Sprintf("%s %s/%s has no ready replicas", workload.GetKind(), scaledObject.Namespace, workload.Name), nil
+		}
+	}
+
+	for _, name := range gate.ScaledObjects {
+		dependency := &kedav1alpha1.ScaledObject{}
+		err := e.client.Get(ctx, client.ObjectKey{Name: name, Namespace: scaledObject.Namespace}, dependency)
+		if errors.IsNotFound(err) {
+			return false, fmt.Sprintf("ScaledObject %s/%s is not found", scaledObject.Namespace, name), nil
+		}
+		if err != nil {
+			return false, "", err
+		}
+		readyReplicas, err := e.getScaleTargetReadyReplicas(ctx, dependency)
+		if err != nil {
+			return false, "", err
+		}
+		if readyReplicas == 0 {
+			return false, fmt.Sprintf("scale target of ScaledObject %s/%s has no ready replicas", scaledObject.Namespace, name), nil
+		}
+	}
+
+	if gate.PodSelector != "" {
+		selector, err := labels.Parse(gate.PodSelector)
+		if err != nil {
+			return false, "", err
+		}
+		pods := &corev1.PodList{}
+		if err := e.client.List(ctx, pods, client.InNamespace(scaledObject.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return false, "", err
+		}
+		if !hasReadyPod(pods.Items) {
+			return false, fmt.Sprintf("no pod matching %q in namespace %s is ready", gate.PodSelector, scaledObject.Namespace), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// getReadyReplicas returns the ready replicas of a Deployment or StatefulSet, 0 if it doesn't exist
+func (e *scaleExecutor) getReadyReplicas(ctx context.Context, namespace, kind, name string) (int32, error) {
+	var object client.Object
+	switch kind {
+	case "Deployment":
+		object = &appsv1.Deployment{}
+	case "StatefulSet":
+		object = &appsv1.StatefulSet{}
+	default:
+		return 0, fmt.Errorf("kind %s is not supported by the readiness gate", kind)
+	}
+
+	err := e.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, object)
+	if errors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	switch obj := object.(type) {
+	case *appsv1.Deployment:
+		return obj.Status.ReadyReplicas, nil
+	case *appsv1.StatefulSet:
+		return obj.Status.ReadyReplicas, nil
+	}
+	return 0, nil
+}
+
+// getScaleTargetReadyReplicas returns the ready replicas of the scale target of the ScaledObject,
+// scale targets other than Deployments and StatefulSets report the replicas of their scale subresource
+func (e *scaleExecutor) getScaleTargetReadyReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (int32, error) {
+	gvkr := scaledObject.Status.ScaleTargetGVKR
+	if gvkr == nil {
+		return 0, nil
+	}
+	if gvkr.Group == "apps" && (gvkr.Kind == "Deployment" || gvkr.Kind == "StatefulSet") {
+		return e.getReadyReplicas(ctx, scaledObject.Namespace, gvkr.Kind, scaledObject.Spec.ScaleTargetRef.Name)
+	}
+	scale, err := e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, gvkr.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return scale.Status.Replicas, nil
+}
+
+func hasReadyPod(pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
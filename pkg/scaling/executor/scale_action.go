@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// ScaleAction is what RequestScale does with the ScaleTarget of a ScaledObject
+type ScaleAction int
+
+const (
+	// ScaleActionNone leaves the ScaleTarget to the HPA
+	ScaleActionNone ScaleAction = iota
+	// ScaleActionPause scales the ScaleTarget to the paused replica count
+	ScaleActionPause
+	// ScaleActionScaleToZeroBySchedule keeps the ScaleTarget scaled to zero for the active schedule
+	ScaleActionScaleToZeroBySchedule
+	// ScaleActionActivate scales the ScaleTarget from zero or idle, unless the readiness gate holds it back
+	ScaleActionActivate
+	// ScaleActionHoldOnError keeps the active ScaleTarget as is while some of its triggers fail
+	ScaleActionHoldOnError
+	// ScaleActionKeepActive refreshes the last active time and steps the activation ramp in progress
+	ScaleActionKeepActive
+	// ScaleActionFallback scales the ScaleTarget to the fallback replica count
+	ScaleActionFallback
+	// ScaleActionTriggerError reports the triggers of the inactive ScaledObject are failing
+	ScaleActionTriggerError
+	// ScaleActionDeactivate scales the ScaleTarget in to zero or idle once the ScaledObject cooled down
+	ScaleActionDeactivate
+	// ScaleActionScaleToMinReplicas scales the ScaleTarget up to minReplicaCount
+	ScaleActionScaleToMinReplicas
+)
+
+// GetScaleAction returns what RequestScale does with the ScaleTarget of the ScaledObject for its current replica count,
+// its activity, the errors of its triggers and its paused replica count, if any
+func GetScaleAction(scaledObject *kedav1alpha1.ScaledObject, currentReplicas int32, isActive, isError bool, pausedCount *int32) ScaleAction {
+	switch {
+	case pausedCount != nil:
+		return ScaleActionPause
+	case scaledObject.IsScaledToZeroBySchedule():
+		// an active schedule with maxReplicaCount 0 keeps the ScaleTarget scaled to zero
+		return ScaleActionScaleToZeroBySchedule
+	}
+
+	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0),
+	// the active schedule may override the min and idle replica counts
+	minReplicas := int32(0)
+	minReplicaCount := scaledObject.GetMinReplicaCount()
+	if minReplicaCount != nil {
+		minReplicas = *minReplicaCount
+	}
+	idleReplicaCount := scaledObject.GetIdleReplicaCount()
+
+	if isActive {
+		switch {
+		case idleReplicaCount != nil && currentReplicas < minReplicas,
+			// triggers are active, Idle Replicas mode is enabled
+			// AND
+			// replica count is less than minimum replica count
+
+			currentReplicas == 0:
+			// triggers are active
+			// AND
+			// replica count is equal to 0
+			return ScaleActionActivate
+		case isError:
+			// some triggers are active, but some responded with error
+			return ScaleActionHoldOnError
+		default:
+			// triggers are active, but we didn't need to scale (replica count > 0)
+			return ScaleActionKeepActive
+		}
+	}
+
+	switch {
+	case isError && scaledObject.Spec.Fallback != nil && scaledObject.Spec.Fallback.Replicas != 0:
+		// there are no active triggers, but a scaler responded with an error
+		// AND
+		// there is a fallback replicas count defined
+		return ScaleActionFallback
+	case isError && scaledObject.Spec.Fallback == nil:
+		// there are no active triggers, but a scaler responded with an error
+		// AND
+		// there is not a fallback replicas count defined
+		return ScaleActionTriggerError
+	case idleReplicaCount != nil && currentReplicas > *idleReplicaCount,
+		// there are no active triggers, Idle Replicas mode is enabled
+		// AND
+		// current replicas count is greater than Idle Replicas count
+
+		currentReplicas > 0 && minReplicas == 0:
+		// there are no active triggers, but the ScaleTarget has replicas
+		// AND
+		// there is no minimum configured or minimum is set to ZERO
+		return ScaleActionDeactivate
+	case currentReplicas < minReplicas && idleReplicaCount == nil:
+		// there are no active triggers
+		// AND
+		// ScaleTarget replicas count is less than minimum replica count specified in ScaledObject
+		// AND
+		// Idle Replicas mode is disabled
+		return ScaleActionScaleToMinReplicas
+	default:
+		// there are no active triggers
+		// AND
+		// nothing needs to be done (eg. deployment is scaled down)
+		return ScaleActionNone
+	}
+}
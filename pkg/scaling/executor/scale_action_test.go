@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetScaleAction(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            v1alpha1.ScaledObjectSpec
+		currentReplicas int32
+		isActive        bool
+		isError         bool
+		pausedCount     *int32
+		expected        ScaleAction
+	}{
+		{name: "paused", currentReplicas: 2, isActive: true, pausedCount: ptr.To[int32](0), expected: ScaleActionPause},
+		{name: "activated from zero", isActive: true, expected: ScaleActionActivate},
+		{name: "activated from idle", spec: v1alpha1.ScaledObjectSpec{MinReplicaCount: ptr.To[int32](2), IdleReplicaCount: ptr.To[int32](0)}, currentReplicas: 0, isActive: true, expected: ScaleActionActivate},
+		{name: "active with errors", currentReplicas: 2, isActive: true, isError: true, expected: ScaleActionHoldOnError},
+		{name: "active", currentReplicas: 2, isActive: true, expected: ScaleActionKeepActive},
+		{name: "fallback", spec: v1alpha1.ScaledObjectSpec{Fallback: &v1alpha1.Fallback{FailureThreshold: 3, Replicas: 5}}, currentReplicas: 2, isError: true, expected: ScaleActionFallback},
+		{name: "errors without fallback", currentReplicas: 2, isError: true, expected: ScaleActionTriggerError},
+		{name: "deactivated to zero", currentReplicas: 2, expected: ScaleActionDeactivate},
+		{name: "deactivated to idle", spec: v1alpha1.ScaledObjectSpec{MinReplicaCount: ptr.To[int32](2), IdleReplicaCount: ptr.To[int32](0)}, currentReplicas: 2, expected: ScaleActionDeactivate},
+		{name: "below min replicas", spec: v1alpha1.ScaledObjectSpec{MinReplicaCount: ptr.To[int32](2)}, currentReplicas: 1, expected: ScaleActionScaleToMinReplicas},
+		{name: "scaled to zero", expected: ScaleActionNone},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &v1alpha1.ScaledObject{Spec: test.spec}
+			action := GetScaleAction(scaledObject, test.currentReplicas, test.isActive, test.isError, test.pausedCount)
+			assert.Equal(t, test.expected, action)
+		})
+	}
+}
+
+func TestStepActivationRamp(t *testing.T) {
+	now := time.Now()
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			MaxReplicaCount: ptr.To[int32](8),
+			Advanced: &v1alpha1.AdvancedConfig{
+				ActivationRamp: &v1alpha1.ActivationRamp{
+					InitialReplicas: 2,
+					StepReplicas:    3,
+					StepInterval:    v1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+	}
+
+	assert.Nil(t, StartActivationRamp(scaledObject, 8, 10, now), "no ramp once activated with the target replicas")
+	scaledObject.Status.ActivationRamp = StartActivationRamp(scaledObject, 2, 10, now)
+	assert.Equal(t, &v1alpha1.ActivationRampStatus{TargetReplicas: 8, LastStepTime: v1.NewTime(now)}, scaledObject.Status.ActivationRamp)
+
+	// no step before the step interval elapsed, the target follows the desired replicas
+	replicas, ramp := StepActivationRamp(scaledObject, 2, 6, now.Add(30*time.Second))
+	assert.Equal(t, int32(2), replicas)
+	assert.Equal(t, &v1alpha1.ActivationRampStatus{TargetReplicas: 6, LastStepTime: v1.NewTime(now)}, ramp)
+
+	// one step once the step interval elapsed
+	replicas, ramp = StepActivationRamp(scaledObject, 2, 10, now.Add(time.Minute))
+	assert.Equal(t, int32(5), replicas)
+	assert.Equal(t, &v1alpha1.ActivationRampStatus{TargetReplicas: 8, LastStepTime: v1.NewTime(now.Add(time.Minute))}, ramp)
+
+	// the ramp ends with the step reaching the target
+	scaledObject.Status.ActivationRamp = ramp
+	replicas, ramp = StepActivationRamp(scaledObject, 5, 10, now.Add(2*time.Minute))
+	assert.Equal(t, int32(8), replicas)
+	assert.Nil(t, ramp)
+
+	// the ramp ends with the HPA catching up
+	replicas, ramp = StepActivationRamp(scaledObject, 8, 10, now.Add(90*time.Second))
+	assert.Equal(t, int32(8), replicas)
+	assert.Nil(t, ramp)
+}
@@ -19,7 +19,9 @@ package executor
 import (
 	"context"
 	"fmt"
+	"math"
 
+	"github.com/expr-lang/expr/vm"
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,18 +31,87 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
 const (
 	// Default cooldown period for a ScaleTarget if no cooldownPeriod is defined on the scaledObject
 	defaultCooldownPeriod = 5 * 60 // 5 minutes
+
+	// Default tolerance of the HPA controller, see --horizontal-pod-autoscaler-tolerance
+	hpaTolerance = 0.1
 )
 
 // ScaleExecutor contains methods RequestJobScale and RequestScale
 type ScaleExecutor interface {
-	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
-	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
+	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64, options *ScaleJobExecutorOptions)
+	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions)
+}
+
+// ScaleExecutorOptions contains the optional inputs of RequestScale
+type ScaleExecutorOptions struct {
+	// DesiredReplicas is the replica count derived from the current metrics, 0 if unknown
+	DesiredReplicas int32
+	// UsageRatio is the ratio of the metric values to their Value targets the HPA scales the current replica count by, nil if unknown
+	UsageRatio *float64
+}
+
+// ScaleJobExecutorOptions contains the optional inputs of RequestJobScale
+type ScaleJobExecutorOptions struct {
+	// CompiledScalingStrategy is the compiled expression of the expression scaling strategy, compiled on each call if nil
+	CompiledScalingStrategy *vm.Program
+	// WorkHints are the hints of the work the jobs to create should pick up, given to the jobs in order
+	WorkHints []scaledjob.WorkHint
+	// TriggerName is the trigger parameter of the jobs without work hint
+	TriggerName string
+}
+
+// GetCompiledScalingStrategy returns the compiled expression of the scaling strategy of the options, it is nil-safe
+func (o *ScaleJobExecutorOptions) GetCompiledScalingStrategy() *vm.Program {
+	if o == nil {
+		return nil
+	}
+	return o.CompiledScalingStrategy
+}
+
+// GetWorkHint returns the work hint of the index-th job to create, a hint without value
+// coming from TriggerName if there is none. It is nil-safe
+func (o *ScaleJobExecutorOptions) GetWorkHint(index int) scaledjob.WorkHint {
+	if o == nil {
+		return scaledjob.WorkHint{}
+	}
+	if index < len(o.WorkHints) {
+		return o.WorkHints[index]
+	}
+	return scaledjob.WorkHint{TriggerName: o.TriggerName}
+}
+
+// GetDesiredReplicas returns the desired replica count of the options, it is nil-safe
+func (o *ScaleExecutorOptions) GetDesiredReplicas() int32 {
+	if o == nil {
+		return 0
+	}
+	return o.DesiredReplicas
+}
+
+// GetHPAReplicas returns the replica count the HPA scales the ScaleTarget to from the current replica count,
+// ie. the largest of the desired replica count and the current replica count scaled by the usage ratio
+func (o *ScaleExecutorOptions) GetHPAReplicas(currentReplicas int32) int32 {
+	if o == nil || (o.DesiredReplicas == 0 && o.UsageRatio == nil) {
+		// without metrics the replica count can be derived from, the HPA keeps it as is
+		return currentReplicas
+	}
+	desiredReplicas := o.DesiredReplicas
+	if o.UsageRatio != nil {
+		replicas := currentReplicas
+		// the HPA doesn't scale while the usage ratio is within its tolerance
+		if math.Abs(*o.UsageRatio-1) > hpaTolerance {
+			replicas = int32(math.Min(math.Ceil(float64(currentReplicas)**o.UsageRatio), math.MaxInt32))
+		}
+		desiredReplicas = max(desiredReplicas, replicas)
+	}
+	return desiredReplicas
 }
 
 type scaleExecutor struct {
@@ -81,6 +152,20 @@ func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Lo
 	return kedastatus.TransformObject(ctx, e.client, logger, object, now, transform)
 }
 
+func (e *scaleExecutor) updateCreatedJobCount(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, createdJobCount int64) error {
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		createdJobCount, ok := target.(int64)
+		if !ok {
+			return fmt.Errorf("transform target is not int64 type %v", target)
+		}
+		if obj, ok := runtimeObj.(*kedav1alpha1.ScaledJob); ok {
+			obj.Status.CreatedJobCount = createdJobCount
+		}
+		return nil
+	}
+	return kedastatus.TransformObject(ctx, e.client, logger, scaledJob, createdJobCount, transform)
+}
+
 func (e *scaleExecutor) setCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string, setCondition func(kedav1alpha1.Conditions, metav1.ConditionStatus, string, string)) error {
 	type transformStruct struct {
 		status  metav1.ConditionStatus
@@ -126,3 +211,10 @@ func (e *scaleExecutor) setFallbackCondition(ctx context.Context, logger logr.Lo
 	}
 	return e.setCondition(ctx, logger, object, status, reason, message, fallback)
 }
+
+func (e *scaleExecutor) setWaitingCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string) error {
+	waiting := func(conditions kedav1alpha1.Conditions, status metav1.ConditionStatus, reason string, message string) {
+		conditions.SetWaitingCondition(status, reason, message)
+	}
+	return e.setCondition(ctx, logger, object, status, reason, message, waiting)
+}
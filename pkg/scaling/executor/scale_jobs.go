@@ -18,9 +18,13 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sort"
 	"strconv"
 
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +34,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
 	version "github.com/kedacore/keda/v2/version"
 )
 
@@ -38,15 +43,16 @@ const (
 	defaultFailedJobsHistoryLimit     = int32(100)
 )
 
-func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64) {
+func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64, options *ScaleJobExecutorOptions) {
 	logger := e.logger.WithValues("scaledJob.Name", scaledJob.Name, "scaledJob.Namespace", scaledJob.Namespace)
 
 	runningJobCount := e.getRunningJobCount(ctx, scaledJob)
 	pendingJobCount := e.getPendingJobCount(ctx, scaledJob)
+	reportReplicas(ctx, runningJobCount)
 	logger.Info("Scaling Jobs", "Number of running Jobs", runningJobCount)
 	logger.Info("Scaling Jobs", "Number of pending Jobs ", pendingJobCount)
 
-	effectiveMaxScale, scaleTo := e.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, logger)
+	effectiveMaxScale, scaleTo := e.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, options.GetCompiledScalingStrategy(), logger)
 
 	if effectiveMaxScale < 0 {
 		effectiveMaxScale = 0
@@ -60,7 +66,8 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		created := e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale, options)
+		reportScale(ctx, runningJobCount+created)
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
@@ -86,7 +93,14 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 	}
 }
 
-func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, runningJobCount int64, scaleTo int64, maxScale int64, pendingJobCount int64, logger logr.Logger) (int64, int64) {
+func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, runningJobCount int64, scaleTo int64, maxScale int64, pendingJobCount int64, compiledScalingStrategy *vm.Program, logger logr.Logger) (int64, int64) {
+	return GetScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, compiledScalingStrategy, logger)
+}
+
+// GetScalingDecision returns the effective max scale given by the scaling strategy of the ScaledJob and the number of jobs to create,
+// the jobs missing to reach minReplicaCount are created regardless of the strategy. The expression of the expression strategy
+// is compiled if compiledScalingStrategy is nil.
+func GetScalingDecision(scaledJob *kedav1alpha1.ScaledJob, runningJobCount int64, scaleTo int64, maxScale int64, pendingJobCount int64, compiledScalingStrategy *vm.Program, logger logr.Logger) (int64, int64) {
 	var effectiveMaxScale int64
 	minReplicaCount := scaledJob.MinReplicaCount()
 
@@ -95,31 +109,43 @@ func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, ru
 		scaleTo = scaleToMinReplica
 		effectiveMaxScale = scaleToMinReplica
 	} else {
-		effectiveMaxScale = NewScalingStrategy(logger, scaledJob).GetEffectiveMaxScale(maxScale, runningJobCount-minReplicaCount, pendingJobCount, scaledJob.MaxReplicaCount())
+		effectiveMaxScale = NewScalingStrategy(logger, scaledJob, compiledScalingStrategy).GetEffectiveMaxScale(maxScale, runningJobCount-minReplicaCount, pendingJobCount, scaledJob.MaxReplicaCount())
 	}
 	return effectiveMaxScale, scaleTo
 }
 
-func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64) {
+// createJobs creates up to maxScale jobs and returns the number of jobs created
+func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64, options *ScaleJobExecutorOptions) int64 {
 	logger.Info("Creating jobs", "Effective number of max jobs", maxScale)
 	if scaleTo > maxScale {
 		scaleTo = maxScale
 	}
 	logger.Info("Creating jobs", "Number of jobs", scaleTo)
 
-	jobs := e.generateJobs(logger, scaledJob, scaleTo)
+	jobs := e.generateJobs(logger, scaledJob, scaleTo, options)
+	created := int64(0)
 	for _, job := range jobs {
 		err := e.client.Create(ctx, job)
 		if err != nil {
 			logger.Error(err, "Failed to create a new Job")
+			continue
+		}
+		created++
+	}
+
+	// the counter moves on by the number of jobs generated, so that the counter parameter of a job is never reused
+	if scaledJob.Spec.JobTemplateParameters != nil && len(jobs) > 0 {
+		if err := e.updateCreatedJobCount(ctx, logger, scaledJob, scaledJob.Status.CreatedJobCount+int64(len(jobs))); err != nil {
+			logger.Error(err, "Failed to update the created job count")
 		}
 	}
 
 	logger.Info("Created jobs", "Number of jobs", scaleTo)
 	e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", scaleTo)
+	return created
 }
 
-func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64) []*batchv1.Job {
+func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, options *ScaleJobExecutorOptions) []*batchv1.Job {
 	scaledJob.Spec.JobTargetRef.Template.GenerateName = scaledJob.GetName() + "-"
 	if scaledJob.Spec.JobTargetRef.Template.Labels == nil {
 		scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{}
@@ -156,6 +182,10 @@ func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1
 			job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 		}
 
+		if scaledJob.Spec.JobTemplateParameters != nil {
+			applyJobTemplateParameters(job, scaledJob.Spec.JobTemplateParameters, scaledJob.Status.CreatedJobCount+int64(i), int64(i), options.GetWorkHint(i))
+		}
+
 		// Set ScaledJob instance as the owner and controller
 		err := controllerutil.SetControllerReference(scaledJob, job, e.reconcilerScheme)
 		if err != nil {
@@ -167,6 +197,70 @@ func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1
 	return jobs
 }
 
+// applyJobTemplateParameters injects the parameters into the job, counter is the sequence number of the job among all the jobs
+// created for the ScaledJob and index its index among the jobs created in this polling interval
+func applyJobTemplateParameters(job *batchv1.Job, parameters *kedav1alpha1.JobTemplateParameters, counter, index int64, hint scaledjob.WorkHint) {
+	for _, parameter := range parameters.Env {
+		env := corev1.EnvVar{Name: parameter.Name, Value: getJobTemplateParameterValue(parameter, counter, index, hint)}
+		for i := range job.Spec.Template.Spec.InitContainers {
+			job.Spec.Template.Spec.InitContainers[i].Env = setEnvVar(job.Spec.Template.Spec.InitContainers[i].Env, env)
+		}
+		for i := range job.Spec.Template.Spec.Containers {
+			job.Spec.Template.Spec.Containers[i].Env = setEnvVar(job.Spec.Template.Spec.Containers[i].Env, env)
+		}
+	}
+
+	if len(parameters.Annotations) == 0 {
+		return
+	}
+	// the annotations of the jobs are shared with the ScaledJob
+	annotations := make(map[string]string, len(job.Annotations)+len(parameters.Annotations))
+	for key, value := range job.Annotations {
+		annotations[key] = value
+	}
+	if job.Spec.Template.Annotations == nil {
+		job.Spec.Template.Annotations = map[string]string{}
+	}
+	for _, parameter := range parameters.Annotations {
+		value := getJobTemplateParameterValue(parameter, counter, index, hint)
+		annotations[parameter.Name] = value
+		job.Spec.Template.Annotations[parameter.Name] = value
+	}
+	job.Annotations = annotations
+}
+
+func getJobTemplateParameterValue(parameter kedav1alpha1.JobTemplateParameter, counter, index int64, hint scaledjob.WorkHint) string {
+	switch parameter.Source {
+	case kedav1alpha1.JobTemplateParameterSourceCounter:
+		if parameter.Modulo != nil && *parameter.Modulo > 0 {
+			counter %= *parameter.Modulo
+		}
+		return strconv.FormatInt(counter, 10)
+	case kedav1alpha1.JobTemplateParameterSourceIndex:
+		if parameter.Modulo != nil && *parameter.Modulo > 0 {
+			index %= *parameter.Modulo
+		}
+		return strconv.FormatInt(index, 10)
+	case kedav1alpha1.JobTemplateParameterSourceTrigger:
+		return hint.TriggerName
+	case kedav1alpha1.JobTemplateParameterSourceHint:
+		return hint.Hint
+	default:
+		return ""
+	}
+}
+
+// setEnvVar sets the environment variable, replacing the one of the same name if any
+func setEnvVar(envVars []corev1.EnvVar, env corev1.EnvVar) []corev1.EnvVar {
+	for i := range envVars {
+		if envVars[i].Name == env.Name {
+			envVars[i] = env
+			return envVars
+		}
+	}
+	return append(envVars, env)
+}
+
 func (e *scaleExecutor) isJobFinished(j *batchv1.Job) bool {
 	for _, c := range j.Status.Conditions {
 		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
@@ -371,11 +465,16 @@ func (e *scaleExecutor) getFinishedJobConditionType(j *batchv1.Job) batchv1.JobC
 	return ""
 }
 
-// NewScalingStrategy returns ScalingStrategy instance
-func NewScalingStrategy(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) ScalingStrategy {
+// NewScalingStrategy returns ScalingStrategy instance, the expression strategy runs the compiled expression if given
+func NewScalingStrategy(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, compiledExpression *vm.Program) ScalingStrategy {
 	switch scaledJob.Spec.ScalingStrategy.Strategy {
 	case "custom":
 		logger.V(1).Info("Selecting Scale Strategy", "specified", scaledJob.Spec.ScalingStrategy.Strategy, "selected:", "custom", "customScalingQueueLength", scaledJob.Spec.ScalingStrategy.CustomScalingQueueLengthDeduction, "customScallingRunningJobPercentage", scaledJob.Spec.ScalingStrategy.CustomScalingRunningJobPercentage)
+		if scaledJob.Spec.ScalingStrategy.CustomScalingQueueLengthDeduction == nil {
+			logger.V(1).Info("CustomScalingQueueLengthDeduction is not set")
+			logger.V(1).Info("Selecting Scale has been changed", "selected", "default")
+			return defaultScalingStrategy{}
+		}
 		var err error
 		if percentage, err := strconv.ParseFloat(scaledJob.Spec.ScalingStrategy.CustomScalingRunningJobPercentage, 64); err == nil {
 			return customScalingStrategy{
@@ -391,6 +490,21 @@ func NewScalingStrategy(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) S
 	case "accurate":
 		logger.V(1).Info("Selecting Scale Strategy", "specified", scaledJob.Spec.ScalingStrategy.Strategy, "selected", "accurate")
 		return accurateScalingStrategy{}
+	case kedav1alpha1.ExpressionScalingStrategy:
+		logger.V(1).Info("Selecting Scale Strategy", "specified", scaledJob.Spec.ScalingStrategy.Strategy, "selected", "expression", "expression", scaledJob.Spec.ScalingStrategy.Expression)
+		if compiledExpression == nil {
+			var err error
+			if compiledExpression, err = kedav1alpha1.CompileScalingStrategyExpression(scaledJob); err != nil {
+				logger.V(1).Info("Fail to compile the scaling strategy expression", "error", err, "expression", scaledJob.Spec.ScalingStrategy.Expression)
+				logger.V(1).Info("Selecting Scale has been changed", "selected", "default")
+				return defaultScalingStrategy{}
+			}
+		}
+		return expressionScalingStrategy{
+			logger:          logger,
+			program:         compiledExpression,
+			minReplicaCount: scaledJob.MinReplicaCount(),
+		}
 	default:
 		logger.V(1).Info("Selecting Scale Strategy", "specified", scaledJob.Spec.ScalingStrategy.Strategy, "selected", "default")
 		return defaultScalingStrategy{}
@@ -428,6 +542,29 @@ func (s accurateScalingStrategy) GetEffectiveMaxScale(maxScale, runningJobCount,
 	return maxScale - pendingJobCount
 }
 
+type expressionScalingStrategy struct {
+	logger          logr.Logger
+	program         *vm.Program
+	minReplicaCount int64
+}
+
+// GetEffectiveMaxScale runs the expression with maxScale as queueLength, the result is capped to maxReplicaCount.
+// No job is created if the expression fails.
+func (s expressionScalingStrategy) GetEffectiveMaxScale(maxScale, runningJobCount, pendingJobCount, maxReplicaCount int64) int64 {
+	env := kedav1alpha1.ScalingStrategyExpressionEnv(maxScale, runningJobCount, pendingJobCount, maxReplicaCount, s.minReplicaCount)
+	result, err := expr.Run(s.program, env)
+	if err != nil {
+		s.logger.Error(err, "Error running the scaling strategy expression")
+		return 0
+	}
+	value, ok := result.(float64)
+	if !ok || math.IsNaN(value) {
+		s.logger.Error(fmt.Errorf("expression returned %v", result), "Error running the scaling strategy expression")
+		return 0
+	}
+	return int64(math.Max(math.Min(value, float64(maxReplicaCount)), 0))
+}
+
 func min(x, y int64) int64 {
 	if x > y {
 		return y
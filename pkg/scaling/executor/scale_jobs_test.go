@@ -31,11 +31,13 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
 )
 
 func TestCleanUpNormalCase(t *testing.T) {
@@ -71,19 +73,19 @@ func TestCleanUpNormalCase(t *testing.T) {
 
 func TestNewNewScalingStrategy(t *testing.T) {
 	logger := logf.Log.WithName("ScaledJobTest")
-	strategy := NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", int32(10), "0"))
+	strategy := NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", int32(10), "0"), nil)
 	assert.Equal(t, "executor.customScalingStrategy", fmt.Sprintf("%T", strategy))
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("accurate", "accurate", int32(0), "0"))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("accurate", "accurate", int32(0), "0"), nil)
 	assert.Equal(t, "executor.accurateScalingStrategy", fmt.Sprintf("%T", strategy))
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithDefaultStrategy("default"))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithDefaultStrategy("default"), nil)
 	assert.Equal(t, "executor.defaultScalingStrategy", fmt.Sprintf("%T", strategy))
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("default", "default", int32(0), "0"))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("default", "default", int32(0), "0"), nil)
 	assert.Equal(t, "executor.defaultScalingStrategy", fmt.Sprintf("%T", strategy))
 }
 
 func TestDefaultScalingStrategy(t *testing.T) {
 	logger := logf.Log.WithName("ScaledJobTest")
-	strategy := NewScalingStrategy(logger, getMockScaledJobWithDefaultStrategy("default"))
+	strategy := NewScalingStrategy(logger, getMockScaledJobWithDefaultStrategy("default"), nil)
 	// maxScale doesn't exceed MaxReplicaCount. You can ignore on this sceanrio
 	// pendingJobCount isn't relevant on this scenario
 	assert.Equal(t, int64(1), strategy.GetEffectiveMaxScale(3, 2, 0, 5))
@@ -94,12 +96,12 @@ func TestCustomScalingStrategy(t *testing.T) {
 	logger := logf.Log.WithName("ScaledJobTest")
 	customScalingQueueLengthDeduction := int32(1)
 	customScalingRunningJobPercentage := "0.5"
-	strategy := NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage))
+	strategy := NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage), nil)
 	// maxScale doesn't exceed MaxReplicaCount. You can ignore on this sceanrio
 	// pendingJobCount isn't relevant on this scenario
 	assert.Equal(t, int64(1), strategy.GetEffectiveMaxScale(3, 2, 0, 5))
 	assert.Equal(t, int64(9), strategy.GetEffectiveMaxScale(10, 0, 0, 10))
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithCustomStrategyWithNilParameter("custom", "custom"))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithCustomStrategyWithNilParameter("custom", "custom"), nil)
 
 	// If you don't set the two parameters is the same behavior as DefaultStrategy
 	assert.Equal(t, int64(1), strategy.GetEffectiveMaxScale(3, 2, 0, 5))
@@ -108,25 +110,31 @@ func TestCustomScalingStrategy(t *testing.T) {
 	// Empty String will be DefaultStrategy
 	customScalingQueueLengthDeduction = int32(1)
 	customScalingRunningJobPercentage = ""
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage), nil)
+	assert.Equal(t, "executor.defaultScalingStrategy", fmt.Sprintf("%T", strategy))
+
+	// A missing customScalingQueueLengthDeduction will be DefaultStrategy
+	scaledJob := getMockScaledJobWithCustomStrategyWithNilParameter("custom", "custom")
+	scaledJob.Spec.ScalingStrategy.CustomScalingRunningJobPercentage = "0.5"
+	strategy = NewScalingStrategy(logger, scaledJob, nil)
 	assert.Equal(t, "executor.defaultScalingStrategy", fmt.Sprintf("%T", strategy))
 
 	// Set 0 as customScalingRunningJobPercentage
 	customScalingQueueLengthDeduction = int32(2)
 	customScalingRunningJobPercentage = "0"
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage), nil)
 	assert.Equal(t, int64(1), strategy.GetEffectiveMaxScale(3, 2, 0, 5))
 
 	// Exceed the MaxReplicaCount
 	customScalingQueueLengthDeduction = int32(-2)
 	customScalingRunningJobPercentage = "0"
-	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage))
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithStrategy("custom", "custom", customScalingQueueLengthDeduction, customScalingRunningJobPercentage), nil)
 	assert.Equal(t, int64(4), strategy.GetEffectiveMaxScale(3, 2, 0, 4))
 }
 
 func TestAccurateScalingStrategy(t *testing.T) {
 	logger := logf.Log.WithName("ScaledJobTest")
-	strategy := NewScalingStrategy(logger, getMockScaledJobWithStrategy("accurate", "accurate", 0, "0"))
+	strategy := NewScalingStrategy(logger, getMockScaledJobWithStrategy("accurate", "accurate", 0, "0"), nil)
 	// maxScale doesn't exceed MaxReplicaCount. You can ignore on this sceanrio
 	assert.Equal(t, int64(3), strategy.GetEffectiveMaxScale(3, 2, 0, 5))
 	assert.Equal(t, int64(3), strategy.GetEffectiveMaxScale(5, 2, 0, 5))
@@ -136,6 +144,28 @@ func TestAccurateScalingStrategy(t *testing.T) {
 	assert.Equal(t, int64(1), strategy.GetEffectiveMaxScale(5, 4, 2, 5))
 }
 
+func TestExpressionScalingStrategy(t *testing.T) {
+	logger := logf.Log.WithName("ScaledJobTest")
+	scaledJob := getMockScaledJobWithExpressionStrategy("queueLength - runningJobCount - pendingJobCount / 2")
+	strategy := NewScalingStrategy(logger, scaledJob, nil)
+	assert.Equal(t, "executor.expressionScalingStrategy", fmt.Sprintf("%T", strategy))
+	assert.Equal(t, int64(1), strategy.GetEffectiveMaxScale(3, 2, 0, 5))
+	assert.Equal(t, int64(2), strategy.GetEffectiveMaxScale(6, 2, 3, 10))
+	// the result is capped to maxReplicaCount and not negative
+	assert.Equal(t, int64(5), strategy.GetEffectiveMaxScale(20, 0, 0, 5))
+	assert.Equal(t, int64(0), strategy.GetEffectiveMaxScale(1, 4, 0, 5))
+
+	// the compiled expression is used if given
+	program, err := kedav1alpha1.CompileScalingStrategyExpression(getMockScaledJobWithExpressionStrategy("maxReplicaCount + minReplicaCount"))
+	assert.NoError(t, err)
+	strategy = NewScalingStrategy(logger, scaledJob, program)
+	assert.Equal(t, int64(4), strategy.GetEffectiveMaxScale(1, 0, 0, 4))
+
+	// an invalid expression selects the default strategy
+	strategy = NewScalingStrategy(logger, getMockScaledJobWithExpressionStrategy("queueLength +"), nil)
+	assert.Equal(t, "executor.defaultScalingStrategy", fmt.Sprintf("%T", strategy))
+}
+
 func TestCleanUpMixedCaseWithSortByTime(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -183,7 +213,7 @@ func TestRunningJobCountSmallerMinReplicaCount(t *testing.T) {
 	var maxScale int64
 	var pendingJobCount int64
 
-	effectiveMaxScale, scaleTo := scaleExecutor.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, scaleExecutor.logger)
+	effectiveMaxScale, scaleTo := scaleExecutor.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, nil, scaleExecutor.logger)
 	assert.Equal(t, int64(2), effectiveMaxScale)
 	assert.Equal(t, int64(2), scaleTo)
 }
@@ -197,7 +227,7 @@ func TestRunningJobCountIsDeductedFromMinReplicaCount(t *testing.T) {
 	var maxScale int64
 	var pendingJobCount int64
 
-	effectiveMaxScale, scaleTo := scaleExecutor.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, scaleExecutor.logger)
+	effectiveMaxScale, scaleTo := scaleExecutor.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, nil, scaleExecutor.logger)
 	assert.Equal(t, int64(1), effectiveMaxScale)
 	assert.Equal(t, int64(1), scaleTo)
 }
@@ -211,7 +241,7 @@ func TestRunningJobCountGreaterOrEqualMinReplicaCountExecutesScalingStrategy(t *
 	var maxScale int64 = 2
 	var pendingJobCount int64
 
-	effectiveMaxScale, scaleTo := scaleExecutor.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, scaleExecutor.logger)
+	effectiveMaxScale, scaleTo := scaleExecutor.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, nil, scaleExecutor.logger)
 	assert.Equal(t, int64(2), effectiveMaxScale)
 	assert.Equal(t, int64(2), scaleTo)
 }
@@ -311,7 +341,7 @@ func TestCreateJobs(t *testing.T) {
 		Return(nil)
 
 	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
-	scaleExecutor.createJobs(ctx, logger, scaledJob, 2, 2)
+	scaleExecutor.createJobs(ctx, logger, scaledJob, 2, 2, nil)
 }
 
 func TestGenerateJobs(t *testing.T) {
@@ -334,7 +364,7 @@ func TestGenerateJobs(t *testing.T) {
 	scaleExecutor := getMockScaleExecutor(client)
 	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
 
-	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2)
+	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2, nil)
 
 	assert.Equal(t, 2, len(jobs))
 	for _, j := range jobs {
@@ -344,6 +374,54 @@ func TestGenerateJobs(t *testing.T) {
 	}
 }
 
+func TestGenerateJobsWithTemplateParameters(t *testing.T) {
+	logger := logf.Log.WithName("GenerateJobsTest")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockScaledJobWithDefaultStrategyAndMeta("test")
+	scaledJob.Spec.JobTargetRef.Template.Spec.Containers = []v1.Container{
+		{Name: "worker", Env: []v1.EnvVar{{Name: "PARTITION", Value: "all"}, {Name: "LOG_LEVEL", Value: "info"}}},
+	}
+	scaledJob.Spec.JobTemplateParameters = &kedav1alpha1.JobTemplateParameters{
+		Env: []kedav1alpha1.JobTemplateParameter{
+			{Name: "PARTITION", Source: kedav1alpha1.JobTemplateParameterSourceCounter, Modulo: ptr.To[int64](4)},
+			{Name: "JOB_INDEX", Source: kedav1alpha1.JobTemplateParameterSourceIndex},
+			{Name: "WORK", Source: kedav1alpha1.JobTemplateParameterSourceHint},
+		},
+		Annotations: []kedav1alpha1.JobTemplateParameter{
+			{Name: "example.com/trigger", Source: kedav1alpha1.JobTemplateParameterSourceTrigger},
+		},
+	}
+	scaledJob.Status.CreatedJobCount = 7
+	options := &ScaleJobExecutorOptions{
+		WorkHints:   []scaledjob.WorkHint{{TriggerName: "kafka", Hint: "orders/3"}},
+		TriggerName: "rabbitmq",
+	}
+
+	jobs := scaleExecutor.generateJobs(logger, scaledJob, 2, options)
+
+	assert.Equal(t, 2, len(jobs))
+	assert.Equal(t, []v1.EnvVar{
+		{Name: "PARTITION", Value: "3"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "JOB_INDEX", Value: "0"},
+		{Name: "WORK", Value: "orders/3"},
+	}, jobs[0].Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, []v1.EnvVar{
+		{Name: "PARTITION", Value: "0"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "JOB_INDEX", Value: "1"},
+		{Name: "WORK", Value: ""},
+	}, jobs[1].Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, map[string]string{"test": "test", "example.com/trigger": "kafka"}, jobs[0].ObjectMeta.Annotations)
+	assert.Equal(t, map[string]string{"example.com/trigger": "kafka"}, jobs[0].Spec.Template.Annotations)
+	assert.Equal(t, map[string]string{"test": "test", "example.com/trigger": "rabbitmq"}, jobs[1].ObjectMeta.Annotations)
+	assert.Equal(t, map[string]string{"test": "test"}, scaledJob.ObjectMeta.Annotations, "the annotations of the ScaledJob must not be modified")
+	assert.Equal(t, []v1.EnvVar{{Name: "PARTITION", Value: "all"}, {Name: "LOG_LEVEL", Value: "info"}}, scaledJob.Spec.JobTargetRef.Template.Spec.Containers[0].Env)
+}
+
 type mockJobParameter struct {
 	Name             string
 	CompletionTime   string
@@ -415,6 +493,19 @@ func getMockScaledJobWithStrategy(name, scalingStrategy string, customScalingQue
 	return scaledJob
 }
 
+func getMockScaledJobWithExpressionStrategy(expression string) *kedav1alpha1.ScaledJob {
+	scaledJob := &kedav1alpha1.ScaledJob{
+		Spec: kedav1alpha1.ScaledJobSpec{
+			ScalingStrategy: kedav1alpha1.ScalingStrategy{
+				Strategy:   "expression",
+				Expression: expression,
+			},
+		},
+	}
+	scaledJob.ObjectMeta.Name = "expression"
+	return scaledJob
+}
+
 func getMockScaledJobWithCustomStrategyWithNilParameter(name, scalingStrategy string) *kedav1alpha1.ScaledJob {
 	scaledJob := &kedav1alpha1.ScaledJob{
 		Spec: kedav1alpha1.ScaledJobSpec{
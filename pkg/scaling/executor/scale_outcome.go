@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+)
+
+// ScaleOutcome is the replica count of the ScaleTarget, or the number of jobs of a ScaledJob,
+// before and after a RequestScale or RequestJobScale call
+type ScaleOutcome struct {
+	ReplicasBefore int64
+	ReplicasAfter  int64
+}
+
+type scaleOutcomeKey struct{}
+
+// WithScaleOutcome returns a context through which RequestScale and RequestJobScale report their outcome
+func WithScaleOutcome(ctx context.Context, outcome *ScaleOutcome) context.Context {
+	return context.WithValue(ctx, scaleOutcomeKey{}, outcome)
+}
+
+// reportReplicas reports the current replicas as both the replicas before and after the scale request,
+// the replicas after are then updated by reportScale when the ScaleTarget is scaled
+func reportReplicas(ctx context.Context, replicas int64) {
+	if outcome, ok := ctx.Value(scaleOutcomeKey{}).(*ScaleOutcome); ok {
+		outcome.ReplicasBefore = replicas
+		outcome.ReplicasAfter = replicas
+	}
+}
+
+// reportScale reports the replicas the ScaleTarget was scaled to
+func reportScale(ctx context.Context, replicas int64) {
+	if outcome, ok := ctx.Value(scaleOutcomeKey{}).(*ScaleOutcome); ok {
+		outcome.ReplicasAfter = replicas
+	}
+}
@@ -33,7 +33,7 @@ import (
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
-func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool) {
+func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions) {
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
 		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
@@ -69,6 +69,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		}
 		currentReplicas = currentScale.Spec.Replicas
 	}
+	reportReplicas(ctx, int64(currentReplicas))
 	// if the ScaledObject's triggers aren't in the error state,
 	// but ScaledObject.Status.ReadyCondition is set not set to 'true' -> set it back to 'true'
 	readyCondition := scaledObject.Status.Conditions.GetReadyCondition()
@@ -89,8 +90,16 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		logger.Error(err, "error getting the paused replica count on the current ScaledObject.")
 		return
 	}
-	status := scaledObject.Status.DeepCopy()
-	if pausedCount != nil {
+	// a ScaledObject in dry-run mode never scales the ScaleTarget, it only reports the replica count it would scale to
+	if scaledObject.IsDryRun() {
+		e.requestDryRunScale(ctx, logger, scaledObject, currentReplicas, isActive, isError, pausedCount, options)
+		return
+	}
+	switch action := GetScaleAction(scaledObject, currentReplicas, isActive, isError, pausedCount); action {
+	case ScaleActionPause:
+		// a paused ScaledObject doesn't resume its activation ramp
+		e.resetActivationRamp(ctx, logger, scaledObject)
+		status := scaledObject.Status.DeepCopy()
 		// Scale the target to the paused replica count
 		if *pausedCount != currentReplicas {
 			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *pausedCount)
@@ -113,64 +122,58 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			logger.Info("Successfully scaled target to paused replicas count", "paused replicas", *pausedCount)
 		}
 		return
-	}
-
-	// if scaledObject.Spec.MinReplicaCount is not set, then set the default value (0)
-	minReplicas := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		minReplicas = *scaledObject.Spec.MinReplicaCount
-	}
-
-	if isActive {
-		switch {
-		case scaledObject.Spec.IdleReplicaCount != nil && currentReplicas < minReplicas,
-			// triggers are active, Idle Replicas mode is enabled
-			// AND
-			// replica count is less than minimum replica count
-
-			currentReplicas == 0:
-			// triggers are active
-			// AND
-			// replica count is equal to 0
-
-			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale)
-		case isError:
-			// some triggers are active, but some responded with error
-
-			// Set ScaledObject.Status.ReadyCondition to Unknown
-			msg := "Some triggers defined in ScaledObject are not working correctly"
-			logger.V(1).Info(msg)
-			if !readyCondition.IsUnknown() {
-				if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionUnknown, "PartialTriggerError", msg); err != nil {
-					logger.Error(err, "error setting ready condition")
-				}
-			}
-		default:
-			// triggers are active, but we didn't need to scale (replica count > 0)
-
-			// update LastActiveTime to now
-			err := e.updateLastActiveTime(ctx, logger, scaledObject)
+	case ScaleActionScaleToZeroBySchedule:
+		e.resetActivationRamp(ctx, logger, scaledObject)
+		if currentReplicas != 0 {
+			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, 0)
 			if err != nil {
-				logger.Error(err, "Error updating last active time")
+				logger.Error(err, "error scaling target to zero for the active schedule", "schedule", scaledObject.Status.ActiveSchedule)
 				return
 			}
+			logger.Info("Successfully scaled target to zero for the active schedule",
+				"schedule", scaledObject.Status.ActiveSchedule,
+				"Original Replicas Count", currentReplicas)
 		}
-	} else {
+		return
+	case ScaleActionActivate:
+		// Scale the ScaleTarget up, unless the readiness gate holds it back
+		if e.isHeldBackByReadinessGate(ctx, logger, scaledObject) {
+			break
+		}
+		e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.GetDesiredReplicas())
+	case ScaleActionHoldOnError:
+		// the activation ramp in progress, if any, holds until the triggers recover
+
+		// Set ScaledObject.Status.ReadyCondition to Unknown
+		msg := "Some triggers defined in ScaledObject are not working correctly"
+		logger.V(1).Info(msg)
+		if !readyCondition.IsUnknown() {
+			if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionUnknown, "PartialTriggerError", msg); err != nil {
+				logger.Error(err, "error setting ready condition")
+			}
+		}
+	case ScaleActionKeepActive:
+		// update LastActiveTime to now
+		err := e.updateLastActiveTime(ctx, logger, scaledObject)
+		if err != nil {
+			logger.Error(err, "Error updating last active time")
+			return
+		}
+
+		// continue the activation ramp in progress, if any
+		e.stepActivationRamp(ctx, logger, scaledObject, currentScale, currentReplicas, options.GetDesiredReplicas())
+	default:
 		// isActive == false
-		switch {
-		case isError && scaledObject.Spec.Fallback != nil && scaledObject.Spec.Fallback.Replicas != 0:
-			// there are no active triggers, but a scaler responded with an error
-			// AND
-			// there is a fallback replicas count defined
+		// the readiness gate and the activation ramp only apply to an active ScaledObject,
+		// the fallback replicas count replaces the ramp
+		e.resetWaitingCondition(ctx, logger, scaledObject)
+		e.resetActivationRamp(ctx, logger, scaledObject)
 
+		switch action {
+		case ScaleActionFallback:
 			// Scale to the fallback replicas count
 			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas)
-		case isError && scaledObject.Spec.Fallback == nil:
-			// there are no active triggers, but a scaler responded with an error
-			// AND
-			// there is not a fallback replicas count defined
-
+		case ScaleActionTriggerError:
 			// Set ScaledObject.Status.ReadyCondition to false
 			msg := "Triggers defined in ScaledObject are not working correctly"
 			logger.V(1).Info(msg)
@@ -179,35 +182,19 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 					logger.Error(err, "error setting ready condition")
 				}
 			}
-		case scaledObject.Spec.IdleReplicaCount != nil && currentReplicas > *scaledObject.Spec.IdleReplicaCount,
-			// there are no active triggers, Idle Replicas mode is enabled
-			// AND
-			// current replicas count is greater than Idle Replicas count
-
-			currentReplicas > 0 && minReplicas == 0:
-			// there are no active triggers, but the ScaleTarget has replicas
-			// AND
-			// there is no minimum configured or minimum is set to ZERO
-
+		case ScaleActionDeactivate:
 			// Try to scale the deployment down, HPA will handle other scale in operations
 			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
-		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
-			// there are no active triggers
-			// AND
-			// ScaleTarget replicas count is less than minimum replica count specified in ScaledObject
-			// AND
-			// Idle Replicas mode is disabled
-
+		case ScaleActionScaleToMinReplicas:
 			// ScaleTarget replicas count to correct value
-			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *scaledObject.Spec.MinReplicaCount)
+			minReplicas := *scaledObject.GetMinReplicaCount()
+			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, minReplicas)
 			if err == nil {
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
-					"New Replicas Count", *scaledObject.Spec.MinReplicaCount)
+					"New Replicas Count", minReplicas)
 			}
 		default:
-			// there are no active triggers
-			// AND
 			// nothing needs to be done (eg. deployment is scaled down)
 			logger.V(1).Info("ScaleTarget no change")
 		}
@@ -230,11 +217,17 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 }
 
 func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32) {
-	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, scaledObject.Spec.Fallback.Replicas)
-	if err == nil {
-		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
-			"Original Replicas Count", currentReplicas,
-			"New Replicas Count", scaledObject.Spec.Fallback.Replicas)
+	// lastKnownMetric behavior scales to fallback.replicas here, the last known metric
+	// is then served to the HPA which adjusts the replicas count
+	replicas := scaledObject.Spec.Fallback.GetReplicas(currentReplicas)
+	if replicas != currentReplicas {
+		_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, replicas)
+		if err == nil {
+			logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback replicas",
+				"Original Replicas Count", currentReplicas,
+				"New Replicas Count", replicas,
+				"Fallback Behavior", scaledObject.Spec.Fallback.GetBehavior())
+		}
 	}
 	if e := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object"); e != nil {
 		logger.Error(e, "Error setting fallback condition")
@@ -244,22 +237,10 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 // An object will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
 func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
-	var cooldownPeriod time.Duration
-
-	if scaledObject.Spec.CooldownPeriod != nil {
-		cooldownPeriod = time.Second * time.Duration(*scaledObject.Spec.CooldownPeriod)
-	} else {
-		cooldownPeriod = time.Second * time.Duration(defaultCooldownPeriod)
-	}
-
-	// LastActiveTime can be nil if the ScaleTarget was scaled outside of KEDA.
-	// In this case we will ignore the cooldown period and scale it down
-	if scaledObject.Status.LastActiveTime == nil ||
-		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now()) {
-		// or last time a trigger was active was > cooldown period, so scale in.
-
-		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
+	idleValue, _ := getIdleOrMinimumReplicaCount(scaledObject)
+	cooldownPeriod := getCooldownPeriod(scaledObject, idleValue)
 
+	if scaleToReplicas, cooledDown := GetDeactivationReplicas(scaledObject, time.Now()); cooledDown {
 		currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, scaleToReplicas)
 		if err == nil {
 			msg := "Successfully set ScaleTarget replicas count to ScaledObject"
@@ -295,13 +276,65 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	}
 }
 
-func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
-	var replicas int32
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		replicas = *scaledObject.Spec.MinReplicaCount
-	} else {
-		replicas = 1
+// GetDeactivationReplicas returns the replica count the ScaleTarget is scaled in to, idleReplicaCount or minReplicaCount,
+// and whether the ScaledObject has cooled down at the given time so it can be scaled in.
+// LastActiveTime can be nil if the ScaleTarget was scaled outside of KEDA, the cooldown period is ignored then.
+func GetDeactivationReplicas(scaledObject *kedav1alpha1.ScaledObject, now time.Time) (int32, bool) {
+	idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
+	cooldownPeriod := getCooldownPeriod(scaledObject, idleValue)
+	return scaleToReplicas, scaledObject.Status.LastActiveTime == nil || isCooledDown(scaledObject, cooldownPeriod, now)
+}
+
+// getCooldownPeriod returns the cooldown period of the ScaledObject,
+// idleCooldownPeriod replaces cooldownPeriod when the ScaleTarget is scaled in to idleReplicaCount
+func getCooldownPeriod(scaledObject *kedav1alpha1.ScaledObject, idle bool) time.Duration {
+	switch {
+	case idle && scaledObject.Spec.IdleCooldownPeriod != nil:
+		return time.Second * time.Duration(*scaledObject.Spec.IdleCooldownPeriod)
+	case scaledObject.Spec.CooldownPeriod != nil:
+		return time.Second * time.Duration(*scaledObject.Spec.CooldownPeriod)
+	default:
+		return time.Second * time.Duration(defaultCooldownPeriod)
+	}
+}
+
+// GetTriggerCooldownPeriod returns the shortest cooldown period the last active time of the trigger is checked against,
+// the cooldown period of the trigger if it is set or else the shortest of the cooldown periods of the ScaledObject
+func GetTriggerCooldownPeriod(scaledObject *kedav1alpha1.ScaledObject, trigger kedav1alpha1.ScaleTriggers) time.Duration {
+	if trigger.CooldownPeriod != nil {
+		return time.Second * time.Duration(*trigger.CooldownPeriod)
 	}
+	cooldownPeriod, idleCooldownPeriod := getCooldownPeriod(scaledObject, false), getCooldownPeriod(scaledObject, true)
+	if idleCooldownPeriod < cooldownPeriod {
+		return idleCooldownPeriod
+	}
+	return cooldownPeriod
+}
+
+// isCooledDown returns whether the ScaledObject has been inactive for longer than the cooldown period,
+// with the last active time of each trigger, the cooldown period of each trigger counts from its own last active time
+func isCooledDown(scaledObject *kedav1alpha1.ScaledObject, cooldownPeriod time.Duration, now time.Time) bool {
+	if len(scaledObject.Status.TriggersLastActiveTime) == 0 {
+		return scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(now)
+	}
+	for i, trigger := range scaledObject.Spec.Triggers {
+		lastActiveTime, found := scaledObject.Status.TriggersLastActiveTime[kedav1alpha1.GetTriggerStatusKey(trigger, i)]
+		if !found {
+			continue
+		}
+		triggerCooldownPeriod := cooldownPeriod
+		if trigger.CooldownPeriod != nil {
+			triggerCooldownPeriod = time.Second * time.Duration(*trigger.CooldownPeriod)
+		}
+		if !lastActiveTime.Add(triggerCooldownPeriod).Before(now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, desiredReplicas int32) {
+	replicas := GetActivationReplicas(scaledObject)
 
 	currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
 
@@ -316,6 +349,7 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 			logger.Error(err, "Error in Updating lastScaleTime and lastActiveTime on the scaledObject")
 			return
 		}
+		e.startActivationRamp(ctx, logger, scaledObject, replicas, desiredReplicas)
 	} else {
 		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetActivationFailed, "Failed to scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 	}
@@ -340,25 +374,29 @@ func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObje
 	scale.Spec.Replicas = replicas
 
 	_, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
+	if err == nil {
+		reportScale(ctx, int64(replicas))
+	}
 	return currentReplicas, err
 }
 
 // getIdleOrMinimumReplicaCount returns true if the second value returned is from IdleReplicaCount
 // it returns false if it is from MinReplicaCount followed by the actual value
 func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool, int32) {
-	if scaledObject.Spec.IdleReplicaCount != nil {
-		return true, *scaledObject.Spec.IdleReplicaCount
+	if idleReplicaCount := scaledObject.GetIdleReplicaCount(); idleReplicaCount != nil {
+		return true, *idleReplicaCount
 	}
 
-	if scaledObject.Spec.MinReplicaCount == nil {
+	minReplicaCount := scaledObject.GetMinReplicaCount()
+	if minReplicaCount == nil {
 		return false, 0
 	}
 
-	return false, *scaledObject.Spec.MinReplicaCount
+	return false, *minReplicaCount
 }
 
-// GetPausedReplicaCount returns the paused replica count of the ScaledObject.
-// If not paused, it returns nil.
+// GetPausedReplicaCount returns the paused replica count of the ScaledObject, given by the
+// PausedReplicasAnnotation or the active pause schedule. If not paused, it returns nil.
 func GetPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, error) {
 	if scaledObject.Annotations != nil {
 		if val, ok := scaledObject.Annotations[kedav1alpha1.PausedReplicasAnnotation]; ok {
@@ -370,5 +408,13 @@ func GetPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, err
 			return &count, nil
 		}
 	}
+	// the paused annotations override the pause schedules
+	if scaledObject.HasPausedAnnotation() {
+		return nil, nil
+	}
+	if schedule, _ := scaledObject.GetActivePauseSchedule(time.Now()); schedule != nil && schedule.PausedReplicaCount != nil {
+		count := *schedule.PausedReplicaCount
+		return &count, nil
+	}
 	return nil, nil
 }
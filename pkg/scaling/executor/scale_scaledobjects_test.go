@@ -20,13 +20,16 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -88,13 +91,67 @@ func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
 	client.EXPECT().Status().Times(2).Return(statusWriter)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, true)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, true, nil)
 
 	assert.Equal(t, int32(5), scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetFallbackCondition()
 	assert.Equal(t, true, condition.IsTrue())
 }
 
+func TestKeepCurrentReplicasWhenHigherThanFallbackReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			Fallback: &v1alpha1.Fallback{
+				FailureThreshold: 3,
+				Replicas:         5,
+				Behavior:         v1alpha1.FallbackBehaviorCurrentReplicasIfHigher,
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(7)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	// current replicas are kept, the scale target isn't updated
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Times(0)
+
+	client.EXPECT().Status().Times(2).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, true, nil)
+
+	condition := scaledObject.Status.Conditions.GetFallbackCondition()
+	assert.Equal(t, true, condition.IsTrue())
+}
+
 func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock_client.NewMockClient(ctrl)
@@ -149,7 +206,7 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, nil)
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -210,7 +267,7 @@ func TestScaleToMinReplicasFromLowerInitialReplicaCount(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, nil)
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -269,7 +326,7 @@ func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	client.EXPECT().Status().Times(2).Return(statusWriter).Times(3)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, nil)
 
 	assert.Equal(t, int32(1), scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -332,7 +389,7 @@ func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, nil)
 
 	assert.Equal(t, idleReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -393,7 +450,7 @@ func TestScaleFromIdleToMinReplicasWhenActive(t *testing.T) {
 	client.EXPECT().Status().Times(2).Return(statusWriter).Times(3)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, nil)
 
 	assert.Equal(t, minReplicas, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
@@ -455,9 +512,269 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	client.EXPECT().Status().Return(statusWriter).Times(2)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 
-	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, nil)
 
 	assert.Equal(t, pausedReplicaCount, scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	assert.Equal(t, false, condition.IsTrue())
 }
+
+func TestScaleToZeroWhenScheduleMaxReplicasIsZero(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(2)
+	maxReplicas := int32(0)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			Advanced: &v1alpha1.AdvancedConfig{
+				Schedules: []v1alpha1.ReplicaSchedule{{
+					Name:            "maintenance",
+					Start:           "0 2 * * *",
+					End:             "0 4 * * *",
+					MaxReplicaCount: &maxReplicas,
+				}},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+			ActiveSchedule: "maintenance",
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(5)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: numberOfReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+
+	// the target is scaled to zero even though the triggers are active
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, nil)
+
+	assert.Equal(t, int32(0), scale.Spec.Replicas)
+}
+
+func TestWaitForReadinessGateBeforeScalingFromZero(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			ReadinessGate: &v1alpha1.ReadinessGate{
+				Workloads: []v1alpha1.ReadinessGateWorkload{{Name: "database"}},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(0)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Eq(types.NamespacedName{Name: "name", Namespace: "namespace"}), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+	client.EXPECT().Get(gomock.Any(), gomock.Eq(types.NamespacedName{Name: "database", Namespace: "namespace"}), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: 0,
+		},
+	})
+
+	// the scale target isn't updated, the Ready, Waiting and Active conditions are set
+	client.EXPECT().Status().Times(3).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, nil)
+
+	condition := scaledObject.Status.Conditions.GetWaitingCondition()
+	assert.True(t, condition.IsTrue())
+	assert.Equal(t, v1alpha1.ScaledObjectConditionWaitingReason, condition.Reason)
+	assert.Equal(t, "Deployment namespace/database has no ready replicas", condition.Message)
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestActivationRampFromZero(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MaxReplicaCount: ptr.To[int32](8),
+			Advanced: &v1alpha1.AdvancedConfig{
+				ActivationRamp: &v1alpha1.ActivationRamp{
+					InitialReplicas: 2,
+					StepReplicas:    3,
+					StepInterval:    v1.Duration{Duration: time.Minute},
+				},
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	expectReplicas := func(replicas int32) *autoscalingv1.Scale {
+		client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+			},
+		})
+		return &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: replicas}}
+	}
+
+	// activated with the initial replicas, the ramp targets the desired replicas capped at maxReplicaCount,
+	// the Ready condition, the last active time, the ramp and the Active condition are set
+	scale := expectReplicas(0)
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+	client.EXPECT().Status().Times(4).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(4)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: 10})
+
+	assert.Equal(t, int32(2), scale.Spec.Replicas)
+	assert.NotNil(t, scaledObject.Status.ActivationRamp)
+	assert.Equal(t, int32(8), scaledObject.Status.ActivationRamp.TargetReplicas)
+
+	// no step before the step interval elapsed
+	expectReplicas(2)
+	client.EXPECT().Status().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: 10})
+
+	// one step once the step interval elapsed, the last active time and the ramp are updated
+	scaledObject.Status.ActivationRamp.LastStepTime = v1.NewTime(time.Now().Add(-2 * time.Minute))
+	scale = expectReplicas(2)
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+	client.EXPECT().Status().Times(2).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: 10})
+
+	assert.Equal(t, int32(5), scale.Spec.Replicas)
+	assert.NotNil(t, scaledObject.Status.ActivationRamp)
+	assert.WithinDuration(t, time.Now(), scaledObject.Status.ActivationRamp.LastStepTime.Time, time.Minute)
+
+	// the ramp is dropped once paused, the ramp and the paused replica count are updated
+	scaledObject.Annotations = map[string]string{v1alpha1.PausedReplicasAnnotation: "5"}
+	expectReplicas(5)
+	client.EXPECT().Status().Times(2).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: 10})
+
+	assert.Nil(t, scaledObject.Status.ActivationRamp)
+}
+
+func TestIsCooledDownWithTriggerCooldownPeriods(t *testing.T) {
+	now := time.Now()
+	lastActive := func(ago time.Duration) v1.Time {
+		return v1.NewTime(now.Add(-ago))
+	}
+
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			CooldownPeriod:     ptr.To[int32](600),
+			IdleCooldownPeriod: ptr.To[int32](60),
+			Triggers: []v1alpha1.ScaleTriggers{
+				{Name: "queue", Type: "rabbitmq"},
+				{Type: "cron", CooldownPeriod: ptr.To[int32](0)},
+			},
+		},
+	}
+	assert.Equal(t, 600*time.Second, getCooldownPeriod(scaledObject, false))
+	assert.Equal(t, 60*time.Second, getCooldownPeriod(scaledObject, true))
+	assert.Equal(t, 60*time.Second, GetTriggerCooldownPeriod(scaledObject, scaledObject.Spec.Triggers[0]))
+	assert.Equal(t, time.Duration(0), GetTriggerCooldownPeriod(scaledObject, scaledObject.Spec.Triggers[1]))
+
+	// without the last active time of the triggers, status.lastActiveTime is used
+	scaledObject.Status.LastActiveTime = ptr.To(lastActive(5 * time.Minute))
+	assert.False(t, isCooledDown(scaledObject, 10*time.Minute, now))
+	assert.True(t, isCooledDown(scaledObject, time.Minute, now))
+
+	// the cron trigger releases immediately, the queue trigger waits for the cooldown period
+	scaledObject.Status.TriggersLastActiveTime = map[string]v1.Time{
+		"queue":  lastActive(20 * time.Minute),
+		"cron-1": lastActive(time.Second),
+	}
+	assert.True(t, isCooledDown(scaledObject, 10*time.Minute, now))
+
+	scaledObject.Status.TriggersLastActiveTime["queue"] = lastActive(5 * time.Minute)
+	assert.False(t, isCooledDown(scaledObject, 10*time.Minute, now))
+	assert.True(t, isCooledDown(scaledObject, time.Minute, now))
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// metricsWatcherBufferSize is the number of notifications a watcher can fall behind before they are dropped
+const metricsWatcherBufferSize = 1024
+
+// MetricsRefresh notifies that the scale loop of a ScaledObject refreshed its metrics
+type MetricsRefresh struct {
+	types.NamespacedName
+	// MetricNames are the external metrics of the ScaledObject queried by its HPA
+	MetricNames []string
+	// PollingInterval is the interval of the scale loop, ie. of the refreshes
+	PollingInterval time.Duration
+}
+
+// newMetricsRefresh returns the refresh of the metrics of the ScaledObject
+func newMetricsRefresh(scaledObject *kedav1alpha1.ScaledObject) MetricsRefresh {
+	metricNames := scaledObject.Status.ExternalMetricNames
+	// the HPA only queries the composite metric when scalingModifiers are used
+	if scaledObject.Status.CompositeScalerName != "" {
+		metricNames = []string{scaledObject.Status.CompositeScalerName}
+	}
+	refresh := MetricsRefresh{
+		NamespacedName: types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name},
+		MetricNames:    metricNames,
+	}
+	if withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scaledObject); err == nil {
+		refresh.PollingInterval = withTriggers.GetPollingInterval()
+	}
+	return refresh
+}
+
+// WatchScaledObjectMetrics returns a channel receiving the ScaledObjects whose metrics were refreshed by
+// their scale loop, the channel is closed once the context is done. Notifications are dropped for watchers
+// which don't keep up, so they must not rely on receiving all of them.
+func (h *scaleHandler) WatchScaledObjectMetrics(ctx context.Context) <-chan MetricsRefresh {
+	return h.metricsWatchers.watch(ctx)
+}
+
+// metricsWatchers notifies the watchers of the metrics of the ScaledObjects
+type metricsWatchers struct {
+	lock     sync.Mutex
+	watchers map[chan MetricsRefresh]struct{}
+}
+
+func newMetricsWatchers() *metricsWatchers {
+	return &metricsWatchers{watchers: map[chan MetricsRefresh]struct{}{}}
+}
+
+func (w *metricsWatchers) watch(ctx context.Context) <-chan MetricsRefresh {
+	ch := make(chan MetricsRefresh, metricsWatcherBufferSize)
+	w.lock.Lock()
+	w.watchers[ch] = struct{}{}
+	w.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.lock.Lock()
+		delete(w.watchers, ch)
+		close(ch)
+		w.lock.Unlock()
+	}()
+	return ch
+}
+
+// notify notifies the watchers without blocking the scale loop
+func (w *metricsWatchers) notify(refresh MetricsRefresh) {
+	if w == nil {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for ch := range w.watchers {
+		select {
+		case ch <- refresh:
+		default:
+		}
+	}
+}
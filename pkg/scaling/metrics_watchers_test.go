@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestNewMetricsRefresh(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{PollingInterval: ptr.To[int32](10)},
+		Status:     kedav1alpha1.ScaledObjectStatus{ExternalMetricNames: []string{"s0-queue", "s1-cron"}},
+	}
+
+	refresh := newMetricsRefresh(scaledObject)
+	assert.Equal(t, types.NamespacedName{Namespace: "default", Name: "worker"}, refresh.NamespacedName)
+	assert.Equal(t, []string{"s0-queue", "s1-cron"}, refresh.MetricNames)
+	assert.Equal(t, 10*time.Second, refresh.PollingInterval)
+
+	scaledObject.Status.CompositeScalerName = kedav1alpha1.CompositeMetricName
+	assert.Equal(t, []string{kedav1alpha1.CompositeMetricName}, newMetricsRefresh(scaledObject).MetricNames, "the HPA only queries the composite metric")
+}
+
+func TestMetricsWatchers(t *testing.T) {
+	watchers := newMetricsWatchers()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := watchers.watch(ctx)
+
+	refresh := MetricsRefresh{NamespacedName: types.NamespacedName{Namespace: "default", Name: "worker"}}
+	watchers.notify(refresh)
+	assert.Equal(t, refresh, <-ch)
+
+	// the notifications are dropped rather than blocking the scale loop
+	for i := 0; i < metricsWatcherBufferSize+1; i++ {
+		watchers.notify(refresh)
+	}
+	assert.Len(t, ch, metricsWatcherBufferSize)
+
+	cancel()
+	received := 0
+	for range ch {
+		received++
+	}
+	assert.Equal(t, metricsWatcherBufferSize, received, "the channel is closed once the context is done")
+	assert.NotPanics(t, func() { watchers.notify(refresh) }, "the closed channel isn't notified")
+
+	var nilWatchers *metricsWatchers
+	assert.NotPanics(t, func() { nilWatchers.notify(refresh) })
+}
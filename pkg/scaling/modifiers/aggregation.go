@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// calculateScalingModifiersAggregation creates custom composite metric by
+// combining all metrics with the aggregation operator and returns this
+// finalized metric
+func calculateScalingModifiersAggregation(sm kedav1alpha1.ScalingModifiers, list []external_metrics.ExternalMetricValue, pairList map[string]string) ([]external_metrics.ExternalMetricValue, error) {
+	var ret external_metrics.ExternalMetricValue
+	ret.MetricName = kedav1alpha1.CompositeMetricName
+	ret.Timestamp = v1.Now()
+
+	var out float64
+	switch sm.Aggregation {
+	case kedav1alpha1.AggregationSum:
+		for _, v := range list {
+			out += v.Value.AsApproximateFloat64()
+		}
+	case kedav1alpha1.AggregationAvg:
+		for _, v := range list {
+			out += v.Value.AsApproximateFloat64()
+		}
+		if len(list) > 0 {
+			out /= float64(len(list))
+		}
+	case kedav1alpha1.AggregationMin:
+		out = math.Inf(1)
+		for _, v := range list {
+			out = math.Min(out, v.Value.AsApproximateFloat64())
+		}
+	case kedav1alpha1.AggregationMax:
+		out = math.Inf(-1)
+		for _, v := range list {
+			out = math.Max(out, v.Value.AsApproximateFloat64())
+		}
+	case kedav1alpha1.AggregationWeighted:
+		for _, v := range list {
+			weight, err := getTriggerWeight(sm, pairList[v.MetricName])
+			if err != nil {
+				return nil, err
+			}
+			out += weight * v.Value.AsApproximateFloat64()
+		}
+	default:
+		return nil, fmt.Errorf("unknown scalingModifiers aggregation %q", sm.Aggregation)
+	}
+
+	// min and max of no metrics
+	if math.IsInf(out, 0) {
+		out = 0
+	}
+
+	ret.Value.SetMilli(int64(out * 1000))
+	return []external_metrics.ExternalMetricValue{ret}, nil
+}
+
+// getTriggerWeight returns the weight of the trigger, 1 if it isn't set
+func getTriggerWeight(sm kedav1alpha1.ScalingModifiers, trigger string) (float64, error) {
+	weight, found := sm.Weights[trigger]
+	if !found {
+		return 1, nil
+	}
+	value, err := strconv.ParseFloat(weight, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing weight %q of trigger %q: %w", weight, trigger, err)
+	}
+	return value, nil
+}
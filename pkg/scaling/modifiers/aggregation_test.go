@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestCalculateScalingModifiersAggregation(t *testing.T) {
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-rabbitmq-queue", Value: *resource.NewQuantity(4, resource.DecimalSI)},
+		{MetricName: "s1-kafka-topic", Value: *resource.NewMilliQuantity(1500, resource.DecimalSI)},
+		{MetricName: "s2-prometheus", Value: *resource.NewQuantity(10, resource.DecimalSI)},
+	}
+	pairList := map[string]string{
+		"s0-rabbitmq-queue": "queue",
+		"s1-kafka-topic":    "lag",
+		"s2-prometheus":     "requests",
+	}
+
+	tests := []struct {
+		name           string
+		sm             kedav1alpha1.ScalingModifiers
+		metrics        []external_metrics.ExternalMetricValue
+		expected       float64
+		expectedErrMsg string
+	}{
+		{
+			name:     "sum",
+			sm:       kedav1alpha1.ScalingModifiers{Aggregation: kedav1alpha1.AggregationSum},
+			metrics:  metrics,
+			expected: 15.5,
+		},
+		{
+			name:     "avg",
+			sm:       kedav1alpha1.ScalingModifiers{Aggregation: kedav1alpha1.AggregationAvg},
+			metrics:  metrics,
+			expected: 5.166,
+		},
+		{
+			name:     "min",
+			sm:       kedav1alpha1.ScalingModifiers{Aggregation: kedav1alpha1.AggregationMin},
+			metrics:  metrics,
+			expected: 1.5,
+		},
+		{
+			name:     "max",
+			sm:       kedav1alpha1.ScalingModifiers{Aggregation: kedav1alpha1.AggregationMax},
+			metrics:  metrics,
+			expected: 10,
+		},
+		{
+			name: "weighted",
+			sm: kedav1alpha1.ScalingModifiers{
+				Aggregation: kedav1alpha1.AggregationWeighted,
+				Weights:     map[string]string{"queue": "0.5", "lag": "2"},
+			},
+			metrics:  metrics,
+			expected: 15,
+		},
+		{
+			name:     "no metrics",
+			sm:       kedav1alpha1.ScalingModifiers{Aggregation: kedav1alpha1.AggregationMin},
+			expected: 0,
+		},
+		{
+			name: "invalid weight",
+			sm: kedav1alpha1.ScalingModifiers{
+				Aggregation: kedav1alpha1.AggregationWeighted,
+				Weights:     map[string]string{"queue": "heavy"},
+			},
+			metrics:        metrics,
+			expectedErrMsg: `error parsing weight "heavy" of trigger "queue"`,
+		},
+		{
+			name:           "unknown aggregation",
+			sm:             kedav1alpha1.ScalingModifiers{Aggregation: "median"},
+			metrics:        metrics,
+			expectedErrMsg: `unknown scalingModifiers aggregation "median"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := calculateScalingModifiersAggregation(test.sm, test.metrics, pairList)
+			if test.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, result, 1)
+			assert.Equal(t, kedav1alpha1.CompositeMetricName, result[0].MetricName)
+			assert.InDelta(t, test.expected, result[0].Value.AsApproximateFloat64(), 0.001)
+		})
+	}
+}
@@ -20,7 +20,8 @@ limitations under the License.
 // function is HandleScalingModifiers() that is called from scale_handler.
 // If fallback is active or the struct scalingModifiers in SO is not defined,
 // input metrics are simply returned without change, otherwise apply formula if
-// conditions are met.
+// conditions are met. Window functions available in the formula are evaluated
+// over the trigger values recorded in the scalers cache (window.go).
 // ************************************************************************** \\
 
 package modifiers
@@ -42,21 +43,32 @@ import (
 // If the structure is defined and conditions are met, apply the formula to
 // manipulate the metrics and return them
 func HandleScalingModifiers(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, fallbackActive bool, cacheObj *cache.ScalersCache, log logr.Logger) []external_metrics.ExternalMetricValue {
-	var err error
 	// dont manipulate with metrics if fallback is currently active or structure isnt defined
 	if !fallbackActive && so != nil && so.IsUsingModifiers() {
-		sm := so.Spec.Advanced.ScalingModifiers
+		return handleScalingModifiers(so.Spec.Advanced.ScalingModifiers, metrics, metricTriggerList, cacheObj, log)
+	}
+	return metrics
+}
 
-		// apply formula if defined
-		metrics, err = applyScalingModifiersFormula(sm, metrics, metricTriggerList, cacheObj)
-		if err != nil {
-			log.Error(err, "error applying custom scalingModifiers.Formula")
-		}
-		log.V(1).Info("returned metrics after formula is applied", "metrics", metrics)
+// HandleScaledJobScalingModifiers is HandleScalingModifiers for the
+// scalingModifiers of a ScaledJob
+func HandleScaledJobScalingModifiers(sj *kedav1alpha1.ScaledJob, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, fallbackActive bool, cacheObj *cache.ScalersCache, log logr.Logger) []external_metrics.ExternalMetricValue {
+	if !fallbackActive && sj != nil && sj.IsUsingModifiers() {
+		return handleScalingModifiers(sj.Spec.ScalingModifiers, metrics, metricTriggerList, cacheObj, log)
 	}
 	return metrics
 }
 
+func handleScalingModifiers(sm kedav1alpha1.ScalingModifiers, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, cacheObj *cache.ScalersCache, log logr.Logger) []external_metrics.ExternalMetricValue {
+	// apply formula if defined
+	metrics, err := applyScalingModifiersFormula(sm, metrics, metricTriggerList, cacheObj)
+	if err != nil {
+		log.Error(err, "error applying custom scalingModifiers.Formula")
+	}
+	log.V(1).Info("returned metrics after formula is applied", "metrics", metrics)
+	return metrics
+}
+
 // ArrayContainsElement determines whether array 'arr' contains element 'el'
 func ArrayContainsElement(el string, arr []string) bool {
 	for _, item := range arr {
@@ -67,13 +79,17 @@ func ArrayContainsElement(el string, arr []string) bool {
 	return false
 }
 
-// applyScalingModifiersFormula applies formula if formula is defined, otherwise
-// skip
+// applyScalingModifiersFormula applies formula or aggregation if one of them is
+// defined, otherwise skip
 func applyScalingModifiersFormula(sm kedav1alpha1.ScalingModifiers, metrics []external_metrics.ExternalMetricValue, pairList map[string]string, cacheObj *cache.ScalersCache) ([]external_metrics.ExternalMetricValue, error) {
 	if sm.Formula != "" {
 		metrics, err := calculateScalingModifiersFormula(metrics, cacheObj, pairList)
 		return metrics, err
 	}
+	if sm.Aggregation != "" {
+		metrics, err := calculateScalingModifiersAggregation(sm, metrics, pairList)
+		return metrics, err
+	}
 	return metrics, nil
 }
 
@@ -95,8 +111,10 @@ func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValu
 		return nil, fmt.Errorf("cached compiled formula is nil during its calculation")
 	}
 
-	// run expression with precompiled formula and real data
-	tmp, err := expr.Run(cacheObj.CompiledFormula, data)
+	// run expression with precompiled formula and real data, the window functions
+	// read the values recorded by the scale loop with RecordMetricsHistory
+	env := kedav1alpha1.ScalingModifiersEnv(data, windowFunctions(cacheObj.MetricsHistory, cacheObj.Now()))
+	tmp, err := expr.Run(cacheObj.CompiledFormula, env)
 	if err != nil {
 		return nil, fmt.Errorf("error trying to run custom formula: %w", err)
 	}
@@ -107,12 +125,41 @@ func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValu
 	return []external_metrics.ExternalMetricValue{ret}, nil
 }
 
+// RecordMetricsHistory records the trigger values of the metrics for the scalingModifiers window functions.
+// It is only called from the scale loop, so each poll adds a single sample per trigger whatever the number
+// of times the HPA reads the metrics.
+func RecordMetricsHistory(cacheObj *cache.ScalersCache, metrics []external_metrics.ExternalMetricValue, pairList map[string]string) {
+	if cacheObj == nil || cacheObj.MetricsHistory == nil {
+		return
+	}
+	now := cacheObj.Now()
+	for _, v := range metrics {
+		if trigger, ok := pairList[v.MetricName]; ok {
+			cacheObj.MetricsHistory.Record(trigger, v.Value.AsApproximateFloat64(), now)
+		}
+	}
+}
+
 // GetPairTriggerAndMetric adds new pair of trigger-metric to the list for
 // scalingModifiers formula list thats needed to map the metric value to
-// trigger name. This is only ran if scalingModifiers.Formula is defined in SO.
+// trigger name. This is only ran if scalingModifiers.Formula or the weighted
+// scalingModifiers.Aggregation is defined in SO.
 func GetPairTriggerAndMetric(so *kedav1alpha1.ScaledObject, metric string, trigger string) (map[string]string, error) {
+	if so.Spec.Advanced == nil {
+		return map[string]string{}, nil
+	}
+	return getPairTriggerAndMetric(so.Spec.Advanced.ScalingModifiers, metric, trigger)
+}
+
+// GetScaledJobPairTriggerAndMetric is GetPairTriggerAndMetric for the
+// scalingModifiers of a ScaledJob
+func GetScaledJobPairTriggerAndMetric(sj *kedav1alpha1.ScaledJob, metric string, trigger string) (map[string]string, error) {
+	return getPairTriggerAndMetric(sj.Spec.ScalingModifiers, metric, trigger)
+}
+
+func getPairTriggerAndMetric(sm kedav1alpha1.ScalingModifiers, metric string, trigger string) (map[string]string, error) {
 	list := map[string]string{}
-	if so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Formula != "" {
+	if sm.RequiresTriggerNames() {
 		if trigger == "" {
 			return list, fmt.Errorf("trigger name not given with compositeScaler for metric %s", metric)
 		}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// windowFunctions returns the implementation of the scalingModifiers window
+// functions, evaluated over the trigger values recorded in history up to now
+func windowFunctions(history *cache.MetricsHistory, now time.Time) kedav1alpha1.ScalingModifiersWindowFunc {
+	return func(fn, trigger string, window time.Duration) (float64, error) {
+		if history == nil {
+			return 0, fmt.Errorf("%s: history of trigger values is not available", fn)
+		}
+		samples := history.Window(trigger, window, now)
+		if len(samples) == 0 {
+			return 0, fmt.Errorf("%s: no values of trigger %q in the last %s", fn, trigger, window)
+		}
+
+		switch fn {
+		case "avg_over":
+			return avgOver(samples), nil
+		case "max_over":
+			return maxOver(samples), nil
+		case "min_over":
+			return minOver(samples), nil
+		case "rate":
+			return rate(samples), nil
+		case "ewma":
+			return ewma(samples, window), nil
+		default:
+			return 0, fmt.Errorf("unknown scalingModifiers function %q", fn)
+		}
+	}
+}
+
+func avgOver(samples []cache.MetricSample) float64 {
+	sum := 0.0
+	for _, s := range samples {
+		sum += s.Value
+	}
+	return sum / float64(len(samples))
+}
+
+func maxOver(samples []cache.MetricSample) float64 {
+	out := math.Inf(-1)
+	for _, s := range samples {
+		out = math.Max(out, s.Value)
+	}
+	return out
+}
+
+func minOver(samples []cache.MetricSample) float64 {
+	out := math.Inf(1)
+	for _, s := range samples {
+		out = math.Min(out, s.Value)
+	}
+	return out
+}
+
+// rate returns the per-second change of the value between the first and the last sample
+func rate(samples []cache.MetricSample) float64 {
+	first, last := samples[0], samples[len(samples)-1]
+	elapsed := last.Timestamp.Sub(first.Timestamp).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return (last.Value - first.Value) / elapsed
+}
+
+// ewma returns the exponentially weighted moving average of the samples, the
+// window is used as time constant so irregularly spaced samples are weighted
+// by the time elapsed since the previous one
+func ewma(samples []cache.MetricSample, window time.Duration) float64 {
+	out := samples[0].Value
+	for i := 1; i < len(samples); i++ {
+		elapsed := samples[i].Timestamp.Sub(samples[i-1].Timestamp)
+		alpha := 1 - math.Exp(-elapsed.Seconds()/window.Seconds())
+		out += alpha * (samples[i].Value - out)
+	}
+	return out
+}
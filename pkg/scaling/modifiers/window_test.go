@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestWindowFunctions(t *testing.T) {
+	now := time.Now()
+	history := cache.NewMetricsHistory()
+	history.Record("lag", 10, now.Add(-20*time.Minute))
+	history.Record("lag", 4, now.Add(-4*time.Minute))
+	history.Record("lag", 8, now.Add(-2*time.Minute))
+	history.Record("lag", 0, now)
+
+	eval := windowFunctions(history, now)
+
+	tests := []struct {
+		fn             string
+		trigger        string
+		window         time.Duration
+		expected       float64
+		expectedErrMsg string
+	}{
+		{fn: "avg_over", trigger: "lag", window: 5 * time.Minute, expected: 4},
+		{fn: "avg_over", trigger: "lag", window: time.Hour, expected: 5.5},
+		{fn: "max_over", trigger: "lag", window: 5 * time.Minute, expected: 8},
+		{fn: "min_over", trigger: "lag", window: 5 * time.Minute, expected: 0},
+		{fn: "rate", trigger: "lag", window: 5 * time.Minute, expected: -1.0 / 60},
+		{fn: "rate", trigger: "lag", window: time.Second, expected: 0},
+		{fn: "ewma", trigger: "lag", window: time.Second, expected: 0},
+		{fn: "avg_over", trigger: "queue", window: time.Minute, expectedErrMsg: `no values of trigger "queue"`},
+		{fn: "median", trigger: "lag", window: time.Minute, expectedErrMsg: `unknown scalingModifiers function "median"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fn+"/"+test.window.String(), func(t *testing.T) {
+			value, err := eval(test.fn, test.trigger, test.window)
+			if test.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, test.expectedErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expected, value, 0.001)
+		})
+	}
+
+	_, err := windowFunctions(nil, now)("avg_over", "lag", time.Minute)
+	assert.ErrorContains(t, err, "history of trigger values is not available")
+}
+
+func TestEWMA(t *testing.T) {
+	now := time.Now()
+	samples := []cache.MetricSample{
+		{Timestamp: now.Add(-time.Minute), Value: 0},
+		{Timestamp: now, Value: 10},
+	}
+	// after one time constant the average moves by 1-1/e of the difference
+	assert.InDelta(t, 10*(1-1/math.E), ewma(samples, time.Minute), 0.001)
+}
+
+func TestCalculateScalingModifiersFormulaWithWindowFunctions(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{Formula: "max_over(lag, 5m) + idle", Target: "2"},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "lag", Type: "kafka"},
+				{Name: "idle", Type: "prometheus"},
+			},
+		},
+	}
+	program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(so)
+	assert.NoError(t, err)
+
+	cacheObj := &cache.ScalersCache{ScaledObject: so, CompiledFormula: program, MetricsHistory: cache.NewMetricsHistory()}
+	cacheObj.MetricsHistory.Record("lag", 12, time.Now().Add(-time.Minute))
+	pairList := map[string]string{"s0-kafka-topic": "lag"}
+
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-kafka-topic", Value: *resource.NewQuantity(3, resource.DecimalSI)},
+	}
+	// the trigger without metric fails the formula as it isn't in the environment
+	_, err = calculateScalingModifiersFormula(metrics, cacheObj, pairList)
+	assert.Error(t, err)
+
+	metrics = append(metrics, external_metrics.ExternalMetricValue{MetricName: "s1-prometheus", Value: *resource.NewQuantity(1, resource.DecimalSI)})
+	pairList["s1-prometheus"] = "idle"
+	result, err := calculateScalingModifiersFormula(metrics, cacheObj, pairList)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.InDelta(t, 13, result[0].Value.AsApproximateFloat64(), 0.001)
+	// the formula only reads the history
+	assert.Len(t, cacheObj.MetricsHistory.Window("lag", 5*time.Minute, time.Now()), 1)
+}
+
+func TestRecordMetricsHistory(t *testing.T) {
+	now := time.Now()
+	cacheObj := &cache.ScalersCache{MetricsHistory: cache.NewMetricsHistory(), Clock: func() time.Time { return now }}
+	pairList := map[string]string{"s0-kafka-topic": "lag"}
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-kafka-topic", Value: *resource.NewQuantity(3, resource.DecimalSI)},
+		{MetricName: "s1-unpaired", Value: *resource.NewQuantity(1, resource.DecimalSI)},
+	}
+
+	RecordMetricsHistory(cacheObj, metrics, pairList)
+	samples := cacheObj.MetricsHistory.Window("lag", time.Minute, now)
+	assert.Len(t, samples, 1)
+	assert.InDelta(t, 3, samples[0].Value, 0.001)
+
+	// nothing is recorded when no window function is used
+	RecordMetricsHistory(&cache.ScalersCache{}, metrics, pairList)
+}
@@ -26,9 +26,11 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/scale"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -147,6 +149,47 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, sc
 	}
 }
 
+// GetCurrentReplicas returns the replica count of the ScaledObject scale target. Deployments and StatefulSets
+// are read through the client to use the informer cache, other resources through the scale subresource.
+func GetCurrentReplicas(ctx context.Context, kubeClient client.Client, scaleClient scale.ScalesGetter, scaledObject *kedav1alpha1.ScaledObject) (int32, error) {
+	if scaledObject.Status.ScaleTargetGVKR == nil {
+		return 0, fmt.Errorf("failed to get ScaledObject.Status.ScaleTargetGVKR, probably invalid ScaledObject cache")
+	}
+
+	gvk := scaledObject.Status.ScaleTargetGVKR.GroupVersionKind()
+	objKey := client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}
+
+	switch {
+	case gvk.Group == "apps" && gvk.Kind == "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := kubeClient.Get(ctx, objKey, deployment); err != nil {
+			return 0, err
+		}
+		if deployment.Spec.Replicas == nil {
+			return 1, nil
+		}
+		return *deployment.Spec.Replicas, nil
+	case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := kubeClient.Get(ctx, objKey, statefulSet); err != nil {
+			return 0, err
+		}
+		if statefulSet.Spec.Replicas == nil {
+			return 1, nil
+		}
+		return *statefulSet.Spec.Replicas, nil
+	default:
+		if scaleClient == nil {
+			return 0, fmt.Errorf("scale client is required to get the replica count of %s", gvk.Kind)
+		}
+		currentScale, err := scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), objKey.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return currentScale.Spec.Replicas, nil
+	}
+}
+
 // ResolveContainerEnv resolves all environment variables in a container.
 // It returns either map of env variable key and value or error if there is any.
 func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.Logger, podSpec *corev1.PodSpec, containerName, namespace string, secretsLister corev1listers.SecretLister) (map[string]string, error) {
@@ -19,6 +19,7 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,27 +28,34 @@ import (
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/common/message"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/fallback"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/decisions"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
 var log = logf.Log.WithName("scale_handler")
@@ -61,33 +69,56 @@ type ScaleHandler interface {
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
 
 	GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error)
+	GetScalingDecisions(kind, namespace, name string) []kedav1alpha1.ScalingDecision
+	WatchScaledObjectMetrics(ctx context.Context) <-chan MetricsRefresh
 }
 
 type scaleHandler struct {
 	client                   client.Client
+	scaleClient              scale.ScalesGetter
 	scaleLoopContexts        *sync.Map
 	scaleExecutor            executor.ScaleExecutor
 	globalHTTPTimeout        time.Duration
 	recorder                 record.EventRecorder
+	eventEmitter             eventemitter.EventHandler
 	scalerCaches             map[string]*cache.ScalersCache
 	scalerCachesLock         *sync.RWMutex
 	scaledObjectsMetricCache metricscache.MetricsCache
+	lastKnownMetricsCache    metricscache.MetricsCache
 	secretsLister            corev1listers.SecretLister
+	scalingDecisions         *decisions.History
+	metricsWatchers          *metricsWatchers
+	sharder                  *sharding.Sharder
+	persister                *metricscache.Persister
 }
 
 // NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
-	return &scaleHandler{
+// the latest scalingDecisionHistorySize scaling decisions of each ScaledObject and ScaledJob are kept in memory,
+// when the sharder is set only the objects owned by the operator replica are scaled
+// and when the persister is set the metrics caches are persisted and restored by it
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder,
+	eventEmitter eventemitter.EventHandler, secretsLister corev1listers.SecretLister, scalingDecisionHistorySize int, sharder *sharding.Sharder, persister *metricscache.Persister) ScaleHandler {
+	h := &scaleHandler{
 		client:                   client,
+		scaleClient:              scaleClient,
 		scaleLoopContexts:        &sync.Map{},
 		scaleExecutor:            executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder),
 		globalHTTPTimeout:        globalHTTPTimeout,
 		recorder:                 recorder,
+		eventEmitter:             eventEmitter,
 		scalerCaches:             map[string]*cache.ScalersCache{},
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		lastKnownMetricsCache:    metricscache.NewMetricsCache(),
 		secretsLister:            secretsLister,
+		scalingDecisions:         decisions.NewHistory(scalingDecisionHistorySize),
+		metricsWatchers:          newMetricsWatchers(),
+		sharder:                  sharder,
+		persister:                persister,
 	}
+	persister.Register("scaledObjectsMetrics", h.scaledObjectsMetricCache)
+	persister.Register("lastKnownMetrics", h.lastKnownMetricsCache)
+	return h
 }
 
 /// --------------------------------------------------------------------------- ///
@@ -148,6 +179,8 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		h.lastKnownMetricsCache.Delete(key)
+		h.scalingDecisions.Delete(key)
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
@@ -167,6 +200,25 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 	pollingInterval := withTriggers.GetPollingInterval()
 	logger.V(1).Info("Watching with pollingInterval", "PollingInterval", pollingInterval)
 
+	// the scalers whose metrics were restored from the persisted cache are checked when they are due,
+	// instead of checking all the scalers at once when the operator starts
+	h.persister.WaitForRestore(ctx)
+	if storedAt, restored := h.persister.RestoredAt(withTriggers.GenerateIdentifier()); restored {
+		if delay := time.Until(storedAt.Add(pollingInterval)); delay > 0 {
+			logger.V(1).Info("Delaying the first check of the scalers, their metrics were restored", "delay", delay)
+			tmr := time.NewTimer(delay)
+			select {
+			case <-tmr.C:
+			case <-ctx.Done():
+				tmr.Stop()
+				if err := h.ClearScalersCache(ctx, scalableObject); err != nil {
+					logger.Error(err, "error clearing scalers cache")
+				}
+				return
+			}
+		}
+	}
+
 	next := time.Now()
 
 	for {
@@ -213,10 +265,14 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 				case <-ctx.Done():
 					return
 				case active := <-activeCh:
+					if !h.sharder.Owns(withTriggers.GenerateIdentifier()) {
+						logger.V(1).Info("Ignoring push scaler activity, the object moved to another shard")
+						continue
+					}
 					scalingMutex.Lock()
 					switch obj := scalableObject.(type) {
 					case *kedav1alpha1.ScaledObject:
-						h.scaleExecutor.RequestScale(ctx, obj, active, false)
+						h.scaleExecutor.RequestScale(ctx, obj, active, false, nil)
 					case *kedav1alpha1.ScaledJob:
 						logger.Info("Warning: External Push Scaler does not support ScaledJob", "object", scalableObject)
 					}
@@ -230,6 +286,12 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 // checkScalers contains the main logic for the ScaleHandler scaling logic.
 // It'll check each trigger active status then call RequestScale
 func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
+	// the scale loop is stopped by the reconciler once the object moved to another shard,
+	// the object must not be scaled in the meantime
+	if withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject); err == nil && !h.sharder.Owns(withTriggers.GenerateIdentifier()) {
+		log.V(1).Info("Skipping scalers check, the object moved to another shard", "key", withTriggers.GenerateIdentifier())
+		return
+	}
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
 	switch obj := scalableObject.(type) {
@@ -239,18 +301,26 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			log.Error(err, "error getting scaledObject", "object", scalableObject)
 			return
 		}
-		isActive, isError, metricsRecords, err := h.getScaledObjectState(ctx, obj)
+		isActive, isError, metricsRecords, state, err := h.getScaledObjectState(ctx, obj)
 		if err != nil {
 			log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
 			return
 		}
-
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
+		h.updateTriggersStatus(ctx, obj, state)
+
+		dryRunReplicas := obj.Status.DryRunReplicas
+		outcome := &executor.ScaleOutcome{}
+		h.scaleExecutor.RequestScale(executor.WithScaleOutcome(ctx, outcome), obj, isActive, isError, &executor.ScaleExecutorOptions{DesiredReplicas: state.DesiredReplicas, UsageRatio: state.UsageRatio})
+		h.recordScaledObjectDecision(ctx, obj, isActive, isError, state, outcome)
+		if obj.IsDryRun() {
+			h.emitDryRunReplicasChanged(obj, dryRunReplicas)
+		}
 
 		if len(metricsRecords) > 0 {
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
 			h.scaledObjectsMetricCache.StoreRecords(obj.GenerateIdentifier(), metricsRecords)
 		}
+		h.metricsWatchers.notify(newMetricsRefresh(obj))
 	case *kedav1alpha1.ScaledJob:
 		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
@@ -258,8 +328,15 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			return
 		}
 
-		isActive, scaleTo, maxScale := h.isScaledJobActive(ctx, obj)
-		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale)
+		isActive, scaleTo, maxScale, scalersMetrics := h.isScaledJobActive(ctx, obj)
+		options := &executor.ScaleJobExecutorOptions{}
+		if cache, err := h.GetScalersCache(ctx, obj); err == nil {
+			options.CompiledScalingStrategy = cache.CompiledScalingStrategy
+		}
+		options.WorkHints, options.TriggerName = scaledjob.GetWorkHints(scalersMetrics)
+		outcome := &executor.ScaleOutcome{}
+		h.scaleExecutor.RequestJobScale(executor.WithScaleOutcome(ctx, outcome), obj, isActive, scaleTo, maxScale, options)
+		h.recordScaledJobDecision(ctx, obj, isActive, scalersMetrics, outcome)
 	}
 }
 
@@ -350,6 +427,8 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
 		asMetricSource = obj.IsUsingModifiers()
+	case *kedav1alpha1.ScaledJob:
+		asMetricSource = obj.IsUsingModifiers()
 	default:
 	}
 
@@ -365,7 +444,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
-		if obj.Spec.Advanced != nil && obj.Spec.Advanced.ScalingModifiers.Formula != "" {
+		if obj.Spec.Advanced != nil && (obj.Spec.Advanced.ScalingModifiers.Formula != "" || obj.Spec.Advanced.ScalingModifiers.Aggregation != "") {
 			// validate scalingModifiers struct and compile formula
 			program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(obj)
 			if err != nil {
@@ -373,8 +452,33 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 				return nil, err
 			}
 			newCache.CompiledFormula = program
+			if program != nil {
+				newCache.MetricsHistory = cache.NewMetricsHistory()
+			}
 		}
 		newCache.ScaledObject = obj
+	case *kedav1alpha1.ScaledJob:
+		if obj.Spec.ScalingStrategy.Strategy == kedav1alpha1.ExpressionScalingStrategy {
+			// validate and compile the expression of the scaling strategy
+			program, err := kedav1alpha1.CompileScalingStrategyExpression(obj)
+			if err != nil {
+				log.Error(err, "error validating-compiling scalingStrategy expression")
+				return nil, err
+			}
+			newCache.CompiledScalingStrategy = program
+		}
+		if obj.IsUsingModifiers() {
+			// validate scalingModifiers struct and compile formula
+			program, err := kedav1alpha1.ValidateAndCompileScaledJobScalingModifiers(obj)
+			if err != nil {
+				log.Error(err, "error validating-compiling scalingModifiers")
+				return nil, err
+			}
+			newCache.CompiledFormula = program
+			if program != nil {
+				newCache.MetricsHistory = cache.NewMetricsHistory()
+			}
+		}
 	default:
 	}
 
@@ -502,7 +606,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 					result := metricResult{}
 
 					// Pair metric values with their trigger names. This is applied only when
-					// ScalingModifiers.Formula is defined in SO.
+					// ScalingModifiers.Formula or the weighted aggregation is defined in SO.
 					result.metricTriggerPair, err = modifiers.GetPairTriggerAndMetric(scaledObject, metricName, scalerConfig.TriggerName)
 					if err != nil {
 						logger.Error(err, "error pairing triggers & metrics for compositeScaler")
@@ -548,7 +652,7 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 			metricTriggerPairList[key] = value
 		}
 		// check if we need to set a fallback
-		metrics, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, h.client, result.metrics, result.err, result.metricName, scaledObject, result.metricSpec)
+		metrics, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, h.client, h.scaleClient, result.metrics, result.err, result.metricName, scaledObject, result.metricSpec, h.lastKnownMetricsCache)
 		if err != nil {
 			isScalerError = true
 			logger.Error(err, "error getting metric for trigger", "trigger", result.triggerName)
@@ -589,8 +693,34 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 // is active as the first return value,
 // the second return value indicates whether there was any error during querying scalers,
 // the third return value is a map of metrics record - a metric value for each scaler and its metric
-// the fourth return value contains error if is not able to access scalers cache
-func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, error) {
+// the fourth return value is the state of the triggers and the desired replica count of the ScaledObject
+// the fifth return value contains error if is not able to access scalers cache
+func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, ScaledObjectState, error) {
+	cache, err := h.GetScalersCache(ctx, scaledObject)
+	metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
+	if err != nil {
+		return false, true, map[string]metricscache.MetricsRecord{}, ScaledObjectState{}, fmt.Errorf("error getting scalers cache %w", err)
+	}
+
+	isScaledObjectActive, isScaledObjectError, metricsRecord, state, err := GetScaledObjectState(ctx, scaledObject, cache)
+
+	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
+	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
+	if isScaledObjectError {
+		err := h.ClearScalersCache(ctx, scaledObject)
+		if err != nil {
+			log.Error(err, "error clearing scalers cache", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+		}
+		log.V(1).Info("scaler error encountered, clearing scaler cache", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	}
+	return isScaledObjectActive, isScaledObjectError, metricsRecord, state, err
+}
+
+// GetScaledObjectState queries the scalers in the cache of the ScaledObject and returns, as the scale loop does:
+// whether the ScaledObject is active according to its triggers, its scalingModifiers and its activation policy,
+// whether any scaler failed, the metrics records of the scalers using cached metrics and the state of the triggers.
+// The error is returned if the scalingModifiers can't be evaluated.
+func GetScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, cache *cache.ScalersCache) (bool, bool, map[string]metricscache.MetricsRecord, ScaledObjectState, error) {
 	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 
 	isScaledObjectActive := false
@@ -599,12 +729,6 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	metricTriggerPairList := make(map[string]string)
 	var matchingMetrics []external_metrics.ExternalMetricValue
 
-	cache, err := h.GetScalersCache(ctx, scaledObject)
-	metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
-	if err != nil {
-		return false, true, map[string]metricscache.MetricsRecord{}, fmt.Errorf("error getting scalers cache %w", err)
-	}
-
 	// count the number of non-external triggers (cpu/mem) in order to check for
 	// scale to zero requirements if atleast one cpu/mem trigger is given.
 	// This is calculated here because of algorithm complexity but
@@ -619,24 +743,44 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	// Let's collect status of all allScalers in parallel,
 	// no matter if any scaler raises error or is active
 	allScalers, scalerConfigs := cache.GetScalers()
-	results := make(chan scalerState, len(allScalers))
+	results := make(chan ScalerState, len(allScalers))
 	wg := sync.WaitGroup{}
 	for scalerIndex := 0; scalerIndex < len(allScalers); scalerIndex++ {
 		wg.Add(1)
-		go func(scaler scalers.Scaler, index int, scalerConfig scalers.ScalerConfig, results chan scalerState, wg *sync.WaitGroup) {
-			results <- h.getScalerState(ctx, scaler, index, scalerConfig, cache, logger, scaledObject)
+		go func(scaler scalers.Scaler, index int, scalerConfig scalers.ScalerConfig, results chan ScalerState, wg *sync.WaitGroup) {
+			results <- getScalerState(ctx, scaler, index, scalerConfig, cache, logger, scaledObject)
 			wg.Done()
 		}(allScalers[scalerIndex], scalerIndex, scalerConfigs[scalerIndex], results, &wg)
 	}
 	wg.Wait()
 	close(results)
+	var activities []triggerActivity
+	desiredReplicas := int32(0)
+	var usageRatio *float64
+	triggerDecisions := make([][]kedav1alpha1.TriggerDecision, len(allScalers))
 	for result := range results {
 		if result.IsActive {
 			isScaledObjectActive = true
 		}
+		// cpu/memory triggers have no activity, they are left out of the activation policy
+		if result.TriggerIndex < len(scaledObject.Spec.Triggers) {
+			if trigger := scaledObject.Spec.Triggers[result.TriggerIndex]; trigger.Type != "cpu" && trigger.Type != "memory" {
+				activities = append(activities, triggerActivity{
+					name:     result.TriggerName,
+					key:      kedav1alpha1.GetTriggerStatusKey(trigger, result.TriggerIndex),
+					isVeto:   scaledObject.Spec.ActivationPolicy.IsVetoTrigger(trigger.Name),
+					isActive: result.IsActive,
+				})
+			}
+		}
 		if result.IsError {
 			isScaledObjectError = true
 		}
+		desiredReplicas = max(desiredReplicas, result.DesiredReplicas)
+		usageRatio = maxUsageRatio(usageRatio, result.UsageRatio)
+		if result.TriggerIndex < len(triggerDecisions) {
+			triggerDecisions[result.TriggerIndex] = getTriggerDecisions(result)
+		}
 		matchingMetrics = append(matchingMetrics, result.Metrics...)
 		for k, v := range result.Pairs {
 			metricTriggerPairList[k] = v
@@ -646,17 +790,9 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 		}
 	}
 
-	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
-	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
-	if isScaledObjectError {
-		err := h.ClearScalersCache(ctx, scaledObject)
-		if err != nil {
-			logger.Error(err, "error clearing scalers cache")
-		}
-		logger.V(1).Info("scaler error encountered, clearing scaler cache")
-	}
-
-	// apply scaling modifiers
+	// apply scaling modifiers, the history of the window functions is only recorded here
+	// and not when the HPA reads the metrics, so that each poll adds a single sample
+	modifiers.RecordMetricsHistory(cache, matchingMetrics, metricTriggerPairList)
 	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, false, cache, logger)
 
 	// when we are using formula, we need to reevaluate if it's active here
@@ -668,7 +804,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 			if scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget != "" {
 				targetValue, err := strconv.ParseFloat(scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget, 64)
 				if err != nil {
-					return false, true, metricsRecord, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
+					return false, true, metricsRecord, ScaledObjectState{}, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
 				}
 				activationValue = targetValue
 			}
@@ -682,6 +818,30 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 				}
 			}
 		}
+		desiredReplicas = getDesiredReplicasForModifiers(scaledObject, matchingMetrics)
+		usageRatio = getUsageRatioForModifiers(scaledObject, matchingMetrics)
+	}
+
+	// the activation policy replaces "any trigger is active" by its own rule, it can't be combined with formula
+	state := ScaledObjectState{DesiredReplicas: desiredReplicas, UsageRatio: usageRatio}
+	for _, inputs := range triggerDecisions {
+		state.TriggerDecisions = append(state.TriggerDecisions, inputs...)
+	}
+	if scaledObject.IsUsingModifiers() && len(matchingMetrics) > 0 {
+		state.FormulaValue = ptr.To(matchingMetrics[0].Value.DeepCopy())
+	}
+	if scaledObject.Spec.ActivationPolicy != nil && !scaledObject.IsUsingModifiers() {
+		isScaledObjectActive, state.ActivationTriggers = evaluateActivationPolicy(scaledObject.Spec.ActivationPolicy, activities)
+		logger.V(1).Info("Activation policy evaluated", "mode", scaledObject.Spec.ActivationPolicy.GetMode(), "isActive", isScaledObjectActive, "activationTriggers", state.ActivationTriggers)
+	}
+
+	// the cooldown of each trigger starts when it stops keeping the ScaledObject active
+	if isScaledObjectActive {
+		for _, activity := range activities {
+			if activity.isActive && !activity.isVeto {
+				state.ActiveTriggers = append(state.ActiveTriggers, activity.key)
+			}
+		}
 	}
 
 	// cpu/memory scaler only can scale to zero if there is any other external metric because otherwise
@@ -689,40 +849,117 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	if len(scaledObject.Spec.Triggers) <= cpuMemCount && !isScaledObjectError {
 		isScaledObjectActive = true
 	}
-	return isScaledObjectActive, isScaledObjectError, metricsRecord, err
+	return isScaledObjectActive, isScaledObjectError, metricsRecord, state, nil
+}
+
+// ScaledObjectState is the state of the triggers of a ScaledObject reported in its status
+// and the replica count derived from its metrics passed to the scale executor
+type ScaledObjectState struct {
+	// ActivationTriggers decided the activity according to the activation policy, if any
+	ActivationTriggers []string
+	// ActiveTriggers are the status keys of the triggers keeping the ScaledObject active
+	ActiveTriggers []string
+	// DesiredReplicas is the replica count the HPA is going to reach for the current metrics, 0 if unknown
+	DesiredReplicas int32
+	// UsageRatio is the ratio of the metric values to their Value targets the HPA scales the current replica count by, if any
+	UsageRatio *float64
+	// TriggerDecisions are the inputs of the triggers recorded in the scaling decision
+	TriggerDecisions []kedav1alpha1.TriggerDecision
+	// FormulaValue is the output of the scalingModifiers formula, if any
+	FormulaValue *resource.Quantity
+}
+
+// triggerLastActiveTimeResolution is the fraction of its cooldown period the last active time of a trigger
+// may lag behind, so an active trigger doesn't patch the status on every polling interval
+const triggerLastActiveTimeResolution = 10
+
+// updateTriggersStatus reports the triggers which decided the activity of the ScaledObject in status.activationTriggers
+// and the last time each trigger kept it active in status.triggersLastActiveTime, the status is patched only when they changed
+func (h *scaleHandler) updateTriggersStatus(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, state ScaledObjectState) {
+	status := GetTriggersStatus(scaledObject, state, time.Now())
+	if reflect.DeepEqual(scaledObject.Status.ActivationTriggers, status.ActivationTriggers) &&
+		reflect.DeepEqual(scaledObject.Status.TriggersLastActiveTime, status.TriggersLastActiveTime) {
+		return
+	}
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating the triggers status of scaledObject")
+	}
+}
+
+// GetTriggersStatus returns the status of the ScaledObject with the triggers of the state in status.activationTriggers and
+// status.triggersLastActiveTime at the given time. The last active time of a trigger is only refreshed once it is older
+// than a fraction of the trigger cooldown period.
+func GetTriggersStatus(scaledObject *kedav1alpha1.ScaledObject, state ScaledObjectState, now time.Time) *kedav1alpha1.ScaledObjectStatus {
+	status := scaledObject.Status.DeepCopy()
+	status.ActivationTriggers = state.ActivationTriggers
+
+	refreshIntervals := make(map[string]time.Duration, len(scaledObject.Spec.Triggers))
+	for i, trigger := range scaledObject.Spec.Triggers {
+		refreshIntervals[kedav1alpha1.GetTriggerStatusKey(trigger, i)] = executor.GetTriggerCooldownPeriod(scaledObject, trigger) / triggerLastActiveTimeResolution
+	}
+
+	for _, key := range state.ActiveTriggers {
+		if status.TriggersLastActiveTime == nil {
+			status.TriggersLastActiveTime = map[string]metav1.Time{}
+		}
+		// without cooldown period the trigger is released as soon as it isn't active, whatever its last active time
+		if lastActiveTime, found := status.TriggersLastActiveTime[key]; found &&
+			(refreshIntervals[key] == 0 || now.Sub(lastActiveTime.Time) < refreshIntervals[key]) {
+			continue
+		}
+		status.TriggersLastActiveTime[key] = metav1.NewTime(now)
+	}
+	// forget the triggers removed from the ScaledObject
+	for key := range status.TriggersLastActiveTime {
+		if _, found := refreshIntervals[key]; !found {
+			delete(status.TriggersLastActiveTime, key)
+		}
+	}
+	return status
 }
 
-// scalerState is used as return
+// ScalerState is used as return
 // for the function getScalerState. It contains
 // the state of the scaler and all the required
 // info for calculating the ScaledObjectState
-type scalerState struct {
+type ScalerState struct {
+	// TriggerIndex is the index of the scaler in the scalers cache
+	TriggerIndex int
+	// TriggerName is the name of the trigger, or the type of the scaler if the trigger isn't named
+	TriggerName string
 	// IsActive will be overrided by formula calculation
 	IsActive bool
 	IsError  bool
-	Metrics  []external_metrics.ExternalMetricValue
-	Pairs    map[string]string
-	Records  map[string]metricscache.MetricsRecord
+	// DesiredReplicas is the replica count derived from the metrics with an AverageValue target, 0 if unknown
+	DesiredReplicas int32
+	// UsageRatio is the largest ratio of the metrics with a Value target to their target, nil if unknown
+	UsageRatio *float64
+	Metrics    []external_metrics.ExternalMetricValue
+	Pairs      map[string]string
+	Records    map[string]metricscache.MetricsRecord
 }
 
 // getScalerState returns getStateScalerResult with the state
 // for an specific scaler. The state contains if it's active or
 // with erros, but also the records for the cache and he metrics
 // for the custom formulas
-func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalers.ScalerConfig,
-	cache *cache.ScalersCache, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) scalerState {
-	result := scalerState{
-		IsActive: false,
-		IsError:  false,
-		Metrics:  []external_metrics.ExternalMetricValue{},
-		Pairs:    map[string]string{},
-		Records:  map[string]metricscache.MetricsRecord{},
+func getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalers.ScalerConfig,
+	cache *cache.ScalersCache, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) ScalerState {
+	result := ScalerState{
+		TriggerIndex: triggerIndex,
+		IsActive:     false,
+		IsError:      false,
+		Metrics:      []external_metrics.ExternalMetricValue{},
+		Pairs:        map[string]string{},
+		Records:      map[string]metricscache.MetricsRecord{},
 	}
 
 	triggerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
 	if scalerConfig.TriggerName != "" {
 		triggerName = scalerConfig.TriggerName
 	}
+	result.TriggerName = triggerName
 
 	metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
 	if err != nil {
@@ -765,6 +1002,8 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 			}
 		} else {
 			result.IsActive = isMetricActive
+			result.DesiredReplicas = max(result.DesiredReplicas, getDesiredReplicasForSpec(spec, metrics))
+			result.UsageRatio = maxUsageRatio(result.UsageRatio, getUsageRatioForSpec(spec, metrics))
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
 				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, triggerName, triggerIndex, metric.MetricName, true, metricValue)
@@ -797,15 +1036,28 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 // getScaledJobMetrics returns metrics for specified metric name for a ScaledJob identified by its name and namespace.
 // It could either query the metric value directly from the scaler or from a cache, that's being stored for the scaler.
 func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) []scaledjob.ScalerMetrics {
-	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
-
 	cache, err := h.GetScalersCache(ctx, scaledJob)
 	metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
 	if err != nil {
 		log.Error(err, "error getting scalers cache", "scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
 		return nil
 	}
+	status := scaledJob.Status.DeepCopy()
+	scalersMetrics := GetScaledJobMetrics(ctx, scaledJob, cache, status, h.lastKnownMetricsCache)
+	fallback.UpdateScaledJobStatus(ctx, h.client, scaledJob, status)
+	return scalersMetrics
+}
+
+// GetScaledJobMetrics queries the scalers in the cache of the ScaledJob and returns the metrics of its triggers,
+// or the composite metric of its scalingModifiers, as the scale loop does. The health of the triggers is updated
+// in the given status and the last known metrics are used by the fallback.
+func GetScaledJobMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, cache *cache.ScalersCache, status *kedav1alpha1.ScaledJobStatus, lastKnownMetrics metricscache.MetricsCache) []scaledjob.ScalerMetrics {
+	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+
 	var scalersMetrics []scaledjob.ScalerMetrics
+	var matchingMetrics []external_metrics.ExternalMetricValue
+	metricTriggerPairList := make(map[string]string)
+	isFallbackActive, isError := false, false
 	scalers, scalerConfigs := cache.GetScalers()
 	for scalerIndex, scaler := range scalers {
 		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
@@ -826,7 +1078,7 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 				continue
 			}
 			metricName := spec.External.Metric.Name
-			metrics, isTriggerActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
+			metrics, isTriggerActive, workHints, latency, err := cache.GetMetricsActivityAndWorkHintsForScaler(ctx, scalerIndex, metricName)
 			metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
 			if latency != -1 {
 				metricscollector.RecordScalerLatency(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, float64(latency))
@@ -834,8 +1086,25 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			if err != nil {
 				scalerLogger.V(1).Info("Error getting scaler metrics and activity, but continue", "error", err)
 				cache.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			}
+			// check if we need to set a fallback
+			var fallbackActive bool
+			metrics, isTriggerActive, fallbackActive, err = fallback.GetScaledJobMetricsWithFallback(scaledJob, status, metrics, isTriggerActive, err, metricName, spec, lastKnownMetrics)
+			isFallbackActive = isFallbackActive || fallbackActive
+			if err != nil {
+				isError = true
 				continue
 			}
+			if scaledJob.IsUsingModifiers() {
+				matchingMetrics = append(matchingMetrics, metrics...)
+				pairs, err := modifiers.GetScaledJobPairTriggerAndMetric(scaledJob, metricName, scalerConfigs[scalerIndex].TriggerName)
+				if err != nil {
+					scalerLogger.Error(err, "error pairing triggers & metrics for compositeScaler")
+				}
+				for k, v := range pairs {
+					metricTriggerPairList[k] = v
+				}
+			}
 			if isTriggerActive {
 				isActive = true
 			}
@@ -844,9 +1113,12 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
 
 			scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
+				TriggerName: scalerName,
+				MetricName:  metricName,
 				QueueLength: queueLength,
 				MaxValue:    maxValue,
 				IsActive:    isActive,
+				WorkHints:   workHints,
 			})
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
@@ -866,13 +1138,38 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, isTriggerActive)
 		}
 	}
+
+	// the scalingModifiers replace the metrics of the triggers by the composite metric, unless the fallback is active
+	if scaledJob.IsUsingModifiers() && !isFallbackActive {
+		return getScaledJobCompositeMetrics(scaledJob, matchingMetrics, metricTriggerPairList, isError, cache, logger)
+	}
 	return scalersMetrics
 }
 
+// getScaledJobCompositeMetrics applies the scalingModifiers of the ScaledJob to the metrics of its triggers
+// and returns the composite metric, which is inactive if a trigger failed as for ScaledObject
+func getScaledJobCompositeMetrics(scaledJob *kedav1alpha1.ScaledJob, metrics []external_metrics.ExternalMetricValue, metricTriggerPairList map[string]string, isError bool, cache *cache.ScalersCache, logger logr.Logger) []scaledjob.ScalerMetrics {
+	modifiers.RecordMetricsHistory(cache, metrics, metricTriggerPairList)
+	metrics = modifiers.HandleScaledJobScalingModifiers(scaledJob, metrics, metricTriggerPairList, false, cache, logger)
+	if len(metrics) == 0 {
+		return nil
+	}
+	compositeMetrics, err := scaledjob.GetCompositeScalerMetrics(scaledJob, metrics)
+	if err != nil {
+		logger.Error(err, "error getting composite metric of scalingModifiers")
+		return nil
+	}
+	compositeMetrics.IsActive = compositeMetrics.IsActive && !isError
+	metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, kedav1alpha1.CompositeMetricName, 0, kedav1alpha1.CompositeMetricName, false, compositeMetrics.QueueLength)
+	metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, kedav1alpha1.CompositeMetricName, 0, kedav1alpha1.CompositeMetricName, false, compositeMetrics.IsActive)
+	return []scaledjob.ScalerMetrics{compositeMetrics}
+}
+
 // isScaledJobActive returns whether the input ScaledJob:
 // is active as the first return value,
-// the second and the third return values indicate queueLength and maxValue for scale
-func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64) {
+// the second and the third return values indicate queueLength and maxValue for scale,
+// the fourth return value is the metrics of each scaler recorded in the scaling decision
+func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64, []scaledjob.ScalerMetrics) {
 	logger := logf.Log.WithName("scalemetrics")
 
 	scalersMetrics := h.getScaledJobMetrics(ctx, scaledJob)
@@ -880,7 +1177,7 @@ func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1a
 		scaledjob.IsScaledJobActive(scalersMetrics, scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation, scaledJob.MinReplicaCount(), scaledJob.MaxReplicaCount())
 
 	logger.V(1).WithValues("ScaledJob", scaledJob.Name).Info("Checking if ScaleJob Scalers are active", "isActive", isActive, "maxValue", maxFloatValue, "MultipleScalersCalculation", scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation)
-	return isActive, queueLength, maxValue
+	return isActive, queueLength, maxValue, scalersMetrics
 }
 
 // getTrueMetricArray is a help function made for composite scaler to determine
@@ -126,7 +126,7 @@ func TestGetScaledObjectMetrics_DirectCall(t *testing.T) {
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
 	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
-	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
 
 	mockClient.EXPECT().Status().Return(mockStatusWriter)
@@ -217,7 +217,7 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
 	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue}, true, nil)
-	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
 
 	mockClient.EXPECT().Status().Return(mockStatusWriter)
@@ -346,7 +346,7 @@ func TestGetScaledObjectMetrics_InParallel(t *testing.T) {
 			return metricsValueFn(i), true, nil
 		})
 	}
-	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	assert.Eventually(t, func() bool {
 		sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
 		return true
@@ -427,7 +427,7 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
@@ -543,7 +543,7 @@ func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
@@ -622,17 +622,96 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, true, isError)
 }
 
+func TestCheckScaledObjectActivationPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	recorder := record.NewFakeRecorder(1)
+
+	metricsSpecs := []v2.MetricSpec{createMetricSpec(1, "metric-name")}
+	newScaler := func(isActive bool) scalers.Scaler {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs).AnyTimes()
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{}, isActive, nil).AnyTimes()
+		scaler.EXPECT().Close(gomock.Any())
+		return scaler
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "queue", Type: "rabbitmq"},
+				{Name: "database", Type: "postgresql"},
+			},
+			ActivationPolicy: &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyAll},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{
+			{Scaler: newScaler(true), ScalerConfig: scalers.ScalerConfig{TriggerName: "queue", TriggerIndex: 0}},
+			{Scaler: newScaler(false), ScalerConfig: scalers.ScalerConfig{TriggerName: "database", TriggerIndex: 1}},
+		},
+		Recorder: recorder,
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	isActive, isError, _, triggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	assert.False(t, isActive)
+	assert.False(t, isError)
+	assert.Equal(t, []string{"queue"}, triggers.ActivationTriggers)
+	assert.Nil(t, triggers.ActiveTriggers)
+
+	scaledObject.Spec.ActivationPolicy.VetoTriggers = []string{"queue"}
+	scaledObject.Spec.ActivationPolicy.Mode = kedav1alpha1.ActivationPolicyAny
+	isActive, _, _, triggers, _ = sh.getScaledObjectState(context.TODO(), &scaledObject)
+	assert.False(t, isActive)
+	assert.Equal(t, []string{"queue"}, triggers.ActivationTriggers)
+
+	scaledObject.Spec.ActivationPolicy = nil
+	isActive, _, _, triggers, _ = sh.getScaledObjectState(context.TODO(), &scaledObject)
+	assert.True(t, isActive)
+	assert.Nil(t, triggers.ActivationTriggers)
+	assert.Equal(t, []string{"queue"}, triggers.ActiveTriggers)
+
+	scalerCache.Close(context.Background())
+}
+
 func TestIsScaledJobActive(t *testing.T) {
 	metricName := "s0-queueLength"
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	// Keep the current behavior
 	// Assme 1 trigger only
 	scaledJobSingle := createScaledJob(1, 100, "") // testing default = max
@@ -650,6 +729,7 @@ func TestIsScaledJobActive(t *testing.T) {
 	caches[scaledJobSingle.GenerateIdentifier()] = &scalerCache
 
 	sh := scaleHandler{
+		client:                   mockClient,
 		scaleLoopContexts:        &sync.Map{},
 		globalHTTPTimeout:        time.Duration(1000),
 		recorder:                 recorder,
@@ -657,7 +737,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
-	isActive, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, int64(20), queueLength)
 	assert.Equal(t, int64(10), maxValue)
@@ -705,6 +785,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		caches[scaledJobSingle.GenerateIdentifier()] = &scalerCache
 
 		sh = scaleHandler{
+			client:                   mockClient,
 			scaleLoopContexts:        &sync.Map{},
 			globalHTTPTimeout:        time.Duration(1000),
 			recorder:                 recorder,
@@ -713,7 +794,7 @@ func TestIsScaledJobActive(t *testing.T) {
 			scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 		}
 		fmt.Printf("index: %d", index)
-		isActive, queueLength, maxValue = sh.isScaledJobActive(context.TODO(), scaledJob)
+		isActive, queueLength, maxValue, _ = sh.isScaledJobActive(context.TODO(), scaledJob)
 		//	assert.Equal(t, 5, index)
 		assert.Equal(t, scalerTestData.ResultIsActive, isActive)
 		assert.Equal(t, scalerTestData.ResultQueueLength, queueLength)
@@ -726,6 +807,10 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	metricName := "s0-queueLength"
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	// Keep the current behavior
 	// Assme 1 trigger only
 	scaledJobSingle := createScaledJob(1, 100, "") // testing default = max
@@ -745,6 +830,7 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	caches[scaledJobSingle.GenerateIdentifier()] = &scalerCache
 
 	sh := scaleHandler{
+		client:                   mockClient,
 		scaleLoopContexts:        &sync.Map{},
 		globalHTTPTimeout:        time.Duration(1000),
 		recorder:                 recorder,
@@ -753,7 +839,7 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, int64(0), queueLength)
 	assert.Equal(t, int64(0), maxValue)
@@ -970,6 +1056,7 @@ func TestScalingModifiersFormula(t *testing.T) {
 		},
 		Recorder:        recorder,
 		CompiledFormula: compiledFormula,
+		MetricsHistory:  cache.NewMetricsHistory(),
 	}
 
 	caches := map[string]*cache.ScalersCache{}
@@ -989,10 +1076,18 @@ func TestScalingModifiersFormula(t *testing.T) {
 	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	scaler1.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs1)
 	scaler2.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs2)
-	scaler1.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue1, metricValue2}, true, nil)
-	scaler2.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue1, metricValue2}, true, nil)
-	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	scaler1.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue1}, true, nil)
+	scaler2.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Any()).Return([]external_metrics.ExternalMetricValue{metricValue2}, true, nil)
+	// the last active time of both triggers is reported
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+	assert.Contains(t, scaledObject.Status.TriggersLastActiveTime, triggerName1)
+	assert.Contains(t, scaledObject.Status.TriggersLastActiveTime, triggerName2)
+	// a single poll of the scale loop records a single sample per trigger
+	assert.Len(t, scalerCache.MetricsHistory.Window(triggerName1, time.Hour, time.Now()), 1)
+	assert.Len(t, scalerCache.MetricsHistory.Window(triggerName2, time.Hour, time.Now()), 1)
 
 	mockClient.EXPECT().Status().Return(mockStatusWriter).Times(2)
 	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
@@ -1003,6 +1098,48 @@ func TestScalingModifiersFormula(t *testing.T) {
 	metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, compositeMetricName)
 	assert.Nil(t, err)
 	assert.Equal(t, float64(7), metrics.Items[0].Value.AsApproximateFloat64())
+	// the metrics read by the HPA aren't recorded
+	assert.Len(t, scalerCache.MetricsHistory.Window(triggerName1, time.Hour, time.Now()), 1)
+	assert.Len(t, scalerCache.MetricsHistory.Window(triggerName2, time.Hour, time.Now()), 1)
+}
+
+func TestUpdateTriggersStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	sh := scaleHandler{client: mockClient}
+
+	noCooldown := int32(0)
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "queue", Type: "rabbitmq"},
+				{Name: "schedule", Type: "cron", CooldownPeriod: &noCooldown},
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ActivationTriggers: []string{"queue", "schedule"},
+			TriggersLastActiveTime: map[string]metav1.Time{
+				"queue":    metav1.NewTime(time.Now().Add(-10 * time.Second)),
+				"schedule": metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+		},
+	}
+	state := ScaledObjectState{ActivationTriggers: []string{"queue", "schedule"}, ActiveTriggers: []string{"queue", "schedule"}}
+
+	// the last active times are recent enough for the default cooldown period, the status isn't patched
+	sh.updateTriggersStatus(context.TODO(), scaledObject, state)
+
+	// the last active time of the queue trigger is older than a fraction of the cooldown period
+	queueLastActiveTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	scheduleLastActiveTime := scaledObject.Status.TriggersLastActiveTime["schedule"]
+	scaledObject.Status.TriggersLastActiveTime["queue"] = queueLastActiveTime
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateTriggersStatus(context.TODO(), scaledObject, state)
+	assert.True(t, scaledObject.Status.TriggersLastActiveTime["queue"].After(queueLastActiveTime.Time))
+	assert.Equal(t, scheduleLastActiveTime, scaledObject.Status.TriggersLastActiveTime["schedule"])
 }
 
 // createMetricSpec creates MetricSpec for given metric name and target value.
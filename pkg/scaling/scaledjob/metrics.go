@@ -1,10 +1,14 @@
 package scaledjob
 
 import (
+	"fmt"
 	"math"
+	"strconv"
 
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 // GetTargetAverageValue returns the average of all the metrics' average value.
@@ -44,10 +48,74 @@ func CalculateQueueLengthAndMaxValue(metrics []external_metrics.ExternalMetricVa
 	return queueLength, maxValue, targetAverageValue
 }
 
+// GetCompositeScalerMetrics returns the metrics of the composite metric computed by the scalingModifiers of the ScaledJob,
+// the target of the scalingModifiers is the average value per job and the composite metric is active when it is above
+// the activation target
+func GetCompositeScalerMetrics(scaledJob *kedav1alpha1.ScaledJob, metrics []external_metrics.ExternalMetricValue) (ScalerMetrics, error) {
+	sm := scaledJob.Spec.ScalingModifiers
+	target, err := strconv.ParseFloat(sm.Target, 64)
+	if err != nil {
+		return ScalerMetrics{}, fmt.Errorf("scalingModifiers.Target parsing error %w", err)
+	}
+	activationTarget := float64(0)
+	if sm.ActivationTarget != "" {
+		if activationTarget, err = strconv.ParseFloat(sm.ActivationTarget, 64); err != nil {
+			return ScalerMetrics{}, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
+		}
+	}
+
+	var queueLength float64
+	for _, metric := range metrics {
+		queueLength += metric.Value.AsApproximateFloat64()
+	}
+	var maxValue float64
+	if target > 0 {
+		maxValue = getMaxValue(queueLength/target, scaledJob.MaxReplicaCount())
+	}
+	return ScalerMetrics{
+		TriggerName: kedav1alpha1.CompositeMetricName,
+		MetricName:  kedav1alpha1.CompositeMetricName,
+		QueueLength: queueLength,
+		MaxValue:    maxValue,
+		IsActive:    queueLength > activationTarget,
+	}, nil
+}
+
 type ScalerMetrics struct {
+	TriggerName string
+	MetricName  string
 	QueueLength float64
 	MaxValue    float64
 	IsActive    bool
+	// WorkHints are the hints of the pending work given by a WorkHintScaler
+	WorkHints []string
+}
+
+// WorkHint is a hint of the work a job should pick up and the trigger it comes from
+type WorkHint struct {
+	TriggerName string
+	Hint        string
+}
+
+// GetWorkHints returns the work hints of the active triggers, in the order of the triggers,
+// and the name of the active trigger with the largest queue length
+func GetWorkHints(scalersMetrics []ScalerMetrics) ([]WorkHint, string) {
+	var hints []WorkHint
+	var triggerName string
+	var queueLength float64
+	for _, metrics := range scalersMetrics {
+		if !metrics.IsActive {
+			continue
+		}
+		if triggerName == "" || metrics.QueueLength > queueLength {
+			triggerName = metrics.TriggerName
+			queueLength = metrics.QueueLength
+		}
+		for _, hint := range metrics.WorkHints {
+			hints = append(hints, WorkHint{TriggerName: metrics.TriggerName, Hint: hint})
+		}
+	}
+	return hints, triggerName
 }
 
 // IsScaledJobActive returns whether the input ScaledJob is active and queueLength and maxValue for scale
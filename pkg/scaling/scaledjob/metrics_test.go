@@ -6,6 +6,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 func TestTargetAverageValue(t *testing.T) {
@@ -59,3 +63,50 @@ func createMetricSpec(averageValue int64, metricName string) v2.MetricSpec {
 		},
 	}
 }
+
+func TestGetCompositeScalerMetrics(t *testing.T) {
+	scaledJob := &kedav1alpha1.ScaledJob{
+		Spec: kedav1alpha1.ScaledJobSpec{
+			MaxReplicaCount: ptr.To[int32](10),
+			ScalingModifiers: kedav1alpha1.ScalingModifiers{
+				Formula:          "queue + backlog",
+				Target:           "4",
+				ActivationTarget: "6",
+			},
+		},
+	}
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: kedav1alpha1.CompositeMetricName, Value: *resource.NewQuantity(5, resource.DecimalSI)},
+	}
+
+	scalerMetrics, err := GetCompositeScalerMetrics(scaledJob, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, kedav1alpha1.CompositeMetricName, scalerMetrics.MetricName)
+	assert.Equal(t, float64(5), scalerMetrics.QueueLength)
+	assert.Equal(t, 1.25, scalerMetrics.MaxValue)
+	assert.False(t, scalerMetrics.IsActive, "the composite metric is below the activation target")
+
+	metrics[0].Value = *resource.NewQuantity(100, resource.DecimalSI)
+	scalerMetrics, err = GetCompositeScalerMetrics(scaledJob, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10), scalerMetrics.MaxValue, "the max value is capped by maxReplicaCount")
+	assert.True(t, scalerMetrics.IsActive)
+
+	scaledJob.Spec.ScalingModifiers.Target = "many"
+	_, err = GetCompositeScalerMetrics(scaledJob, metrics)
+	assert.Error(t, err)
+}
+
+func TestGetWorkHints(t *testing.T) {
+	hints, triggerName := GetWorkHints([]ScalerMetrics{
+		{TriggerName: "kafka", QueueLength: 5, IsActive: true, WorkHints: []string{"orders/1", "orders/0"}},
+		{TriggerName: "inactive", QueueLength: 50, WorkHints: []string{"orders/2"}},
+		{TriggerName: "rabbitmq", QueueLength: 10, IsActive: true},
+	})
+	assert.Equal(t, []WorkHint{{TriggerName: "kafka", Hint: "orders/1"}, {TriggerName: "kafka", Hint: "orders/0"}}, hints)
+	assert.Equal(t, "rabbitmq", triggerName)
+
+	hints, triggerName = GetWorkHints(nil)
+	assert.Empty(t, hints)
+	assert.Empty(t, triggerName)
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/common/message"
@@ -87,7 +86,7 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 			}
 			config.AuthParams = authParams
 			config.PodIdentity = podIdentity
-			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
+			scaler, err := scalers.BuildScaler(ctx, h.client, trigger.Type, config)
 			return scaler, config, err
 		}
 
@@ -116,144 +115,3 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 
 	return result, nil
 }
-
-// buildScaler builds a scaler form input config and trigger type
-func buildScaler(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
-	// TRIGGERS-START
-	switch triggerType {
-	case "activemq":
-		return scalers.NewActiveMQScaler(config)
-	case "apache-kafka":
-		return scalers.NewApacheKafkaScaler(ctx, config)
-	case "arangodb":
-		return scalers.NewArangoDBScaler(config)
-	case "artemis-queue":
-		return scalers.NewArtemisQueueScaler(config)
-	case "aws-cloudwatch":
-		return scalers.NewAwsCloudwatchScaler(ctx, config)
-	case "aws-dynamodb":
-		return scalers.NewAwsDynamoDBScaler(ctx, config)
-	case "aws-dynamodb-streams":
-		return scalers.NewAwsDynamoDBStreamsScaler(ctx, config)
-	case "aws-kinesis-stream":
-		return scalers.NewAwsKinesisStreamScaler(ctx, config)
-	case "aws-sqs-queue":
-		return scalers.NewAwsSqsQueueScaler(ctx, config)
-	case "azure-app-insights":
-		return scalers.NewAzureAppInsightsScaler(config)
-	case "azure-blob":
-		return scalers.NewAzureBlobScaler(config)
-	case "azure-data-explorer":
-		return scalers.NewAzureDataExplorerScaler(config)
-	case "azure-eventhub":
-		return scalers.NewAzureEventHubScaler(ctx, config)
-	case "azure-log-analytics":
-		return scalers.NewAzureLogAnalyticsScaler(config)
-	case "azure-monitor":
-		return scalers.NewAzureMonitorScaler(config)
-	case "azure-pipelines":
-		return scalers.NewAzurePipelinesScaler(ctx, config)
-	case "azure-queue":
-		return scalers.NewAzureQueueScaler(config)
-	case "azure-servicebus":
-		return scalers.NewAzureServiceBusScaler(ctx, config)
-	case "cassandra":
-		return scalers.NewCassandraScaler(config)
-	case "couchdb":
-		return scalers.NewCouchDBScaler(ctx, config)
-	case "cpu":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, config)
-	case "cron":
-		return scalers.NewCronScaler(config)
-	case "datadog":
-		return scalers.NewDatadogScaler(ctx, config)
-	case "elasticsearch":
-		return scalers.NewElasticsearchScaler(config)
-	case "etcd":
-		return scalers.NewEtcdScaler(config)
-	case "external":
-		return scalers.NewExternalScaler(config)
-	// TODO: use other way for test.
-	case "external-mock":
-		return scalers.NewExternalMockScaler(config)
-	case "external-push":
-		return scalers.NewExternalPushScaler(config)
-	case "gcp-cloudtasks":
-		return scalers.NewGcpCloudTasksScaler(config)
-	case "gcp-pubsub":
-		return scalers.NewPubSubScaler(config)
-	case "gcp-stackdriver":
-		return scalers.NewStackdriverScaler(ctx, config)
-	case "gcp-storage":
-		return scalers.NewGcsScaler(config)
-	case "github-runner":
-		return scalers.NewGitHubRunnerScaler(config)
-	case "graphite":
-		return scalers.NewGraphiteScaler(config)
-	case "huawei-cloudeye":
-		return scalers.NewHuaweiCloudeyeScaler(config)
-	case "ibmmq":
-		return scalers.NewIBMMQScaler(config)
-	case "influxdb":
-		return scalers.NewInfluxDBScaler(config)
-	case "kafka":
-		return scalers.NewKafkaScaler(config)
-	case "kubernetes-workload":
-		return scalers.NewKubernetesWorkloadScaler(client, config)
-	case "liiklus":
-		return scalers.NewLiiklusScaler(config)
-	case "loki":
-		return scalers.NewLokiScaler(config)
-	case "memory":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, config)
-	case "metrics-api":
-		return scalers.NewMetricsAPIScaler(config)
-	case "mongodb":
-		return scalers.NewMongoDBScaler(ctx, config)
-	case "mssql":
-		return scalers.NewMSSQLScaler(config)
-	case "mysql":
-		return scalers.NewMySQLScaler(config)
-	case "nats-jetstream":
-		return scalers.NewNATSJetStreamScaler(config)
-	case "new-relic":
-		return scalers.NewNewRelicScaler(config)
-	case "openstack-metric":
-		return scalers.NewOpenstackMetricScaler(ctx, config)
-	case "openstack-swift":
-		return scalers.NewOpenstackSwiftScaler(config)
-	case "postgresql":
-		return scalers.NewPostgreSQLScaler(config)
-	case "predictkube":
-		return scalers.NewPredictKubeScaler(ctx, config)
-	case "prometheus":
-		return scalers.NewPrometheusScaler(config)
-	case "pulsar":
-		return scalers.NewPulsarScaler(config)
-	case "rabbitmq":
-		return scalers.NewRabbitMQScaler(config)
-	case "redis":
-		return scalers.NewRedisScaler(ctx, false, false, config)
-	case "redis-cluster":
-		return scalers.NewRedisScaler(ctx, true, false, config)
-	case "redis-cluster-streams":
-		return scalers.NewRedisStreamsScaler(ctx, true, false, config)
-	case "redis-sentinel":
-		return scalers.NewRedisScaler(ctx, false, true, config)
-	case "redis-sentinel-streams":
-		return scalers.NewRedisStreamsScaler(ctx, false, true, config)
-	case "redis-streams":
-		return scalers.NewRedisStreamsScaler(ctx, false, false, config)
-	case "selenium-grid":
-		return scalers.NewSeleniumGridScaler(config)
-	case "solace-event-queue":
-		return scalers.NewSolaceScaler(config)
-	case "solr":
-		return scalers.NewSolrScaler(config)
-	case "stan":
-		return scalers.NewStanScaler(config)
-	default:
-		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
-	}
-	// TRIGGERS-END
-}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// GetScalingDecisions returns the latest scaling decisions of the ScaledObject or ScaledJob, oldest first
+func (h *scaleHandler) GetScalingDecisions(kind, namespace, name string) []kedav1alpha1.ScalingDecision {
+	return h.scalingDecisions.List(kedav1alpha1.GenerateIdentifier(kind, namespace, name))
+}
+
+// getTriggerDecisions returns the inputs of a scaler to the scaling decision, one per metric
+func getTriggerDecisions(state ScalerState) []kedav1alpha1.TriggerDecision {
+	if len(state.Metrics) == 0 {
+		return []kedav1alpha1.TriggerDecision{{
+			Name:     state.TriggerName,
+			IsActive: state.IsActive,
+			IsError:  state.IsError,
+		}}
+	}
+	inputs := make([]kedav1alpha1.TriggerDecision, 0, len(state.Metrics))
+	for _, metric := range state.Metrics {
+		inputs = append(inputs, kedav1alpha1.TriggerDecision{
+			Name:       state.TriggerName,
+			MetricName: metric.MetricName,
+			Value:      ptr.To(metric.Value.DeepCopy()),
+			IsActive:   state.IsActive,
+			IsError:    state.IsError,
+		})
+	}
+	return inputs
+}
+
+// recordScaledObjectDecision records the inputs and the outcome of the scale request of the ScaledObject
+func (h *scaleHandler) recordScaledObjectDecision(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive, isError bool, state ScaledObjectState, outcome *executor.ScaleOutcome) {
+	pausedReplicaCount, _ := executor.GetPausedReplicaCount(scaledObject)
+	fallbackCondition := scaledObject.Status.Conditions.GetFallbackCondition()
+	decision := kedav1alpha1.ScalingDecision{
+		Time:           metav1.Now(),
+		Triggers:       state.TriggerDecisions,
+		FormulaValue:   state.FormulaValue,
+		IsActive:       isActive,
+		IsError:        isError,
+		IsFallback:     fallbackCondition.IsTrue(),
+		IsPaused:       pausedReplicaCount != nil,
+		ReplicasBefore: outcome.ReplicasBefore,
+		ReplicasAfter:  outcome.ReplicasAfter,
+	}
+	key := scaledObject.GenerateIdentifier()
+	h.scalingDecisions.Record(key, decision)
+
+	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+	limit, err := kedav1alpha1.GetScalingDecisionHistoryLimit(scaledObject.Annotations)
+	if err != nil {
+		logger.Error(err, "error getting the scaling decision history limit")
+		return
+	}
+	status := scaledObject.Status.DeepCopy()
+	status.ScalingDecisions = h.scalingDecisions.Latest(key, limit)
+	if equality.Semantic.DeepEqual(scaledObject.Status.ScalingDecisions, status.ScalingDecisions) {
+		return
+	}
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating the scaling decisions of scaledObject")
+	}
+}
+
+// recordScaledJobDecision records the inputs and the outcome of the scale request of the ScaledJob
+func (h *scaleHandler) recordScaledJobDecision(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scalersMetrics []scaledjob.ScalerMetrics, outcome *executor.ScaleOutcome) {
+	fallbackCondition := scaledJob.Status.Conditions.GetFallbackCondition()
+	decision := kedav1alpha1.ScalingDecision{
+		Time:           metav1.Now(),
+		IsActive:       isActive,
+		IsFallback:     fallbackCondition.IsTrue(),
+		ReplicasBefore: outcome.ReplicasBefore,
+		ReplicasAfter:  outcome.ReplicasAfter,
+	}
+	for _, metrics := range scalersMetrics {
+		decision.Triggers = append(decision.Triggers, kedav1alpha1.TriggerDecision{
+			Name:       metrics.TriggerName,
+			MetricName: metrics.MetricName,
+			Value:      resource.NewMilliQuantity(int64(metrics.QueueLength*1000), resource.DecimalSI),
+			IsActive:   metrics.IsActive,
+		})
+	}
+	key := scaledJob.GenerateIdentifier()
+	h.scalingDecisions.Record(key, decision)
+
+	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+	limit, err := kedav1alpha1.GetScalingDecisionHistoryLimit(scaledJob.Annotations)
+	if err != nil {
+		logger.Error(err, "error getting the scaling decision history limit")
+		return
+	}
+	status := scaledJob.Status.DeepCopy()
+	status.ScalingDecisions = h.scalingDecisions.Latest(key, limit)
+	if equality.Semantic.DeepEqual(scaledJob.Status.ScalingDecisions, status.ScalingDecisions) {
+		return
+	}
+	if err := kedastatus.UpdateScaledJobStatus(ctx, h.client, logger, scaledJob, status); err != nil {
+		logger.Error(err, "error updating the scaling decisions of scaledJob")
+	}
+}
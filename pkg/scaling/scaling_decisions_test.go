@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/scaling/decisions"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+)
+
+func TestRecordScaledObjectDecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	sh := scaleHandler{
+		client:           mockClient,
+		scalingDecisions: decisions.NewHistory(10),
+	}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+	}
+	state := ScaledObjectState{
+		TriggerDecisions: getTriggerDecisions(ScalerState{
+			TriggerName: "queue",
+			IsActive:    true,
+		}),
+		FormulaValue: resource.NewQuantity(4, resource.DecimalSI),
+	}
+
+	// without the annotation the decision is only kept in memory
+	sh.recordScaledObjectDecision(context.TODO(), scaledObject, true, false, state, &executor.ScaleOutcome{ReplicasBefore: 0, ReplicasAfter: 2})
+	assert.Empty(t, scaledObject.Status.ScalingDecisions)
+
+	// with the annotation the latest decisions are reported in status
+	scaledObject.Annotations = map[string]string{kedav1alpha1.ScalingDecisionHistoryAnnotation: "1"}
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.recordScaledObjectDecision(context.TODO(), scaledObject, true, false, state, &executor.ScaleOutcome{ReplicasBefore: 2, ReplicasAfter: 4})
+	assert.Len(t, scaledObject.Status.ScalingDecisions, 1)
+	assert.Equal(t, int64(4), scaledObject.Status.ScalingDecisions[0].ReplicasAfter)
+
+	history := sh.GetScalingDecisions("ScaledObject", "test", "test")
+	assert.Len(t, history, 2)
+	assert.Equal(t, int64(0), history[0].ReplicasBefore)
+	assert.Equal(t, int64(2), history[1].ReplicasBefore)
+	assert.Equal(t, "queue", history[1].Triggers[0].Name)
+	assert.True(t, history[1].Triggers[0].IsActive)
+	assert.Equal(t, int64(4), history[1].FormulaValue.Value())
+	assert.False(t, history[1].IsPaused)
+
+	scaledObject.Annotations[kedav1alpha1.PausedReplicasAnnotation] = "1"
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.recordScaledObjectDecision(context.TODO(), scaledObject, false, false, state, &executor.ScaleOutcome{ReplicasBefore: 4, ReplicasAfter: 1})
+	assert.True(t, scaledObject.Status.ScalingDecisions[0].IsPaused)
+}
+
+func TestRecordScaledJobDecision(t *testing.T) {
+	sh := scaleHandler{scalingDecisions: decisions.NewHistory(10)}
+	scaledJob := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+	}
+	scalersMetrics := []scaledjob.ScalerMetrics{
+		{TriggerName: "queue", MetricName: "s0-queue", QueueLength: 2.5, IsActive: true},
+	}
+
+	sh.recordScaledJobDecision(context.TODO(), scaledJob, true, scalersMetrics, &executor.ScaleOutcome{ReplicasBefore: 1, ReplicasAfter: 3})
+
+	history := sh.GetScalingDecisions("ScaledJob", "test", "test")
+	assert.Len(t, history, 1)
+	assert.True(t, history[0].IsActive)
+	assert.Equal(t, int64(3), history[0].ReplicasAfter)
+	assert.Equal(t, "s0-queue", history[0].Triggers[0].MetricName)
+	assert.Equal(t, "2500m", history[0].Triggers[0].Value.String())
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Resolver follows the Leases of the operator replicas to find the replica owning a ScaledObject,
+// it is used by the adapter to send the metrics requests to the replica running its scale loop
+type Resolver struct {
+	reader    client.Reader
+	namespace string
+	group     string
+	interval  time.Duration
+	now       func() time.Time
+
+	lock sync.RWMutex
+	ring Ring
+}
+
+// NewResolver returns a Resolver reading the Leases of the shard group in the namespace at the interval,
+// the group and interval default to the ones of the operator replicas if not set
+func NewResolver(reader client.Reader, namespace, group string, interval time.Duration) *Resolver {
+	if group == "" {
+		group = DefaultShardGroup
+	}
+	if interval <= 0 {
+		interval = defaultRetryPeriod
+	}
+	return &Resolver{
+		reader:    reader,
+		namespace: namespace,
+		group:     group,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Owner returns the replica owning the object identified by "kind.namespace.name", it returns
+// false when no replica is known, eg. before the Leases were read
+func (r *Resolver) Owner(key string) (Member, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.ring.Owner(key)
+}
+
+// Members returns the live replicas sorted by name
+func (r *Resolver) Members() []Member {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.ring.Members()
+}
+
+// Start reads the Leases at the resolver interval until the context is done,
+// it implements the Runnable interface of controller-runtime Manager.
+func (r *Resolver) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, r.sync, r.interval)
+	return nil
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime. Every replica of the adapter routes its requests.
+func (r *Resolver) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Resolver) sync(ctx context.Context) {
+	leases := &coordinationv1.LeaseList{}
+	if err := r.reader.List(ctx, leases, client.InNamespace(r.namespace), client.MatchingLabels{ShardGroupLabel: r.group}); err != nil {
+		log.Error(err, "error listing shard leases")
+		return
+	}
+	ring := ringFromLeases(leases.Items, r.now())
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !ring.Equal(r.ring) {
+		log.V(1).Info("Shard members changed", "members", ring.Members())
+	}
+	r.ring = ring
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"hash/fnv"
+	"sort"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+)
+
+const (
+	// ShardGroupLabel is the label of the Leases of the operator replicas taking part in the sharding
+	ShardGroupLabel = "keda.sh/shard-group"
+	// MetricsServiceAddressAnnotation is the annotation of the Lease holding the address of the Metrics Service
+	// gRPC server of the operator replica, the adapter sends the metrics requests of its ScaledObjects there
+	MetricsServiceAddressAnnotation = "keda.sh/metrics-service-address"
+
+	// DefaultShardGroup is the shard group of the operator replicas
+	DefaultShardGroup = "keda-operator"
+)
+
+// Member is an operator replica holding a shard
+type Member struct {
+	// Name is the name of the Lease of the replica, ie. its pod name
+	Name string
+	// Address is the address of the Metrics Service gRPC server of the replica
+	Address string
+}
+
+// Ring assigns the ScaledObjects and ScaledJobs to the members by rendezvous hashing, so
+// only the objects of a member which joins or leaves the ring move to another member
+type Ring struct {
+	members []Member
+}
+
+// NewRing returns the ring of the members, sorted by name
+func NewRing(members []Member) Ring {
+	sorted := make([]Member, len(members))
+	copy(sorted, members)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return Ring{members: sorted}
+}
+
+// Members returns the members of the ring sorted by name
+func (r Ring) Members() []Member {
+	return r.members
+}
+
+// Equal returns whether both rings have the same members
+func (r Ring) Equal(other Ring) bool {
+	if len(r.members) != len(other.members) {
+		return false
+	}
+	for i := range r.members {
+		if r.members[i] != other.members[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Owner returns the member owning the key, ie. the identifier of the ScaledObject or
+// ScaledJob "kind.namespace.name", it returns false if the ring is empty
+func (r Ring) Owner(key string) (Member, bool) {
+	var owner Member
+	var ownerScore uint64
+	found := false
+	for _, member := range r.members {
+		score := rendezvousScore(member.Name, key)
+		if !found || score > ownerScore || (score == ownerScore && member.Name < owner.Name) {
+			owner = member
+			ownerScore = score
+			found = true
+		}
+	}
+	return owner, found
+}
+
+func rendezvousScore(member, key string) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(member))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(key))
+	// FNV doesn't spread keys differing only by their last bytes, the score is mixed with the finalizer of MurmurHash3
+	score := hash.Sum64()
+	score ^= score >> 33
+	score *= 0xff51afd7ed558ccd
+	score ^= score >> 33
+	score *= 0xc4ceb9fe1a85ec53
+	score ^= score >> 33
+	return score
+}
+
+// ringFromLeases returns the ring of the members whose Lease isn't expired
+func ringFromLeases(leases []coordinationv1.Lease, now time.Time) Ring {
+	var members []Member
+	for _, lease := range leases {
+		if !isLeaseAlive(&lease, now) {
+			continue
+		}
+		members = append(members, Member{
+			Name:    lease.Name,
+			Address: lease.Annotations[MetricsServiceAddressAnnotation],
+		})
+	}
+	return NewRing(members)
+}
+
+// isLeaseAlive returns whether the Lease was renewed within its duration
+func isLeaseAlive(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.DeletionTimestamp != nil || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiration := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.Before(expiration)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRingOwner(t *testing.T) {
+	_, found := NewRing(nil).Owner("scaledobject.default.worker")
+	assert.False(t, found, "an empty ring has no owner")
+
+	ring := NewRing([]Member{{Name: "keda-operator-c"}, {Name: "keda-operator-a"}, {Name: "keda-operator-b"}})
+	assert.Equal(t, []Member{{Name: "keda-operator-a"}, {Name: "keda-operator-b"}, {Name: "keda-operator-c"}}, ring.Members())
+	assert.True(t, ring.Equal(NewRing([]Member{{Name: "keda-operator-b"}, {Name: "keda-operator-c"}, {Name: "keda-operator-a"}})))
+	assert.False(t, ring.Equal(NewRing([]Member{{Name: "keda-operator-a"}, {Name: "keda-operator-b"}})))
+
+	owned := map[string]int{}
+	smaller := NewRing([]Member{{Name: "keda-operator-a"}, {Name: "keda-operator-b"}})
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("scaledobject.default.worker-%d", i)
+		owner, found := ring.Owner(key)
+		assert.True(t, found)
+		owned[owner.Name]++
+
+		// only the objects of the member which left move
+		smallerOwner, _ := smaller.Owner(key)
+		if owner.Name != "keda-operator-c" {
+			assert.Equal(t, owner, smallerOwner, key)
+		}
+	}
+	for name, count := range owned {
+		assert.Greater(t, count, 50, "the objects should be spread among the members, %s owns %d", name, count)
+	}
+}
+
+func TestRingFromLeases(t *testing.T) {
+	now := time.Now()
+	newLease := func(name string, renewed time.Duration) coordinationv1.Lease {
+		return coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{MetricsServiceAddressAnnotation: name + ":9666"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: ptr.To(int32(15)),
+				RenewTime:            &metav1.MicroTime{Time: now.Add(-renewed)},
+			},
+		}
+	}
+	leases := []coordinationv1.Lease{
+		newLease("keda-operator-b", time.Second),
+		newLease("keda-operator-a", 10*time.Second),
+		newLease("keda-operator-c", 20*time.Second),
+		{ObjectMeta: metav1.ObjectMeta{Name: "keda-operator-d"}},
+	}
+
+	assert.Equal(t, []Member{
+		{Name: "keda-operator-a", Address: "keda-operator-a:9666"},
+		{Name: "keda-operator-b", Address: "keda-operator-b:9666"},
+	}, ringFromLeases(leases, now).Members())
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+
+	// handoffRetryPeriods is the number of retry periods a replica waits before it starts the scale loops
+	// of the objects moved to it, so that their previous owner saw the change and stopped them
+	handoffRetryPeriods = 2
+
+	// shardEventsBufferSize is the number of objects which can be waiting to be enqueued in a controller
+	shardEventsBufferSize = 1024
+)
+
+var log = logf.Log.WithName("sharding")
+
+// Options configures the shard of an operator replica, unset durations default to the ones of the leader election
+type Options struct {
+	// Namespace is the namespace of the Leases, ie. the namespace of KEDA
+	Namespace string
+	// Group is the shard group of the replicas, their Leases are labeled with it
+	Group string
+	// Identity is the name of the Lease of the replica, ie. its pod name
+	Identity string
+	// Address is the address of the Metrics Service gRPC server of the replica
+	Address string
+	// LeaseDuration is the duration the other replicas wait before taking over the objects of a replica which stopped renewing its Lease
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration a replica keeps scaling its objects without renewing its Lease,
+	// it must be shorter than LeaseDuration
+	RenewDeadline time.Duration
+	// RetryPeriod is the interval the Lease is renewed and the Leases of the other replicas are read at
+	RetryPeriod time.Duration
+}
+
+// Sharder distributes the ScaledObjects and ScaledJobs among the operator replicas: each replica renews a Lease
+// and the live Leases form a Ring deciding which replica owns each object and runs its scale loop.
+// A replica stops scaling an object as soon as it sees it moved to another replica, and waits two retry
+// periods before scaling an object moved to it, so an object is never scaled by two replicas at once.
+type Sharder struct {
+	client       client.Client
+	reader       client.Reader
+	options      Options
+	handoffDelay time.Duration
+	now          func() time.Time
+
+	lock sync.RWMutex
+	// ring is made of the live members, stableRing is the last ring whose handoff completed
+	ring       Ring
+	stableRing Ring
+	changedAt  time.Time
+	handoff    bool
+	renewedAt  time.Time
+	sources    []*shardSource
+}
+
+type shardSource struct {
+	list   client.ObjectList
+	events chan event.GenericEvent
+}
+
+// NewSharder returns the Sharder of the replica, the client is used to renew the Lease and list the objects
+// of the controllers and the reader, which shouldn't be cached, to list the Leases
+func NewSharder(client client.Client, reader client.Reader, options Options) (*Sharder, error) {
+	if options.Namespace == "" || options.Identity == "" {
+		return nil, fmt.Errorf("the namespace and the identity of the shard are required")
+	}
+	if options.Group == "" {
+		options.Group = DefaultShardGroup
+	}
+	if options.LeaseDuration <= 0 {
+		options.LeaseDuration = defaultLeaseDuration
+	}
+	if options.RenewDeadline <= 0 {
+		options.RenewDeadline = defaultRenewDeadline
+	}
+	if options.RetryPeriod <= 0 {
+		options.RetryPeriod = defaultRetryPeriod
+	}
+	if options.RenewDeadline >= options.LeaseDuration {
+		return nil, fmt.Errorf("the renew deadline %s must be shorter than the lease duration %s", options.RenewDeadline, options.LeaseDuration)
+	}
+	return &Sharder{
+		client:       client,
+		reader:       reader,
+		options:      options,
+		handoffDelay: handoffRetryPeriods * options.RetryPeriod,
+		now:          time.Now,
+	}, nil
+}
+
+// Source returns the source of the objects of the list type whose owner changed, the controller
+// reconciling them starts or stops their scale loop. It must be called before the Sharder is started.
+func (s *Sharder) Source(list client.ObjectList) source.Source {
+	events := make(chan event.GenericEvent, shardEventsBufferSize)
+	s.lock.Lock()
+	s.sources = append(s.sources, &shardSource{list: list, events: events})
+	s.lock.Unlock()
+	return &source.Channel{Source: events}
+}
+
+// Owns returns whether the replica runs the scale loop of the object, identified by "kind.namespace.name".
+// A nil Sharder owns all the objects, ie. sharding is disabled.
+func (s *Sharder) Owns(key string) bool {
+	if s == nil {
+		return true
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.owns(key, s.now())
+}
+
+// owns returns whether the replica owns the object, the lock must be held
+func (s *Sharder) owns(key string, now time.Time) bool {
+	// the other replicas may have taken over the objects of a replica which can't renew its Lease
+	if now.Sub(s.renewedAt) > s.options.RenewDeadline {
+		return false
+	}
+	if !s.isOwner(s.ring, key) {
+		return false
+	}
+	return !s.handoff || now.Sub(s.changedAt) >= s.handoffDelay || s.isOwner(s.stableRing, key)
+}
+
+func (s *Sharder) isOwner(ring Ring, key string) bool {
+	owner, ok := ring.Owner(key)
+	return ok && owner.Name == s.options.Identity
+}
+
+// Start renews the Lease of the replica and follows the Leases of the other ones until the context is done,
+// the Lease is deleted then so that the objects of the replica move without waiting for it to expire.
+// It implements the Runnable interface of controller-runtime Manager.
+func (s *Sharder) Start(ctx context.Context) error {
+	log.Info("Starting shard", "identity", s.options.Identity, "group", s.options.Group)
+	wait.UntilWithContext(ctx, s.sync, s.options.RetryPeriod)
+
+	releaseCtx, cancel := context.WithTimeout(context.Background(), s.options.RetryPeriod)
+	defer cancel()
+	s.lock.Lock()
+	s.renewedAt = time.Time{}
+	s.lock.Unlock()
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: s.options.Namespace, Name: s.options.Identity}}
+	if err := s.client.Delete(releaseCtx, lease); err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "error releasing shard lease", "lease", s.options.Identity)
+	}
+	return nil
+}
+
+// NeedLeaderElection is needed to implement LeaderElectionRunnable interface
+// of controller-runtime. Every replica holds a shard.
+func (s *Sharder) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Sharder) sync(ctx context.Context) {
+	now := s.now()
+	renewed := true
+	if err := s.renew(ctx, now); err != nil {
+		log.Error(err, "error renewing shard lease", "lease", s.options.Identity)
+		renewed = false
+	}
+
+	leases := &coordinationv1.LeaseList{}
+	if err := s.reader.List(ctx, leases, client.InNamespace(s.options.Namespace), client.MatchingLabels{ShardGroupLabel: s.options.Group}); err != nil {
+		log.Error(err, "error listing shard leases")
+		return
+	}
+	s.update(ctx, ringFromLeases(leases.Items, now), renewed, now)
+}
+
+// update applies the members read from the Leases and enqueues the objects whose ownership changed
+func (s *Sharder) update(ctx context.Context, ring Ring, renewed bool, now time.Time) {
+	var filters []func(key string) bool
+	s.lock.Lock()
+	// the objects are scaled again once the Lease is renewed after the renew deadline passed
+	if renewed {
+		if !s.renewedAt.IsZero() && now.Sub(s.renewedAt) > s.options.RenewDeadline {
+			filters = append(filters, func(key string) bool {
+				return s.isOwner(ring, key)
+			})
+		}
+		s.renewedAt = now
+	}
+
+	if !ring.Equal(s.ring) {
+		log.Info("Shard members changed", "members", ring.Members())
+		previous := s.ring
+		s.ring = ring
+		s.changedAt = now
+		s.handoff = true
+		// the objects moved from the replica are stopped right away, the ones moved back to it are started again
+		filters = append(filters, func(key string) bool {
+			return s.isOwner(previous, key) != s.isOwner(ring, key)
+		})
+	} else if s.handoff && now.Sub(s.changedAt) >= s.handoffDelay {
+		stable := s.stableRing
+		s.stableRing = ring
+		s.handoff = false
+		// the objects moved to the replica are started once their previous owner had time to stop them
+		filters = append(filters, func(key string) bool {
+			return s.isOwner(ring, key) && !s.isOwner(stable, key)
+		})
+	}
+	sources := s.sources
+	s.lock.Unlock()
+
+	for _, filter := range filters {
+		s.enqueue(ctx, sources, filter)
+	}
+}
+
+// enqueue sends the objects of the sources matching the filter to their controller
+func (s *Sharder) enqueue(ctx context.Context, sources []*shardSource, filter func(key string) bool) {
+	for _, src := range sources {
+		list, ok := src.list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			continue
+		}
+		if err := s.client.List(ctx, list); err != nil {
+			log.Error(err, "error listing objects to reconcile after shard change")
+			continue
+		}
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			log.Error(err, "error extracting objects to reconcile after shard change")
+			continue
+		}
+		var events []event.GenericEvent
+		for _, object := range objects {
+			identifiable, ok := object.(interface {
+				client.Object
+				GenerateIdentifier() string
+			})
+			if ok && filter(identifiable.GenerateIdentifier()) {
+				events = append(events, event.GenericEvent{Object: identifiable})
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		go func(ch chan<- event.GenericEvent) {
+			for _, e := range events {
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(src.events)
+	}
+}
+
+// renew creates or renews the Lease of the replica
+func (s *Sharder) renew(ctx context.Context, now time.Time) error {
+	lease := &coordinationv1.Lease{}
+	err := s.reader.Get(ctx, types.NamespacedName{Namespace: s.options.Namespace, Name: s.options.Identity}, lease)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.options.Namespace,
+				Name:      s.options.Identity,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				AcquireTime: &metav1.MicroTime{Time: now},
+			},
+		}
+		s.setLease(lease, now)
+		return s.client.Create(ctx, lease)
+	}
+	if err != nil {
+		return err
+	}
+	s.setLease(lease, now)
+	return s.client.Update(ctx, lease)
+}
+
+func (s *Sharder) setLease(lease *coordinationv1.Lease, now time.Time) {
+	if lease.Labels == nil {
+		lease.Labels = map[string]string{}
+	}
+	lease.Labels[ShardGroupLabel] = s.options.Group
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[MetricsServiceAddressAnnotation] = s.options.Address
+	lease.Spec.HolderIdentity = ptr.To(s.options.Identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(s.options.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+}
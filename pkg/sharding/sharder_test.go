@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const testNamespace = "keda"
+
+func newTestSharder(t *testing.T, c client.Client, identity string, now *time.Time) *Sharder {
+	sharder, err := NewSharder(c, c, Options{Namespace: testNamespace, Identity: identity, Address: identity + ":9666"})
+	require.NoError(t, err)
+	sharder.now = func() time.Time { return *now }
+	return sharder
+}
+
+// keyOwnedBy returns the key of a ScaledObject owned by the member in the ring
+func keyOwnedBy(t *testing.T, ring Ring, member string) string {
+	for i := 0; i < 100; i++ {
+		key := kedav1alpha1.GenerateIdentifier("ScaledObject", "default", fmt.Sprintf("worker-%d", i))
+		if owner, _ := ring.Owner(key); owner.Name == member {
+			return key
+		}
+	}
+	t.Fatalf("no key owned by %s", member)
+	return ""
+}
+
+func TestSharderHandoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+	now := time.Now()
+
+	a := newTestSharder(t, c, "keda-operator-a", &now)
+	a.sync(ctx)
+
+	lease := &coordinationv1.Lease{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "keda-operator-a"}, lease))
+	assert.Equal(t, DefaultShardGroup, lease.Labels[ShardGroupLabel])
+	assert.Equal(t, "keda-operator-a:9666", lease.Annotations[MetricsServiceAddressAnnotation])
+
+	key := kedav1alpha1.GenerateIdentifier("ScaledObject", "default", "worker")
+	assert.False(t, a.Owns(key), "the objects are owned once the handoff delay passed")
+	now = now.Add(a.handoffDelay)
+	a.sync(ctx)
+	assert.True(t, a.Owns(key))
+
+	// a second replica joins, the objects moving to it are released by the first one right away
+	// and owned by the second one once the handoff delay passed
+	b := newTestSharder(t, c, "keda-operator-b", &now)
+	b.sync(ctx)
+	a.sync(ctx)
+	ring := NewRing([]Member{{Name: "keda-operator-a"}, {Name: "keda-operator-b"}})
+	keyA, keyB := keyOwnedBy(t, ring, "keda-operator-a"), keyOwnedBy(t, ring, "keda-operator-b")
+	assert.True(t, a.Owns(keyA))
+	assert.False(t, a.Owns(keyB))
+	assert.False(t, b.Owns(keyB))
+	assert.False(t, b.Owns(keyA))
+
+	now = now.Add(b.handoffDelay)
+	a.sync(ctx)
+	b.sync(ctx)
+	assert.True(t, a.Owns(keyA))
+	assert.False(t, a.Owns(keyB))
+	assert.True(t, b.Owns(keyB))
+	assert.False(t, b.Owns(keyA))
+
+	// the second replica stops renewing its Lease, it stops scaling once the renew deadline passed
+	// and its objects move back to the first replica once the Lease expired
+	now = now.Add(b.options.RenewDeadline + time.Second)
+	assert.False(t, b.Owns(keyB))
+	a.sync(ctx)
+	assert.False(t, a.Owns(keyB), "the Lease of the second replica isn't expired yet")
+	now = now.Add(b.options.LeaseDuration)
+	a.sync(ctx)
+	assert.True(t, a.Owns(keyA))
+	assert.False(t, a.Owns(keyB))
+	now = now.Add(a.handoffDelay)
+	a.sync(ctx)
+	assert.True(t, a.Owns(keyB))
+}
+
+func TestSharderEnqueue(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	var objects []client.Object
+	for i := 0; i < 10; i++ {
+		objects = append(objects, &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("worker-%d", i)}})
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	now := time.Now()
+
+	sharder := newTestSharder(t, c, "keda-operator-a", &now)
+	sharder.Source(&kedav1alpha1.ScaledObjectList{})
+
+	sharder.sync(ctx)
+	now = now.Add(sharder.handoffDelay)
+	sharder.sync(ctx)
+
+	received := map[string]int{}
+	for i := 0; i < 2*len(objects); i++ {
+		select {
+		case e := <-sharder.sources[0].events:
+			received[e.Object.GetName()]++
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d events, got %v", 2*len(objects), received)
+		}
+	}
+	// each object is enqueued when the replica joins and when the handoff completes
+	for _, object := range objects {
+		assert.Equal(t, 2, received[object.GetName()], object.GetName())
+	}
+}
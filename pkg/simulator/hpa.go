@@ -0,0 +1,299 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"math"
+	"time"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+// Default tolerance of the HPA controller, see --horizontal-pod-autoscaler-tolerance
+const hpaTolerance = 0.1
+
+// hpaMetric is a metric of the HPA with the sum of its values
+type hpaMetric struct {
+	targetType v2.MetricTargetType
+	target     float64
+	value      float64
+}
+
+// replicas returns the replica count proposed by the HPA for the metric, the current
+// replica count is kept while the usage ratio is within the tolerance
+func (m hpaMetric) replicas(currentReplicas int32) int32 {
+	if m.target <= 0 {
+		return currentReplicas
+	}
+	var ratio float64
+	var proposed float64
+	switch m.targetType {
+	case v2.AverageValueMetricType:
+		// for external metrics the average value is the sum of the values divided by the replica count
+		ratio = m.value / (m.target * float64(currentReplicas))
+		proposed = math.Ceil(m.value / m.target)
+	default:
+		// Value targets, and the average utilization or value of resource metrics given per pod
+		ratio = m.value / m.target
+		proposed = math.Ceil(ratio * float64(currentReplicas))
+	}
+	if math.Abs(ratio-1) <= hpaTolerance {
+		return currentReplicas
+	}
+	return int32(math.Min(proposed, math.MaxInt32))
+}
+
+type timestampedRecommendation struct {
+	timestamp       time.Time
+	recommendations int32
+}
+
+type timestampedScaleEvent struct {
+	timestamp     time.Time
+	replicaChange int32
+}
+
+// hpaEmulator reproduces the replica calculation of the HPA controller and the
+// stabilization windows and scaling policies of its behavior
+type hpaEmulator struct {
+	minReplicas     int32
+	maxReplicas     int32
+	scaleUp         *v2.HPAScalingRules
+	scaleDown       *v2.HPAScalingRules
+	recommendations []timestampedRecommendation
+	scaleUpEvents   []timestampedScaleEvent
+	scaleDownEvents []timestampedScaleEvent
+}
+
+func newHPAEmulator(minReplicas, maxReplicas int32, behavior *v2.HorizontalPodAutoscalerBehavior) *hpaEmulator {
+	var scaleUp, scaleDown *v2.HPAScalingRules
+	if behavior != nil {
+		scaleUp, scaleDown = behavior.ScaleUp, behavior.ScaleDown
+	}
+	return &hpaEmulator{
+		minReplicas: minReplicas,
+		maxReplicas: maxReplicas,
+		scaleUp:     defaultScaleUpRules(scaleUp),
+		scaleDown:   defaultScaleDownRules(scaleDown),
+	}
+}
+
+// defaultScaleUpRules fills the scale up rules with the defaults applied by the API server
+func defaultScaleUpRules(rules *v2.HPAScalingRules) *v2.HPAScalingRules {
+	defaulted := &v2.HPAScalingRules{
+		StabilizationWindowSeconds: ptr.To[int32](0),
+		SelectPolicy:               ptr.To(v2.MaxChangePolicySelect),
+		Policies: []v2.HPAScalingPolicy{
+			{Type: v2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: v2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+	return mergeScalingRules(defaulted, rules)
+}
+
+// defaultScaleDownRules fills the scale down rules with the defaults applied by the API server
+func defaultScaleDownRules(rules *v2.HPAScalingRules) *v2.HPAScalingRules {
+	defaulted := &v2.HPAScalingRules{
+		StabilizationWindowSeconds: ptr.To[int32](300),
+		SelectPolicy:               ptr.To(v2.MaxChangePolicySelect),
+		Policies: []v2.HPAScalingPolicy{
+			{Type: v2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+	return mergeScalingRules(defaulted, rules)
+}
+
+func mergeScalingRules(defaulted, rules *v2.HPAScalingRules) *v2.HPAScalingRules {
+	if rules == nil {
+		return defaulted
+	}
+	if rules.StabilizationWindowSeconds != nil {
+		defaulted.StabilizationWindowSeconds = rules.StabilizationWindowSeconds
+	}
+	if rules.SelectPolicy != nil {
+		defaulted.SelectPolicy = rules.SelectPolicy
+	}
+	if len(rules.Policies) > 0 {
+		defaulted.Policies = rules.Policies
+	}
+	return defaulted
+}
+
+// recommend returns the replica count the HPA proposes for the metrics, ie. the largest one proposed by a metric
+// bounded by minReplicas and maxReplicas, the current replica count is kept if there is no metric
+func (h *hpaEmulator) recommend(currentReplicas int32, metrics []hpaMetric) int32 {
+	if len(metrics) == 0 {
+		return currentReplicas
+	}
+	replicas := int32(0)
+	for _, metric := range metrics {
+		replicas = max(replicas, metric.replicas(currentReplicas))
+	}
+	return min(max(replicas, h.minReplicas), h.maxReplicas)
+}
+
+// scale returns the replica count the HPA scales to at the given time from the replica count it recommends,
+// the recommendation is stabilized, limited by the scaling policies and bounded by minReplicas and maxReplicas
+func (h *hpaEmulator) scale(now time.Time, currentReplicas, recommendation int32) int32 {
+	recommendation = min(max(recommendation, h.minReplicas), h.maxReplicas)
+	stabilized := h.stabilize(now, currentReplicas, recommendation)
+
+	replicas := stabilized
+	switch {
+	case stabilized > currentReplicas:
+		limit := max(h.scaleUpLimit(now, currentReplicas), currentReplicas)
+		replicas = min(stabilized, limit, h.maxReplicas)
+	case stabilized < currentReplicas:
+		limit := min(h.scaleDownLimit(now, currentReplicas), currentReplicas)
+		replicas = max(stabilized, limit, h.minReplicas)
+	}
+	h.recordScaleEvent(now, currentReplicas, replicas)
+	return replicas
+}
+
+// stabilize keeps the recommendation and returns the lowest recommendation of the scale up window
+// or the highest one of the scale down window, the current replica count if it is in between
+func (h *hpaEmulator) stabilize(now time.Time, currentReplicas, recommendation int32) int32 {
+	upCutoff := now.Add(-time.Second * time.Duration(*h.scaleUp.StabilizationWindowSeconds))
+	downCutoff := now.Add(-time.Second * time.Duration(*h.scaleDown.StabilizationWindowSeconds))
+	longestCutoff := upCutoff
+	if downCutoff.Before(longestCutoff) {
+		longestCutoff = downCutoff
+	}
+
+	upRecommendation, downRecommendation := recommendation, recommendation
+	kept := []timestampedRecommendation{{timestamp: now, recommendations: recommendation}}
+	for _, rec := range h.recommendations {
+		if rec.timestamp.After(upCutoff) {
+			upRecommendation = min(upRecommendation, rec.recommendations)
+		}
+		if rec.timestamp.After(downCutoff) {
+			downRecommendation = max(downRecommendation, rec.recommendations)
+		}
+		if rec.timestamp.After(longestCutoff) {
+			kept = append(kept, rec)
+		}
+	}
+	h.recommendations = kept
+
+	stabilized := currentReplicas
+	if stabilized < upRecommendation {
+		stabilized = upRecommendation
+	}
+	if stabilized > downRecommendation {
+		stabilized = downRecommendation
+	}
+	return stabilized
+}
+
+// scaleUpLimit returns the largest replica count the scale up policies allow
+func (h *hpaEmulator) scaleUpLimit(now time.Time, currentReplicas int32) int32 {
+	selectPolicy := *h.scaleUp.SelectPolicy
+	if selectPolicy == v2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	result := int32(math.MinInt32)
+	if selectPolicy == v2.MinChangePolicySelect {
+		result = math.MaxInt32
+	}
+	for _, policy := range h.scaleUp.Policies {
+		added := getReplicasChangePerPeriod(now, policy.PeriodSeconds, h.scaleUpEvents)
+		deleted := getReplicasChangePerPeriod(now, policy.PeriodSeconds, h.scaleDownEvents)
+		periodStartReplicas := currentReplicas - added + deleted
+		var proposed int32
+		if policy.Type == v2.PodsScalingPolicy {
+			proposed = periodStartReplicas + policy.Value
+		} else {
+			proposed = int32(math.Ceil(float64(periodStartReplicas) * (1 + float64(policy.Value)/100)))
+		}
+		if selectPolicy == v2.MinChangePolicySelect {
+			result = min(result, proposed)
+		} else {
+			result = max(result, proposed)
+		}
+	}
+	return result
+}
+
+// scaleDownLimit returns the smallest replica count the scale down policies allow
+func (h *hpaEmulator) scaleDownLimit(now time.Time, currentReplicas int32) int32 {
+	selectPolicy := *h.scaleDown.SelectPolicy
+	if selectPolicy == v2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	result := int32(math.MaxInt32)
+	if selectPolicy == v2.MinChangePolicySelect {
+		result = math.MinInt32
+	}
+	for _, policy := range h.scaleDown.Policies {
+		added := getReplicasChangePerPeriod(now, policy.PeriodSeconds, h.scaleUpEvents)
+		deleted := getReplicasChangePerPeriod(now, policy.PeriodSeconds, h.scaleDownEvents)
+		periodStartReplicas := currentReplicas - added + deleted
+		var proposed int32
+		if policy.Type == v2.PodsScalingPolicy {
+			proposed = periodStartReplicas - policy.Value
+		} else {
+			proposed = int32(float64(periodStartReplicas) * (1 - float64(policy.Value)/100))
+		}
+		if selectPolicy == v2.MinChangePolicySelect {
+			result = max(result, proposed)
+		} else {
+			result = min(result, proposed)
+		}
+	}
+	return result
+}
+
+// recordScaleEvent keeps the replica change for the scaling policies, events older than the longest policy period are dropped
+func (h *hpaEmulator) recordScaleEvent(now time.Time, previousReplicas, replicas int32) {
+	h.scaleUpEvents = dropOldScaleEvents(now, h.scaleUp, h.scaleUpEvents)
+	h.scaleDownEvents = dropOldScaleEvents(now, h.scaleDown, h.scaleDownEvents)
+	switch {
+	case replicas > previousReplicas:
+		h.scaleUpEvents = append(h.scaleUpEvents, timestampedScaleEvent{timestamp: now, replicaChange: replicas - previousReplicas})
+	case replicas < previousReplicas:
+		h.scaleDownEvents = append(h.scaleDownEvents, timestampedScaleEvent{timestamp: now, replicaChange: previousReplicas - replicas})
+	}
+}
+
+func dropOldScaleEvents(now time.Time, rules *v2.HPAScalingRules, events []timestampedScaleEvent) []timestampedScaleEvent {
+	longestPeriod := int32(0)
+	for _, policy := range rules.Policies {
+		longestPeriod = max(longestPeriod, policy.PeriodSeconds)
+	}
+	cutoff := now.Add(-time.Second * time.Duration(longestPeriod))
+	var kept []timestampedScaleEvent
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
+
+// getReplicasChangePerPeriod returns the replica change of the events in the period ending at the given time
+func getReplicasChangePerPeriod(now time.Time, periodSeconds int32, events []timestampedScaleEvent) int32 {
+	cutoff := now.Add(-time.Second * time.Duration(periodSeconds))
+	change := int32(0)
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			change += event.replicaChange
+		}
+	}
+	return change
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/utils/ptr"
+)
+
+func TestHPAMetricReplicas(t *testing.T) {
+	tests := []struct {
+		name            string
+		metric          hpaMetric
+		currentReplicas int32
+		expected        int32
+	}{
+		{
+			name:            "average value",
+			metric:          hpaMetric{targetType: v2.AverageValueMetricType, target: 10, value: 95},
+			currentReplicas: 2,
+			expected:        10,
+		},
+		{
+			name:            "average value within tolerance",
+			metric:          hpaMetric{targetType: v2.AverageValueMetricType, target: 10, value: 21},
+			currentReplicas: 2,
+			expected:        2,
+		},
+		{
+			name:            "value",
+			metric:          hpaMetric{targetType: v2.ValueMetricType, target: 100, value: 50},
+			currentReplicas: 4,
+			expected:        2,
+		},
+		{
+			name:            "utilization",
+			metric:          hpaMetric{targetType: v2.UtilizationMetricType, target: 50, value: 80},
+			currentReplicas: 3,
+			expected:        5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.metric.replicas(test.currentReplicas))
+		})
+	}
+}
+
+func TestHPAEmulatorScaleUpPolicies(t *testing.T) {
+	hpa := newHPAEmulator(1, 100, nil)
+	now := time.Unix(0, 0)
+
+	// the default policies allow adding 4 pods or doubling the replica count every 15 seconds
+	assert.Equal(t, int32(5), hpa.scale(now, 1, 50))
+	assert.Equal(t, int32(5), hpa.scale(now.Add(10*time.Second), 5, 50))
+	assert.Equal(t, int32(10), hpa.scale(now.Add(20*time.Second), 5, 50))
+	assert.Equal(t, int32(20), hpa.scale(now.Add(40*time.Second), 10, 50))
+}
+
+func TestHPAEmulatorScaleDownStabilization(t *testing.T) {
+	hpa := newHPAEmulator(1, 100, &v2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &v2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](60)},
+	})
+	now := time.Unix(0, 0)
+
+	assert.Equal(t, int32(10), hpa.scale(now, 10, 10))
+	// the highest recommendation of the window is kept
+	assert.Equal(t, int32(10), hpa.scale(now.Add(30*time.Second), 10, 2))
+	assert.Equal(t, int32(10), hpa.scale(now.Add(50*time.Second), 10, 2))
+	assert.Equal(t, int32(2), hpa.scale(now.Add(70*time.Second), 10, 2))
+}
+
+func TestHPAEmulatorScaleDownPolicies(t *testing.T) {
+	hpa := newHPAEmulator(1, 100, &v2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &v2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.To[int32](0),
+			Policies:                   []v2.HPAScalingPolicy{{Type: v2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60}},
+		},
+	})
+	now := time.Unix(0, 0)
+
+	assert.Equal(t, int32(8), hpa.scale(now, 10, 1))
+	assert.Equal(t, int32(8), hpa.scale(now.Add(30*time.Second), 8, 1))
+	assert.Equal(t, int32(6), hpa.scale(now.Add(75*time.Second), 8, 1))
+}
+
+func TestHPAEmulatorRecommend(t *testing.T) {
+	hpa := newHPAEmulator(2, 8, nil)
+
+	assert.Equal(t, int32(3), hpa.recommend(3, nil))
+	assert.Equal(t, int32(5), hpa.recommend(3, []hpaMetric{
+		{targetType: v2.AverageValueMetricType, target: 10, value: 50},
+		{targetType: v2.UtilizationMetricType, target: 50, value: 40},
+	}))
+	assert.Equal(t, int32(8), hpa.recommend(3, []hpaMetric{{targetType: v2.AverageValueMetricType, target: 1, value: 50}}))
+	assert.Equal(t, int32(2), hpa.recommend(3, []hpaMetric{{targetType: v2.AverageValueMetricType, target: 10, value: 0}}))
+}
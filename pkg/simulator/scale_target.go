@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/scale"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// scaleTarget stands for the ScaleTarget of the simulated ScaledObject, it serves its replica count
+// to the code reading it through the client or the scale client
+type scaleTarget struct {
+	replicas int32
+}
+
+// client returns a client serving the replica count of Deployments and StatefulSets,
+// the status updates are dropped as the simulator keeps the status in memory
+func (t *scaleTarget) client() runtimeclient.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, client runtimeclient.WithWatch, key runtimeclient.ObjectKey, obj runtimeclient.Object, opts ...runtimeclient.GetOption) error {
+			switch target := obj.(type) {
+			case *appsv1.Deployment:
+				target.Name, target.Namespace = key.Name, key.Namespace
+				target.Spec.Replicas = ptr.To(t.replicas)
+				return nil
+			case *appsv1.StatefulSet:
+				target.Name, target.Namespace = key.Name, key.Namespace
+				target.Spec.Replicas = ptr.To(t.replicas)
+				return nil
+			default:
+				return client.Get(ctx, key, obj, opts...)
+			}
+		},
+		SubResourcePatch: func(context.Context, runtimeclient.Client, string, runtimeclient.Object, runtimeclient.Patch, ...runtimeclient.SubResourcePatchOption) error {
+			return nil
+		},
+	}).Build()
+}
+
+// Scales returns the scale client of the ScaleTarget
+func (t *scaleTarget) Scales(string) scale.ScaleInterface {
+	return t
+}
+
+// Get returns the scale of the ScaleTarget
+func (t *scaleTarget) Get(_ context.Context, _ schema.GroupResource, name string, _ metav1.GetOptions) (*autoscalingv1.Scale, error) {
+	return &autoscalingv1.Scale{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       autoscalingv1.ScaleSpec{Replicas: t.replicas},
+		Status:     autoscalingv1.ScaleStatus{Replicas: t.replicas},
+	}, nil
+}
+
+// Update isn't supported, the simulator scales the ScaleTarget itself
+func (t *scaleTarget) Update(context.Context, schema.GroupResource, *autoscalingv1.Scale, metav1.UpdateOptions) (*autoscalingv1.Scale, error) {
+	return nil, fmt.Errorf("the simulated scale target can't be updated")
+}
+
+// Patch isn't supported, the simulator scales the ScaleTarget itself
+func (t *scaleTarget) Patch(context.Context, schema.GroupVersionResource, string, types.PatchType, []byte, metav1.PatchOptions) (*autoscalingv1.Scale, error) {
+	return nil, fmt.Errorf("the simulated scale target can't be patched")
+}
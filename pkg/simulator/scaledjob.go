@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/expr-lang/expr/vm"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+)
+
+// defaultJobDuration is how long the simulated jobs run if Options.JobDuration isn't set
+const defaultJobDuration = time.Minute
+
+// SimulateScaledJob replays the samples through the scaling logic of the ScaledJob, each sample being evaluated
+// as a polling cycle of KEDA with the metrics computation of the scale loop. The jobs created run for
+// Options.JobDuration and are never pending.
+func SimulateScaledJob(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, samples []Sample, options Options) (*Timeline, error) {
+	sj := scaledJob.DeepCopy()
+	triggers, err := newTriggers(sj.Spec.Triggers, v2.AverageValueMetricType, options)
+	if err != nil {
+		return nil, err
+	}
+	jobDuration := options.JobDuration
+	if jobDuration <= 0 {
+		jobDuration = defaultJobDuration
+	}
+	logger := log.WithValues("scaledJob.Namespace", sj.Namespace, "scaledJob.Name", sj.Name)
+	var compiledScalingStrategy *vm.Program
+	if sj.Spec.ScalingStrategy.Strategy == kedav1alpha1.ExpressionScalingStrategy {
+		if compiledScalingStrategy, err = kedav1alpha1.CompileScalingStrategyExpression(sj); err != nil {
+			return nil, err
+		}
+	}
+
+	// the scalers of the triggers read the sample the simulation is at
+	var current Sample
+	scalersCache := &cache.ScalersCache{
+		Scalers:  newScalerBuilders(triggers, &current),
+		Recorder: &record.FakeRecorder{},
+		Clock:    func() time.Time { return current.Timestamp },
+	}
+	if sj.IsUsingModifiers() {
+		program, err := kedav1alpha1.ValidateAndCompileScaledJobScalingModifiers(sj)
+		if err != nil {
+			return nil, err
+		}
+		scalersCache.CompiledFormula = program
+		if program != nil {
+			scalersCache.MetricsHistory = cache.NewMetricsHistory()
+		}
+	}
+
+	// the status updates of the ScaledJob are dropped by the client, the simulator keeps it in memory
+	client := (&scaleTarget{}).client()
+	lastKnownMetrics := metricscache.NewMetricsCache()
+	// jobs holds the completion time of the running jobs
+	var jobs []time.Time
+	for i := int32(0); i < options.InitialReplicas; i++ {
+		jobs = append(jobs, samples[0].Timestamp.Add(jobDuration))
+	}
+
+	timeline := &Timeline{Kind: "ScaledJob", Name: sj.Name}
+	for _, sample := range samples {
+		current = sample
+		step := Step{Timestamp: sample.Timestamp}
+
+		running := jobs[:0]
+		for _, completion := range jobs {
+			if completion.After(sample.Timestamp) {
+				running = append(running, completion)
+			}
+		}
+		jobs = running
+
+		// the scale loop queries the scalers of the triggers and persists their health
+		status := sj.Status.DeepCopy()
+		scalersMetrics := scaling.GetScaledJobMetrics(ctx, sj, scalersCache, status, lastKnownMetrics)
+		fallback.UpdateScaledJobStatus(ctx, client, sj, status)
+		fallbackCondition := sj.Status.Conditions.GetFallbackCondition()
+		step.IsFallback = fallbackCondition.IsTrue()
+		for _, t := range triggers {
+			if _, found := sample.Values[t.name]; !found && !t.isResource {
+				step.IsError = true
+			}
+		}
+		if sj.IsUsingModifiers() && !step.IsFallback && len(scalersMetrics) > 0 {
+			step.FormulaValue = &scalersMetrics[0].QueueLength
+		}
+
+		isPaused, err := isScaledJobPaused(sj, sample.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		step.IsPaused = isPaused
+
+		isActive, scaleTo, maxScale, _ := scaledjob.IsScaledJobActive(scalersMetrics, sj.Spec.ScalingStrategy.MultipleScalersCalculation, sj.MinReplicaCount(), sj.MaxReplicaCount())
+		step.IsActive = isActive
+		if isActive && !isPaused {
+			effectiveMaxScale, scaleTo := executor.GetScalingDecision(sj, int64(len(jobs)), scaleTo, maxScale, 0, compiledScalingStrategy, logger)
+			created := max(min(scaleTo, effectiveMaxScale), 0)
+			for i := int64(0); i < created; i++ {
+				jobs = append(jobs, sample.Timestamp.Add(jobDuration))
+			}
+			step.CreatedJobs = created
+		}
+
+		step.Replicas = int32(len(jobs))
+		timeline.Steps = append(timeline.Steps, step)
+	}
+	return timeline, nil
+}
+
+// isScaledJobPaused returns whether the ScaledJob is paused at the given time,
+// the paused annotation overrides the pause schedules
+func isScaledJobPaused(sj *kedav1alpha1.ScaledJob, now time.Time) (bool, error) {
+	if value, found := sj.GetAnnotations()[kedav1alpha1.PausedAnnotation]; found {
+		paused, err := strconv.ParseBool(value)
+		if err != nil {
+			// as for the controller, a value which is not a boolean pauses the ScaledJob
+			return true, nil
+		}
+		return paused, nil
+	}
+	for i := range sj.Spec.PauseSchedules {
+		if err := sj.Spec.PauseSchedules[i].Validate(); err != nil {
+			return false, fmt.Errorf("pauseSchedules[%d]: %w", i, err)
+		}
+	}
+	schedule, _ := sj.GetActivePauseSchedule(now)
+	return schedule != nil, nil
+}
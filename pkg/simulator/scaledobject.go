@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+)
+
+var log = logf.Log.WithName("simulator")
+
+// scaledObjectSimulator holds the state KEDA and the HPA keep between the samples
+type scaledObjectSimulator struct {
+	scaledObject     *kedav1alpha1.ScaledObject
+	triggers         []trigger
+	target           *scaleTarget
+	client           runtimeclient.Client
+	cache            *cache.ScalersCache
+	lastKnownMetrics metricscache.MetricsCache
+	hpa              *hpaEmulator
+	now              time.Time
+	sample           Sample
+	logger           logr.Logger
+}
+
+// SimulateScaledObject replays the samples through the scaling logic of the ScaledObject, each sample being
+// evaluated as a polling cycle of KEDA, with the state computation of the scale loop and the decision of the
+// scale executor, followed by a sync of the HPA.
+func SimulateScaledObject(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, samples []Sample, options Options) (*Timeline, error) {
+	so := scaledObject.DeepCopy()
+	if err := kedav1alpha1.CheckReplicaCountBoundsAreValid(so); err != nil {
+		return nil, err
+	}
+	triggers, err := newTriggers(so.Spec.Triggers, v2.AverageValueMetricType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &scaledObjectSimulator{
+		scaledObject:     so,
+		triggers:         triggers,
+		target:           &scaleTarget{replicas: options.InitialReplicas},
+		lastKnownMetrics: metricscache.NewMetricsCache(),
+		logger:           log.WithValues("scaledObject.Namespace", so.Namespace, "scaledObject.Name", so.Name),
+	}
+	s.client = s.target.client()
+	s.cache = &cache.ScalersCache{
+		ScaledObject: so,
+		Scalers:      newScalerBuilders(triggers, &s.sample),
+		Recorder:     &record.FakeRecorder{},
+		Clock:        func() time.Time { return s.now },
+	}
+	if so.IsUsingModifiers() {
+		program, err := kedav1alpha1.ValidateAndCompileScalingModifiers(so)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.CompiledFormula = program
+		if program != nil {
+			s.cache.MetricsHistory = cache.NewMetricsHistory()
+		}
+	}
+	so.Status = kedav1alpha1.ScaledObjectStatus{ScaleTargetGVKR: getScaleTargetGVKR(so.Spec.ScaleTargetRef)}
+
+	var behavior *v2.HorizontalPodAutoscalerBehavior
+	if so.Spec.Advanced != nil && so.Spec.Advanced.HorizontalPodAutoscalerConfig != nil {
+		behavior = so.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
+	}
+	s.hpa = newHPAEmulator(*so.GetHPAMinReplicas(), so.GetHPAMaxReplicas(), behavior)
+
+	timeline := &Timeline{Kind: "ScaledObject", Name: so.Name, Warnings: scaledObjectWarnings(so)}
+	for _, sample := range samples {
+		step, err := s.step(ctx, sample)
+		if err != nil {
+			return nil, err
+		}
+		timeline.Steps = append(timeline.Steps, step)
+	}
+	return timeline, nil
+}
+
+// scaledObjectWarnings returns the features of the ScaledObject the simulator doesn't reproduce
+func scaledObjectWarnings(so *kedav1alpha1.ScaledObject) []string {
+	var warnings []string
+	if so.Spec.ReadinessGate != nil {
+		warnings = append(warnings, "the readiness gate is not simulated, its dependencies are always ready")
+	}
+	if so.Spec.Fallback != nil && so.Spec.Fallback.GetBehavior() == kedav1alpha1.FallbackBehaviorLastKnownMetric {
+		warnings = append(warnings, "fallback.maxMetricAge is not simulated, the last known metrics never expire")
+	}
+	return warnings
+}
+
+// getScaleTargetGVKR returns the group, version, kind and resource of the ScaleTarget, a Deployment by default
+func getScaleTargetGVKR(ref *kedav1alpha1.ScaleTarget) *kedav1alpha1.GroupVersionKindResource {
+	gvkr := &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+	if ref == nil {
+		return gvkr
+	}
+	if ref.APIVersion != "" {
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil {
+			gvkr.Group, gvkr.Version = gv.Group, gv.Version
+		}
+	}
+	if ref.Kind != "" {
+		gvkr.Kind = ref.Kind
+		gvkr.Resource = strings.ToLower(ref.Kind) + "s"
+	}
+	return gvkr
+}
+
+// step evaluates the triggers at the sample and scales the simulated ScaleTarget as KEDA and the HPA would
+func (s *scaledObjectSimulator) step(ctx context.Context, sample Sample) (Step, error) {
+	so := s.scaledObject
+	s.now = sample.Timestamp
+	s.sample = sample
+	currentReplicas := s.target.replicas
+	step := Step{Timestamp: sample.Timestamp}
+
+	// the operator keeps the replica schedule in force in status
+	schedule, err := so.FindActiveSchedule(s.now)
+	if err != nil {
+		return step, err
+	}
+	so.Status.ActiveSchedule = ""
+	if schedule != nil {
+		so.Status.ActiveSchedule = schedule.Name
+	}
+
+	// the scale loop queries the scalers of the triggers, the status keeps the triggers keeping the ScaledObject active
+	isActive, isError, _, state, err := scaling.GetScaledObjectState(ctx, so, s.cache)
+	if err != nil {
+		return step, err
+	}
+	step.IsActive, step.IsError = isActive, isError
+	if state.FormulaValue != nil {
+		value := state.FormulaValue.AsApproximateFloat64()
+		step.FormulaValue = &value
+	}
+	so.Status = *scaling.GetTriggersStatus(so, state, s.now)
+
+	hpaMetrics, err := s.getHPAMetrics(ctx, sample, &step)
+	if err != nil {
+		return step, err
+	}
+
+	isPaused, pausedReplicas, err := s.getPausedReplicas(currentReplicas)
+	if err != nil {
+		return step, err
+	}
+	step.IsPaused = isPaused
+	var pausedCount *int32
+	if isPaused {
+		pausedCount = &pausedReplicas
+	}
+
+	replicas := currentReplicas
+	action := executor.GetScaleAction(so, currentReplicas, isActive, isError, pausedCount)
+	switch action {
+	case executor.ScaleActionPause:
+		so.Status.ActivationRamp = nil
+		replicas = pausedReplicas
+	case executor.ScaleActionScaleToZeroBySchedule:
+		so.Status.ActivationRamp = nil
+		replicas = 0
+	case executor.ScaleActionActivate:
+		replicas = executor.GetActivationReplicas(so)
+		so.Status.LastActiveTime = ptr.To(metav1.NewTime(s.now))
+		so.Status.ActivationRamp = executor.StartActivationRamp(so, replicas, state.DesiredReplicas, s.now)
+	case executor.ScaleActionHoldOnError:
+		// the ScaleTarget and the activation ramp in progress are held while some triggers fail
+	case executor.ScaleActionKeepActive:
+		so.Status.LastActiveTime = ptr.To(metav1.NewTime(s.now))
+		replicas, so.Status.ActivationRamp = executor.StepActivationRamp(so, currentReplicas, state.DesiredReplicas, s.now)
+	default:
+		so.Status.ActivationRamp = nil
+		switch action {
+		case executor.ScaleActionFallback:
+			replicas = so.Spec.Fallback.GetReplicas(currentReplicas)
+		case executor.ScaleActionDeactivate:
+			if scaleToReplicas, cooledDown := executor.GetDeactivationReplicas(so, s.now); cooledDown {
+				replicas = scaleToReplicas
+			}
+		case executor.ScaleActionScaleToMinReplicas:
+			replicas = *so.GetMinReplicaCount()
+		}
+	}
+
+	// the HPA scales the ScaleTarget KEDA left as is, it is disabled while the ScaleTarget is paused or scaled to zero,
+	// and the operator keeps its bounds in sync with the active schedule
+	if replicas == currentReplicas && currentReplicas > 0 && action != executor.ScaleActionPause {
+		s.hpa.minReplicas, s.hpa.maxReplicas = *so.GetHPAMinReplicas(), so.GetHPAMaxReplicas()
+		recommendation := s.hpa.recommend(currentReplicas, hpaMetrics)
+		step.Recommendation = &recommendation
+		replicas = s.hpa.scale(s.now, currentReplicas, recommendation)
+	}
+
+	s.target.replicas = replicas
+	step.Replicas = replicas
+	return step, nil
+}
+
+// getHPAMetrics returns the metrics the HPA reads at the sample: the resource metrics and the external metrics served by KEDA,
+// which fall back to fallback.replicas when the triggers keep failing and are replaced by the output of the scalingModifiers
+func (s *scaledObjectSimulator) getHPAMetrics(ctx context.Context, sample Sample, step *Step) ([]hpaMetric, error) {
+	so := s.scaledObject
+	var hpaMetrics []hpaMetric
+	var externalMetrics []external_metrics.ExternalMetricValue
+	externalMetricsBySpec := map[string][]external_metrics.ExternalMetricValue{}
+	metricTriggerPairList := map[string]string{}
+	for _, t := range s.triggers {
+		if t.isResource {
+			// resource metrics are read by the HPA from the metrics server, a missing value is ignored
+			if value, found := sample.Values[t.name]; found {
+				hpaMetrics = append(hpaMetrics, hpaMetric{targetType: v2.ValueMetricType, target: t.target, value: value})
+			}
+			continue
+		}
+
+		metrics, _, scalerErr := t.observe(sample)
+		metrics, fallbackActive, err := fallback.GetMetricsWithFallback(ctx, s.client, s.target, metrics, scalerErr, t.metricName, so, t.spec, s.lastKnownMetrics)
+		if fallbackActive {
+			step.IsFallback = true
+		}
+		if err == nil {
+			externalMetricsBySpec[t.metricName] = metrics
+			externalMetrics = append(externalMetrics, metrics...)
+		}
+		pairs, err := modifiers.GetPairTriggerAndMetric(so, t.metricName, t.scaleName)
+		if err != nil {
+			return nil, err
+		}
+		for metric, triggerName := range pairs {
+			metricTriggerPairList[metric] = triggerName
+		}
+	}
+
+	if !so.IsUsingModifiers() {
+		for _, t := range s.triggers {
+			if metrics, found := externalMetricsBySpec[t.metricName]; found && !t.isResource {
+				hpaMetrics = append(hpaMetrics, hpaMetric{targetType: t.metricType, target: t.target, value: sumMetrics(metrics)})
+			}
+		}
+		return hpaMetrics, nil
+	}
+
+	// the output of the scalingModifiers is the external metric of the HPA, the metrics are passed through when the fallback is active
+	scalingModifiers := so.Spec.Advanced.ScalingModifiers
+	metrics := modifiers.HandleScalingModifiers(so, externalMetrics, metricTriggerPairList, step.IsFallback, s.cache, s.logger)
+	target, err := strconv.ParseFloat(scalingModifiers.Target, 64)
+	if err != nil || target <= 0 || len(metrics) == 0 {
+		return hpaMetrics, nil
+	}
+	metricType := v2.AverageValueMetricType
+	if scalingModifiers.MetricType != "" {
+		metricType = scalingModifiers.MetricType
+	}
+	return append(hpaMetrics, hpaMetric{targetType: metricType, target: target, value: sumMetrics(metrics)}), nil
+}
+
+// getPausedReplicas returns whether the ScaledObject is paused at the time of the sample and the replica count
+// it is held at: the paused replica count if any, the current one otherwise. The paused annotations override the pause schedules.
+func (s *scaledObjectSimulator) getPausedReplicas(currentReplicas int32) (bool, int32, error) {
+	so := s.scaledObject
+	if value, found := so.GetAnnotations()[kedav1alpha1.PausedReplicasAnnotation]; found {
+		replicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return false, 0, fmt.Errorf("invalid %s annotation: %w", kedav1alpha1.PausedReplicasAnnotation, err)
+		}
+		return true, int32(replicas), nil
+	}
+	if so.HasPausedAnnotation() {
+		return so.NeedToBePausedByAnnotation(), currentReplicas, nil
+	}
+	schedule, _ := so.GetActivePauseSchedule(s.now)
+	switch {
+	case schedule == nil:
+		return false, 0, nil
+	case schedule.PausedReplicaCount != nil:
+		return true, *schedule.PausedReplicaCount, nil
+	default:
+		return true, currentReplicas, nil
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Series formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Sample holds the values of the triggers observed at a point in time,
+// a trigger without value fails at that time
+type Sample struct {
+	Timestamp time.Time
+	Values    map[string]float64
+}
+
+type jsonSample struct {
+	Timestamp json.RawMessage     `json:"timestamp"`
+	Values    map[string]*float64 `json:"values"`
+}
+
+// ParseSeries parses a time series of trigger values in the given format.
+//
+// The CSV format has a header with a timestamp column followed by one column per trigger,
+// an empty cell means the trigger failed. The JSON format is a list of
+// {"timestamp": ..., "values": {"<trigger>": <value>}} objects, a null or missing value means the trigger failed.
+//
+// Timestamps are either RFC 3339 times, or offsets from start given as durations ("90s") or seconds.
+// The samples must be in chronological order.
+func ParseSeries(data []byte, format string, start time.Time) ([]Sample, error) {
+	var samples []Sample
+	var err error
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		samples, err = parseCSVSeries(data, start)
+	case FormatJSON:
+		samples, err = parseJSONSeries(data, start)
+	default:
+		return nil, fmt.Errorf("unknown series format %q, must be %s or %s", format, FormatCSV, FormatJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("the series has no samples")
+	}
+	for i := 1; i < len(samples); i++ {
+		if !samples[i].Timestamp.After(samples[i-1].Timestamp) {
+			return nil, fmt.Errorf("sample %d: timestamp %s is not after the previous one", i, samples[i].Timestamp.Format(time.RFC3339))
+		}
+	}
+	return samples, nil
+}
+
+func parseCSVSeries(data []byte, start time.Time) ([]Sample, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the CSV header: %w", err)
+	}
+	if len(header) < 2 {
+		return nil, fmt.Errorf("the CSV header must have a timestamp column and at least one trigger column")
+	}
+
+	var samples []Sample
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading the CSV series: %w", err)
+		}
+		timestamp, err := parseTimestamp(record[0], start)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		sample := Sample{Timestamp: timestamp, Values: map[string]float64{}}
+		for i, cell := range record[1:] {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q for trigger %q", line, cell, header[i+1])
+			}
+			sample.Values[strings.TrimSpace(header[i+1])] = value
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func parseJSONSeries(data []byte, start time.Time) ([]Sample, error) {
+	var items []jsonSample
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("error parsing the JSON series: %w", err)
+	}
+
+	samples := make([]Sample, 0, len(items))
+	for i, item := range items {
+		raw := strings.TrimSpace(string(item.Timestamp))
+		if unquoted, err := strconv.Unquote(raw); err == nil {
+			raw = unquoted
+		}
+		timestamp, err := parseTimestamp(raw, start)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", i, err)
+		}
+		sample := Sample{Timestamp: timestamp, Values: map[string]float64{}}
+		for trigger, value := range item.Values {
+			if value != nil {
+				sample.Values[trigger] = *value
+			}
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// parseTimestamp parses an RFC 3339 time, or an offset from start given as a duration or a number of seconds
+func parseTimestamp(value string, start time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+	if offset, err := time.ParseDuration(value); err == nil {
+		return start.Add(offset), nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return start.Add(time.Duration(seconds * float64(time.Second))), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q, must be an RFC 3339 time, a duration or a number of seconds", value)
+}
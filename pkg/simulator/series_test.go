@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeries(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		data     string
+		format   string
+		expected []Sample
+		wantErr  bool
+	}{
+		{
+			name:    "timestamps not increasing",
+			format:  FormatCSV,
+			data:    "timestamp,queue\n30s,1\n1.5,3\n",
+			wantErr: true,
+		},
+		{
+			name:   "csv",
+			format: FormatCSV,
+			data: `# recorded queue length and cpu utilization
+timestamp,queue,cpu
+0,1,50
+30s,,60
+2024-01-01T00:01:00Z,3,
+`,
+			expected: []Sample{
+				{Timestamp: start, Values: map[string]float64{"queue": 1, "cpu": 50}},
+				{Timestamp: start.Add(30 * time.Second), Values: map[string]float64{"cpu": 60}},
+				{Timestamp: start.Add(time.Minute), Values: map[string]float64{"queue": 3}},
+			},
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			data:   `[{"timestamp": 0, "values": {"queue": 1}}, {"timestamp": "1m", "values": {"queue": null}}]`,
+			expected: []Sample{
+				{Timestamp: start, Values: map[string]float64{"queue": 1}},
+				{Timestamp: start.Add(time.Minute), Values: map[string]float64{}},
+			},
+		},
+		{
+			name:    "unknown format",
+			format:  "yaml",
+			data:    "- timestamp: 0",
+			wantErr: true,
+		},
+		{
+			name:    "empty series",
+			format:  FormatCSV,
+			data:    "timestamp,queue\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			format:  FormatCSV,
+			data:    "timestamp,queue\n0,many\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid timestamp",
+			format:  FormatJSON,
+			data:    `[{"timestamp": "yesterday", "values": {"queue": 1}}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			samples, err := ParseSeries([]byte(test.data), test.format, start)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, samples)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays recorded trigger values through the scaling logic of KEDA without a cluster:
+// the scaling modifiers, the fallback, the activation and cooldown of ScaledObjects, the scaling
+// strategies of ScaledJobs and an emulation of the HPA with its behavior. It reports the replica
+// count, or the number of running jobs, at each sample of the series.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// Options are the inputs of a simulation besides the scalable object and the series
+type Options struct {
+	// Targets override the target values found in the trigger metadata, by trigger
+	Targets map[string]float64
+	// Activations override the activation thresholds found in the trigger metadata, by trigger
+	Activations map[string]float64
+	// InitialReplicas is the replica count of the ScaleTarget, or the number of running jobs, before the first sample
+	InitialReplicas int32
+	// JobDuration is how long the jobs created for a ScaledJob run
+	JobDuration time.Duration
+}
+
+// Step is the outcome of the scaling logic for a sample of the series
+type Step struct {
+	Timestamp  time.Time `json:"timestamp"`
+	IsActive   bool      `json:"isActive"`
+	IsError    bool      `json:"isError"`
+	IsFallback bool      `json:"isFallback"`
+	IsPaused   bool      `json:"isPaused"`
+	// FormulaValue is the output of the scalingModifiers, if any
+	FormulaValue *float64 `json:"formulaValue,omitempty"`
+	// Recommendation is the replica count the HPA proposes for the metrics before its behavior applies, if the HPA is scaling
+	Recommendation *int32 `json:"recommendation,omitempty"`
+	// Replicas is the replica count of the ScaleTarget, or the number of running jobs, after the step
+	Replicas int32 `json:"replicas"`
+	// CreatedJobs is the number of jobs created for a ScaledJob
+	CreatedJobs int64 `json:"createdJobs,omitempty"`
+}
+
+// Timeline is the result of a simulation
+type Timeline struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Steps []Step `json:"steps"`
+	// Warnings are the features of the scalable object which are not simulated
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// targetKeys are the metadata keys scalers commonly read their target value from,
+// the ones reading it from another key are configured through Options.Targets
+var targetKeys = []string{"targetValue", "threshold", "value", "queueLength", "targetQueueLength", "lagThreshold", "listLength", "targetMetricValue"}
+
+// trigger is a trigger of the scalable object fed by a column of the series
+type trigger struct {
+	// name is the column of the series, the name of the trigger or its type if it isn't named
+	name string
+	// key is the key of the trigger in status.triggersLastActiveTime
+	key        string
+	scaleName  string
+	metricName string
+	isResource bool
+	metricType v2.MetricTargetType
+	target     float64
+	activation float64
+	spec       v2.MetricSpec
+}
+
+// newTriggers resolves the target and the activation threshold of the triggers, from the options or from the trigger metadata:
+// the first metadata key prefixed by "activation" is the activation threshold and the key it prefixes is the target,
+// otherwise the target is given by one of targetKeys
+func newTriggers(scaleTriggers []kedav1alpha1.ScaleTriggers, defaultMetricType v2.MetricTargetType, options Options) ([]trigger, error) {
+	triggers := make([]trigger, 0, len(scaleTriggers))
+	names := map[string]bool{}
+	for i, scaleTrigger := range scaleTriggers {
+		name := scaleTrigger.Name
+		if name == "" {
+			name = scaleTrigger.Type
+		}
+		if names[name] {
+			return nil, fmt.Errorf("triggers[%d]: %q is defined multiple times, the triggers must be named to be told apart in the series", i, name)
+		}
+		names[name] = true
+
+		t := trigger{
+			name:       name,
+			key:        kedav1alpha1.GetTriggerStatusKey(scaleTrigger, i),
+			scaleName:  scaleTrigger.Name,
+			metricName: fmt.Sprintf("s%d-%s", i, strings.ToLower(name)),
+			isResource: scaleTrigger.Type == "cpu" || scaleTrigger.Type == "memory",
+			metricType: scaleTrigger.MetricType,
+		}
+		if t.metricType == "" {
+			t.metricType = defaultMetricType
+			if t.isResource {
+				t.metricType = v2.UtilizationMetricType
+			}
+		}
+
+		targetKey, activationKey := findTargetKeys(scaleTrigger.Metadata)
+		var err error
+		if target, ok := options.Targets[name]; ok {
+			t.target = target
+		} else if targetKey == "" {
+			return nil, fmt.Errorf("trigger %q: no target found in metadata, set it in the options", name)
+		} else if t.target, err = parseValue(scaleTrigger.Metadata[targetKey]); err != nil {
+			return nil, fmt.Errorf("trigger %q: invalid target %s: %w", name, targetKey, err)
+		}
+		if activation, ok := options.Activations[name]; ok {
+			t.activation = activation
+		} else if activationKey != "" {
+			if t.activation, err = parseValue(scaleTrigger.Metadata[activationKey]); err != nil {
+				return nil, fmt.Errorf("trigger %q: invalid activation threshold %s: %w", name, activationKey, err)
+			}
+		}
+		if t.target <= 0 {
+			return nil, fmt.Errorf("trigger %q: target must be greater than 0", name)
+		}
+		t.spec = t.metricSpec(corev1.ResourceName(scaleTrigger.Type))
+		triggers = append(triggers, t)
+	}
+	return triggers, nil
+}
+
+// findTargetKeys returns the metadata keys of the target and of the activation threshold, empty if not found
+func findTargetKeys(metadata map[string]string) (string, string) {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		suffix, found := strings.CutPrefix(key, "activation")
+		if !found || suffix == "" {
+			continue
+		}
+		if _, err := parseValue(metadata[key]); err != nil {
+			continue
+		}
+		targetKey := strings.ToLower(suffix[:1]) + suffix[1:]
+		if _, ok := metadata[targetKey]; ok {
+			return targetKey, key
+		}
+		for _, candidate := range targetKeys {
+			if _, ok := metadata[candidate]; ok {
+				return candidate, key
+			}
+		}
+		return "", key
+	}
+	for _, candidate := range targetKeys {
+		if _, ok := metadata[candidate]; ok {
+			return candidate, ""
+		}
+	}
+	return "", ""
+}
+
+func parseValue(value string) (float64, error) {
+	quantity, err := resource.ParseQuantity(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return quantity.AsApproximateFloat64(), nil
+}
+
+// metricSpec returns the metric spec the trigger exposes to the HPA, a metric of the resource for cpu/memory triggers
+func (t trigger) metricSpec(resourceName corev1.ResourceName) v2.MetricSpec {
+	if t.isResource {
+		utilization := int32(t.target)
+		return v2.MetricSpec{
+			Type: v2.ResourceMetricSourceType,
+			Resource: &v2.ResourceMetricSource{
+				Name:   resourceName,
+				Target: v2.MetricTarget{Type: v2.UtilizationMetricType, AverageUtilization: &utilization},
+			},
+		}
+	}
+	target := resource.NewMilliQuantity(int64(t.target*1000), resource.DecimalSI)
+	spec := v2.MetricSpec{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: t.metricName},
+			Target: v2.MetricTarget{Type: t.metricType},
+		},
+	}
+	if t.metricType == v2.ValueMetricType {
+		spec.External.Target.Value = target
+	} else {
+		spec.External.Target.AverageValue = target
+	}
+	return spec
+}
+
+// observe returns the metrics of the trigger at the sample and whether it is active,
+// or the error of the scaler if the sample has no value for the trigger
+func (t trigger) observe(sample Sample) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, found := sample.Values[t.name]
+	if !found {
+		return nil, false, fmt.Errorf("no value for trigger %q at %s", t.name, sample.Timestamp.Format(time.RFC3339))
+	}
+	// the timestamp is left for the fallback to set, it is compared to the wall clock
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: t.metricName,
+		Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
+	}
+	return []external_metrics.ExternalMetricValue{metric}, value > t.activation, nil
+}
+
+// scaler is the scaler of a trigger in the scalers cache, it serves the value of the trigger at the current sample
+type scaler struct {
+	trigger trigger
+	sample  *Sample
+}
+
+// newScalerBuilders returns the scalers of the triggers reading the sample the simulation is at
+func newScalerBuilders(triggers []trigger, sample *Sample) []cache.ScalerBuilder {
+	builders := make([]cache.ScalerBuilder, 0, len(triggers))
+	for i, t := range triggers {
+		s := &scaler{trigger: t, sample: sample}
+		config := scalers.ScalerConfig{TriggerName: t.scaleName, TriggerIndex: i}
+		builders = append(builders, cache.ScalerBuilder{
+			Scaler:       s,
+			ScalerConfig: config,
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return s, &config, nil
+			},
+		})
+	}
+	return builders
+}
+
+// GetMetricsAndActivity returns the metrics of the trigger at the current sample
+func (s *scaler) GetMetricsAndActivity(context.Context, string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	return s.trigger.observe(*s.sample)
+}
+
+// GetMetricSpecForScaling returns the metric spec of the trigger
+func (s *scaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	return []v2.MetricSpec{s.trigger.spec}
+}
+
+// Close does nothing, the scaler holds no resources
+func (s *scaler) Close(context.Context) error {
+	return nil
+}
+
+func sumMetrics(metrics []external_metrics.ExternalMetricValue) float64 {
+	sum := float64(0)
+	for _, metric := range metrics {
+		sum += metric.Value.AsApproximateFloat64()
+	}
+	return sum
+}
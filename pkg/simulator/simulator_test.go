@@ -0,0 +1,334 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// newSamples returns samples of the trigger every 30 seconds, nil values are failures of the scaler
+func newSamples(trigger string, values ...*float64) []Sample {
+	samples := make([]Sample, 0, len(values))
+	for i, value := range values {
+		sample := Sample{Timestamp: time.Unix(int64(30*i), 0), Values: map[string]float64{}}
+		if value != nil {
+			sample.Values[trigger] = *value
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+func getReplicas(timeline *Timeline) []int32 {
+	replicas := make([]int32, 0, len(timeline.Steps))
+	for _, step := range timeline.Steps {
+		replicas = append(replicas, step.Replicas)
+	}
+	return replicas
+}
+
+func TestNewTriggers(t *testing.T) {
+	triggers, err := newTriggers([]kedav1alpha1.ScaleTriggers{
+		{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"queueName": "jobs", "value": "10", "activationValue": "5"}},
+		{Type: "prometheus", Metadata: map[string]string{"threshold": "0.5"}},
+		{Type: "kafka", Metadata: map[string]string{"lagThreshold": "100", "activationLagThreshold": "10"}},
+		{Type: "cpu", MetricType: "Utilization", Metadata: map[string]string{"value": "50"}},
+	}, "AverageValue", Options{Activations: map[string]float64{"prometheus": 0.1}})
+	require.NoError(t, err)
+	require.Len(t, triggers, 4)
+
+	assert.Equal(t, "queue", triggers[0].name)
+	assert.Equal(t, "s0-queue", triggers[0].metricName)
+	assert.Equal(t, float64(10), triggers[0].target)
+	assert.Equal(t, float64(5), triggers[0].activation)
+
+	assert.Equal(t, "prometheus", triggers[1].name)
+	assert.Equal(t, 0.5, triggers[1].target)
+	assert.Equal(t, 0.1, triggers[1].activation)
+
+	assert.Equal(t, float64(100), triggers[2].target)
+	assert.Equal(t, float64(10), triggers[2].activation)
+
+	assert.True(t, triggers[3].isResource)
+	assert.Equal(t, float64(50), triggers[3].target)
+
+	_, err = newTriggers([]kedav1alpha1.ScaleTriggers{
+		{Type: "rabbitmq", Metadata: map[string]string{"value": "10"}},
+		{Type: "rabbitmq", Metadata: map[string]string{"value": "20"}},
+	}, "AverageValue", Options{})
+	assert.Error(t, err, "unnamed triggers of the same type can't be told apart")
+
+	_, err = newTriggers([]kedav1alpha1.ScaleTriggers{
+		{Type: "external", Metadata: map[string]string{"scalerAddress": "scaler:6000"}},
+	}, "AverageValue", Options{})
+	assert.Error(t, err, "a target must be found")
+}
+
+func TestSimulateScaledObject(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "worker"},
+			CooldownPeriod:  ptr.To[int32](120),
+			MaxReplicaCount: ptr.To[int32](20),
+			Fallback:        &kedav1alpha1.Fallback{FailureThreshold: 1, Replicas: 6},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "10", "activationValue": "5"}},
+			},
+		},
+	}
+	samples := newSamples("queue",
+		ptr.To[float64](0),   // 0s inactive
+		ptr.To[float64](50),  // 30s activated
+		ptr.To[float64](200), // 1m0s scale up limited by the policies
+		nil,                  // 1m30s failure, scaled to fallback.replicas
+		nil,                  // 2m0s fallback
+		ptr.To[float64](0),   // 2m30s cooldown, held by the stabilization window
+		ptr.To[float64](0),   // 3m0s cooldown
+		ptr.To[float64](0),   // 3m30s scaled to zero
+	)
+
+	timeline, err := SimulateScaledObject(context.Background(), so, samples, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "ScaledObject", timeline.Kind)
+	assert.Equal(t, []int32{0, 1, 5, 6, 6, 6, 6, 0}, getReplicas(timeline))
+
+	assert.False(t, timeline.Steps[0].IsActive)
+	assert.True(t, timeline.Steps[1].IsActive)
+	assert.Nil(t, timeline.Steps[1].Recommendation, "the HPA doesn't scale from zero")
+	assert.Equal(t, ptr.To[int32](20), timeline.Steps[2].Recommendation)
+	assert.True(t, timeline.Steps[3].IsError)
+	assert.False(t, timeline.Steps[3].IsFallback)
+	assert.True(t, timeline.Steps[4].IsFallback)
+	assert.False(t, timeline.Steps[5].IsFallback)
+	assert.Equal(t, ptr.To[int32](1), timeline.Steps[5].Recommendation)
+}
+
+func TestSimulateScaledObjectScalingModifiers(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"},
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: kedav1alpha1.ScalingModifiers{
+					Formula:          "queue + backlog",
+					Target:           "10",
+					ActivationTarget: "15",
+				},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "10"}},
+				{Type: "rabbitmq", Name: "backlog", Metadata: map[string]string{"value": "10"}},
+			},
+		},
+	}
+	samples := []Sample{
+		{Timestamp: time.Unix(0, 0), Values: map[string]float64{"queue": 5, "backlog": 5}},
+		{Timestamp: time.Unix(30, 0), Values: map[string]float64{"queue": 10, "backlog": 10}},
+		{Timestamp: time.Unix(60, 0), Values: map[string]float64{"queue": 20, "backlog": 20}},
+	}
+
+	timeline, err := SimulateScaledObject(context.Background(), so, samples, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{0, 1, 4}, getReplicas(timeline))
+	assert.Equal(t, ptr.To[float64](10), timeline.Steps[0].FormulaValue)
+	assert.False(t, timeline.Steps[0].IsActive, "the triggers are active but the formula is below the activation target")
+	assert.True(t, timeline.Steps[1].IsActive)
+}
+
+func TestSimulateScaledObjectPaused(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "worker",
+			Namespace:   "default",
+			Annotations: map[string]string{kedav1alpha1.PausedReplicasAnnotation: "3"},
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "10"}},
+			},
+		},
+	}
+
+	timeline, err := SimulateScaledObject(context.Background(), so, newSamples("queue", ptr.To[float64](0), ptr.To[float64](100)), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{3, 3}, getReplicas(timeline))
+	assert.True(t, timeline.Steps[1].IsPaused)
+}
+
+func TestSimulateScaledObjectActivationPolicy(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:   &kedav1alpha1.ScaleTarget{Name: "worker"},
+			ActivationPolicy: &kedav1alpha1.ActivationPolicy{Mode: kedav1alpha1.ActivationPolicyAll},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "10"}},
+				{Type: "rabbitmq", Name: "backlog", Metadata: map[string]string{"value": "10"}},
+			},
+		},
+	}
+	samples := []Sample{
+		{Timestamp: time.Unix(0, 0), Values: map[string]float64{"queue": 20, "backlog": 0}},
+		{Timestamp: time.Unix(30, 0), Values: map[string]float64{"queue": 20, "backlog": 20}},
+	}
+
+	timeline, err := SimulateScaledObject(context.Background(), so, samples, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, timeline.Warnings)
+	assert.Equal(t, []int32{0, 1}, getReplicas(timeline))
+	assert.False(t, timeline.Steps[0].IsActive, "all the triggers must be active")
+	assert.True(t, timeline.Steps[1].IsActive)
+}
+
+func TestSimulateScaledObjectActivationRamp(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "worker"},
+			MaxReplicaCount: ptr.To[int32](8),
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				// the HPA doesn't scale up, the ScaleTarget is only scaled up by the steps of the ramp
+				HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{
+					Behavior: &v2.HorizontalPodAutoscalerBehavior{
+						ScaleUp: &v2.HPAScalingRules{SelectPolicy: ptr.To(v2.DisabledPolicySelect)},
+					},
+				},
+				ActivationRamp: &kedav1alpha1.ActivationRamp{
+					InitialReplicas: 2,
+					StepReplicas:    3,
+					StepInterval:    metav1.Duration{Duration: time.Minute},
+				},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "10"}},
+			},
+		},
+	}
+	samples := newSamples("queue",
+		ptr.To[float64](0),   // 0s inactive
+		ptr.To[float64](100), // 30s activated with the initial replicas
+		ptr.To[float64](100), // 1m0s step not due
+		ptr.To[float64](100), // 1m30s step
+		ptr.To[float64](100), // 2m0s step not due
+		ptr.To[float64](100), // 2m30s step capped by maxReplicaCount, the ramp ends
+	)
+
+	timeline, err := SimulateScaledObject(context.Background(), so, samples, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, timeline.Warnings)
+	assert.Equal(t, []int32{0, 2, 2, 5, 5, 8}, getReplicas(timeline))
+}
+
+func TestSimulateScaledObjectTriggersLastActiveTime(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "worker"},
+			CooldownPeriod: ptr.To[int32](600),
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "10"}},
+			},
+		},
+	}
+	// active at 0s and 30s, the last active time of the trigger is only refreshed every minute,
+	// a tenth of the cooldown period, so it is kept at 0s and the ScaledObject cools down after 10m0s
+	values := []*float64{ptr.To[float64](5), ptr.To[float64](5)}
+	for len(values) < 23 {
+		values = append(values, ptr.To[float64](0))
+	}
+
+	timeline, err := SimulateScaledObject(context.Background(), so, newSamples("queue", values...), Options{})
+	require.NoError(t, err)
+	replicas := getReplicas(timeline)
+	assert.Equal(t, int32(1), replicas[20], "10m0s is still within the cooldown period")
+	assert.Equal(t, int32(0), replicas[21], "10m30s is past the cooldown period")
+}
+
+func TestSimulateScaledJob(t *testing.T) {
+	sj := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledJobSpec{
+			MaxReplicaCount: ptr.To[int32](10),
+			ScalingStrategy: kedav1alpha1.ScalingStrategy{Strategy: "accurate"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "1"}},
+			},
+		},
+	}
+	samples := newSamples("queue",
+		ptr.To[float64](0),  // 0s
+		ptr.To[float64](3),  // 30s 3 jobs created
+		ptr.To[float64](12), // 1m0s capped by maxReplicaCount
+		ptr.To[float64](12), // 1m30s the first jobs completed
+		nil,                 // 2m0s failure
+		ptr.To[float64](2),  // 2m30s
+	)
+
+	timeline, err := SimulateScaledJob(context.Background(), sj, samples, Options{JobDuration: 45 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, "ScaledJob", timeline.Kind)
+	assert.Equal(t, []int32{0, 3, 10, 10, 3, 2}, getReplicas(timeline))
+
+	created := make([]int64, 0, len(timeline.Steps))
+	for _, step := range timeline.Steps {
+		created = append(created, step.CreatedJobs)
+	}
+	assert.Equal(t, []int64{0, 3, 7, 3, 0, 2}, created)
+	assert.True(t, timeline.Steps[4].IsError)
+	assert.False(t, timeline.Steps[4].IsActive)
+}
+
+func TestSimulateScaledJobScalingModifiers(t *testing.T) {
+	sj := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledJobSpec{
+			MaxReplicaCount: ptr.To[int32](10),
+			ScalingModifiers: kedav1alpha1.ScalingModifiers{
+				Formula:          "queue + backlog",
+				Target:           "2",
+				ActivationTarget: "3",
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", Name: "queue", Metadata: map[string]string{"value": "1"}},
+				{Type: "rabbitmq", Name: "backlog", Metadata: map[string]string{"value": "1"}},
+			},
+		},
+	}
+	samples := []Sample{
+		{Timestamp: time.Unix(0, 0), Values: map[string]float64{"queue": 1, "backlog": 1}},
+		{Timestamp: time.Unix(30, 0), Values: map[string]float64{"queue": 4, "backlog": 4}},
+	}
+
+	timeline, err := SimulateScaledJob(context.Background(), sj, samples, Options{JobDuration: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{0, 4}, getReplicas(timeline), "one job per target of the formula")
+	assert.Equal(t, ptr.To[float64](2), timeline.Steps[0].FormulaValue)
+	assert.False(t, timeline.Steps[0].IsActive, "the triggers are active but the formula is below the activation target")
+	assert.True(t, timeline.Steps[1].IsActive)
+}
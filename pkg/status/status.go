@@ -68,6 +68,23 @@ func UpdateScaledObjectStatus(ctx context.Context, client runtimeclient.StatusCl
 	return TransformObject(ctx, client, logger, scaledObject, status, transform)
 }
 
+// UpdateScaledJobStatus patches the given ScaledJob with the updated status passed to it or returns an error.
+func UpdateScaledJobStatus(ctx context.Context, client runtimeclient.StatusClient, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, status *kedav1alpha1.ScaledJobStatus) error {
+	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
+		status, ok := target.(*kedav1alpha1.ScaledJobStatus)
+		if !ok {
+			return fmt.Errorf("transform target is not kedav1alpha1.ScaledJobStatus type %v", target)
+		}
+		switch obj := runtimeObj.(type) {
+		case *kedav1alpha1.ScaledJob:
+			obj.Status = *status
+		default:
+		}
+		return nil
+	}
+	return TransformObject(ctx, client, logger, scaledJob, status, transform)
+}
+
 // getTriggerAuth returns TriggerAuthentication/ClusterTriggerAuthentication object and its status from AuthenticationRef or returns an error.
 func getTriggerAuth(ctx context.Context, client runtimeclient.Client, triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string) (runtimeclient.Object, *kedav1alpha1.TriggerAuthenticationStatus, error) {
 	if triggerAuthRef == nil {
@@ -4,9 +4,9 @@ set -euo pipefail
 LEAD='TRIGGERS-START'
 TAIL='TRIGGERS-END'
 
-SCALERS_FILE="pkg/scaling/scalers_builder.go"
-CURRENT=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep "case")
-SORTED=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep "case" | sort)
+SCALERS_FILE="pkg/scalers/scalers_registry.go"
+CURRENT=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep "Name:")
+SORTED=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep "Name:" | sort)
 
 if [[ "${CURRENT}" == "${SORTED}" ]]; then
   echo "Scalers are sorted in ${SCALERS_FILE}"